@@ -5,6 +5,7 @@ set -eu
 
 DEBUG="${DEBUG:-false}"
 TERRAFORM_VERSION="${TERRAFORM_VERSION:-}"
+TERRAFORM_BINARY="${TERRAFORM_BINARY:-terraform}"
 EXTERNAL_ENCODED_VPN="${EXTERNAL_ENCODED_VPN:-}"
 VPN_ENCODED_LOGIN="${VPN_ENCODED_LOGIN:-}"
 RKE_PROVIDER_VERSION="${RKE_PROVIDER_VERSION:-}"
@@ -23,6 +24,7 @@ count=0
 while [[ 3 -gt $count ]]; do
     docker build . -f Dockerfile --build-arg CONFIG_FILE=config.yml --build-arg PEM_FILE=key.pem \
                                                                     --build-arg TERRAFORM_VERSION="$TERRAFORM_VERSION" \
+                                                                    --build-arg TERRAFORM_BINARY="$TERRAFORM_BINARY" \
                                                                     --build-arg RKE_PROVIDER_VERSION="$RKE_PROVIDER_VERSION" \
                                                                     --build-arg RANCHER2_PROVIDER_VERSION="$RANCHER2_PROVIDER_VERSION" \
                                                                     --build-arg LOCALS_PROVIDER_VERSION="$LOCALS_PROVIDER_VERSION" \
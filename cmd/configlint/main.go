@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/config/schema"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	printSchema := flag.Bool("schema", false, "print the JSON Schema of the cattle-config file and exit")
+	standalone := flag.Bool("standalone", false, "also check the fields needed to stand up a standalone Rancher server")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-standalone] <cattle-config.yaml>...\n       %s -schema\n", os.Args[0], os.Args[0])
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if *printSchema {
+		content, err := schema.Generate().JSON()
+		if err != nil {
+			logrus.Fatalf("Failed to generate the JSON Schema. Error: %v", err)
+		}
+
+		os.Stdout.Write(content)
+		return
+	}
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	failed := false
+
+	for _, file := range flag.Args() {
+		document, err := config.LoadConfigFromFile(file)
+		if err != nil {
+			logrus.Errorf("Failed to load %s. Error: %v", file, err)
+			failed = true
+			continue
+		}
+
+		problems := schema.Lint(document, *standalone)
+
+		for _, problem := range problems {
+			fmt.Printf("%s: %s\n", file, problem.Error())
+		}
+
+		if len(problems) > 0 {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
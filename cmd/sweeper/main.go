@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/redact"
+	"github.com/rancher/tfp-automation/framework/sweeper"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	awsPlatform     = "aws"
+	rancherPlatform = "rancher"
+)
+
+func main() {
+	pattern := flag.String("pattern", "", "shell pattern, such as tfp-*, matched against the resourcePrefix names of the resources to sweep; test users are not matched against it")
+	minAge := flag.Duration("min-age", 6*time.Hour, "only sweep resources created at least this long ago")
+	users := flag.Bool("users", false, "also sweep every testuser-* user older than -min-age, whatever the pattern, as test users are not named after the resourcePrefix")
+	dryRun := flag.Bool("dry-run", false, "log the resources that would be deleted without deleting them")
+	platforms := flag.String("platforms", rancherPlatform+","+awsPlatform, "comma separated platforms to sweep, rancher and aws")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s -pattern <pattern> [-min-age <duration>] [-users] [-dry-run] [-platforms rancher,aws]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "The Rancher server and AWS credentials are read from the cattle-config set by %s.\n", shepherdConfig.ConfigEnvironmentKey)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	options := sweeper.Options{Pattern: *pattern, MinAge: *minAge, Users: *users, DryRun: *dryRun}
+
+	err := options.Validate()
+	if err != nil {
+		flag.Usage()
+		logrus.Fatalf("Invalid options. Error: %v", err)
+	}
+
+	swept, err := sweep(options, strings.Split(*platforms, ","))
+
+	if options.DryRun {
+		logrus.Infof("Dry run: %d resources would be deleted.", len(swept))
+	} else {
+		logrus.Infof("Deleted %d resources.", len(swept))
+	}
+
+	if err != nil {
+		logrus.Errorf("Failed to sweep every resource. Error: %v", err)
+		os.Exit(1)
+	}
+}
+
+// sweep sweeps the given platforms with the given options, using the cattle-config set by CATTLE_TEST_CONFIG. The
+// merged config, which holds the resolved secrets, is removed once done.
+func sweep(options sweeper.Options, platforms []string) ([]sweeper.Resource, error) {
+	dir, err := os.MkdirTemp("", "sweeper-")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(dir)
+
+	cattleConfig, mergedConfig, err := framework.LoadMergedConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	redact.Register(cattleConfig)
+
+	os.Setenv(shepherdConfig.ConfigEnvironmentKey, mergedConfig)
+	os.Setenv(config.OverlaysEnvironmentKey, "")
+
+	_, terraformConfig, _ := config.LoadTFPConfigs(cattleConfig)
+
+	var sweepers []sweeper.Sweeper
+
+	for _, platform := range platforms {
+		switch strings.TrimSpace(platform) {
+		case rancherPlatform:
+			client, err := rancher.NewClient("", session.NewSession())
+			if err != nil {
+				return nil, err
+			}
+
+			sweepers = append(sweepers, sweeper.NewRancherSweeper(client))
+		case awsPlatform:
+			awsSweeper, err := sweeper.NewAWSSweeper(terraformConfig)
+			if err != nil {
+				return nil, err
+			}
+
+			sweepers = append(sweepers, awsSweeper)
+		default:
+			return nil, fmt.Errorf("unsupported platform %s, use %s or %s", platform, rancherPlatform, awsPlatform)
+		}
+	}
+
+	return sweeper.Sweep(sweepers, options)
+}
@@ -87,6 +87,36 @@ func (c TestClientName) String() string {
 	return string(c)
 }
 
+type Backend struct {
+	HTTP *HTTPBackend `json:"http,omitempty" yaml:"http,omitempty"`
+	PG   *PGBackend   `json:"pg,omitempty" yaml:"pg,omitempty"`
+	S3   *S3Backend   `json:"s3,omitempty" yaml:"s3,omitempty"`
+}
+
+type HTTPBackend struct {
+	Address       string `json:"address,omitempty" yaml:"address,omitempty"`
+	LockAddress   string `json:"lockAddress,omitempty" yaml:"lockAddress,omitempty"`
+	Password      string `json:"password,omitempty" yaml:"password,omitempty"`
+	UnlockAddress string `json:"unlockAddress,omitempty" yaml:"unlockAddress,omitempty"`
+	Username      string `json:"username,omitempty" yaml:"username,omitempty"`
+}
+
+type PGBackend struct {
+	ConnStr    string `json:"connStr,omitempty" yaml:"connStr,omitempty"`
+	SchemaName string `json:"schemaName,omitempty" yaml:"schemaName,omitempty"`
+}
+
+type S3Backend struct {
+	AccessKey          string `json:"accessKey,omitempty" yaml:"accessKey,omitempty"`
+	Bucket             string `json:"bucket,omitempty" yaml:"bucket,omitempty"`
+	Endpoint           string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	Key                string `json:"key,omitempty" yaml:"key,omitempty"`
+	Region             string `json:"region,omitempty" yaml:"region,omitempty"`
+	SecretKey          string `json:"secretKey,omitempty" yaml:"secretKey,omitempty"`
+	UseLockfile        bool   `json:"useLockfile,omitempty" yaml:"useLockfile,omitempty"`
+	WorkspaceKeyPrefix string `json:"workspaceKeyPrefix,omitempty" yaml:"workspaceKeyPrefix,omitempty"`
+}
+
 type Nodepool struct {
 	Quantity         int64  `json:"quantity,omitempty" yaml:"quantity,omitempty"`
 	Etcd             bool   `json:"etcd,omitempty" yaml:"etcd,omitempty"`
@@ -99,6 +129,20 @@ type Nodepool struct {
 	MaxPodsContraint int64  `json:"maxPodsContraint,omitempty" yaml:"maxPodsContraint,omitempty"`
 }
 
+type Provider struct {
+	IgnoreVersionEnvVar bool   `json:"ignoreVersionEnvVar,omitempty" yaml:"ignoreVersionEnvVar,omitempty"`
+	Mirror              string `json:"mirror,omitempty" yaml:"mirror,omitempty"`
+	Source              string `json:"source,omitempty" yaml:"source,omitempty"`
+	Version             string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+type Providers struct {
+	AWS      *Provider `json:"aws,omitempty" yaml:"aws,omitempty"`
+	Local    *Provider `json:"local,omitempty" yaml:"local,omitempty"`
+	Rancher2 *Provider `json:"rancher2,omitempty" yaml:"rancher2,omitempty"`
+	RKE      *Provider `json:"rke,omitempty" yaml:"rke,omitempty"`
+}
+
 type Proxy struct {
 	ProxyBastion string `json:"proxyBastion,omitempty" yaml:"proxyBastion,omitempty"`
 }
@@ -147,6 +191,16 @@ type StandaloneRegistry struct {
 	UpgradedAssetsPath string `json:"upgradedAssetsPath,omitempty" yaml:"upgradedAssetsPath,omitempty"`
 }
 
+type Tags struct {
+	BuildNumber string            `json:"buildNumber,omitempty" yaml:"buildNumber,omitempty"`
+	Custom      map[string]string `json:"custom,omitempty" yaml:"custom,omitempty"`
+	ExpiresAt   string            `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+	JobName     string            `json:"jobName,omitempty" yaml:"jobName,omitempty"`
+	Owner       string            `json:"owner,omitempty" yaml:"owner,omitempty"`
+	TestName    string            `json:"testName,omitempty" yaml:"testName,omitempty"`
+	TTL         string            `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+}
+
 type TerraformConfig struct {
 	AWSConfig                           aws.Config                   `json:"awsConfig,omitempty" yaml:"awsConfig,omitempty"`
 	AWSCredentials                      aws.Credentials              `json:"awsCredentials,omitempty" yaml:"awsCredentials,omitempty"`
@@ -166,6 +220,7 @@ type TerraformConfig struct {
 	OktaConfig                          authproviders.OktaConfig     `json:"oktaConfig,omitempty" yaml:"oktaConfig,omitempty"`
 	OpenLDAPConfig                      authproviders.OpenLDAPConfig `json:"openLDAPConfig,omitempty" yaml:"openLDAPConfig,omitempty"`
 	AuthProvider                        string                       `json:"authProvider,omitempty" yaml:"authProvider,omitempty"`
+	Backend                             *Backend                     `json:"backend,omitempty" yaml:"backend,omitempty"`
 	ResourcePrefix                      string                       `json:"resourcePrefix,omitempty" yaml:"resourcePrefix,omitempty"`
 	CNI                                 string                       `json:"cni,omitempty" yaml:"cni,omitempty"`
 	ChartValues                         string                       `json:"chartValues,omitempty" yaml:"chartValues,omitempty"`
@@ -176,11 +231,14 @@ type TerraformConfig struct {
 	ETCDRKE1                            *management.ETCDService      `json:"etcdRKE1,omitempty" yaml:"etcdRKE1,omitempty"`
 	Module                              string                       `json:"module,omitempty" yaml:"module,omitempty"`
 	NetworkPlugin                       string                       `json:"networkPlugin,omitempty" yaml:"networkPlugin,omitempty"`
+	OutputFormat                        string                       `json:"outputFormat,omitempty" yaml:"outputFormat,omitempty"`
 	PrivateKeyPath                      string                       `json:"privateKeyPath,omitempty" yaml:"privateKeyPath,omitempty"`
 	PrivateRegistries                   *PrivateRegistries           `json:"privateRegistries,omitempty" yaml:"privateRegistries,omitempty"`
+	Providers                           *Providers                   `json:"providers,omitempty" yaml:"providers,omitempty"`
 	Proxy                               *Proxy                       `json:"proxy,omitempty" yaml:"proxy,omitempty"`
 	Standalone                          *Standalone                  `json:"standalone,omitempty" yaml:"standalone,omitempty"`
 	StandaloneRegistry                  *StandaloneRegistry          `json:"standaloneRegistry,omitempty" yaml:"standaloneRegistry,omitempty"`
+	Tags                                *Tags                        `json:"tags,omitempty" yaml:"tags,omitempty"`
 	TimeSleep                           string                       `json:"timeSleep,omitempty" yaml:"timeSleep,omitempty"`
 	WindowsPrivateKeyPath               string                       `json:"windowsPrivateKeyPath,omitempty" yaml:"windowsPrivateKeyPath,omitempty"`
 }
@@ -202,18 +260,29 @@ type Snapshots struct {
 	WorkerConcurrencyValue       string `json:"workerConcurrencyValue,omitempty" yaml:"workerConcurrencyValue,omitempty"`
 }
 
+type ProviderMirror struct {
+	Offline bool   `json:"offline,omitempty" yaml:"offline,omitempty"`
+	Path    string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
 type TerratestConfig struct {
-	KubernetesVersion         string     `json:"kubernetesVersion,omitempty" yaml:"kubernetesVersion,omitempty"`
-	LocalQaseReporting        bool       `json:"localQaseReporting,omitempty" yaml:"localQaseReporting,omitempty" default:"false"`
-	NodeCount                 int64      `json:"nodeCount,omitempty" yaml:"nodeCount,omitempty"`
-	Nodepools                 []Nodepool `json:"nodepools,omitempty" yaml:"nodepools,omitempty"`
-	PSACT                     string     `json:"psact,omitempty" yaml:"psact,omitempty"`
-	ScalingInput              Scaling    `json:"scalingInput,omitempty" yaml:"scalingInput,omitempty"`
-	SnapshotInput             Snapshots  `json:"snapshotInput,omitempty" yaml:"snapshotInput,omitempty"`
-	StandaloneLogging         bool       `json:"standaloneLogging,omitempty" yaml:"standaloneLogging,omitempty"`
-	TFLogging                 bool       `json:"tfLogging,omitempty" yaml:"tfLogging,omitempty"`
-	UpgradedKubernetesVersion string     `json:"upgradedKubernetesVersion,omitempty" yaml:"upgradedKubernetesVersion,omitempty"`
-	WindowsNodeCount          int64      `json:"windowsNodeCount,omitempty" yaml:"windowsNodeCount,omitempty"`
+	ArtifactsDir              string          `json:"artifactsDir,omitempty" yaml:"artifactsDir,omitempty"`
+	DestroyClusters           []string        `json:"destroyClusters,omitempty" yaml:"destroyClusters,omitempty"`
+	ForceCleanupMinAge        string          `json:"forceCleanupMinAge,omitempty" yaml:"forceCleanupMinAge,omitempty"`
+	KubernetesVersion         string          `json:"kubernetesVersion,omitempty" yaml:"kubernetesVersion,omitempty"`
+	LocalQaseReporting        bool            `json:"localQaseReporting,omitempty" yaml:"localQaseReporting,omitempty" default:"false"`
+	NodeCount                 int64           `json:"nodeCount,omitempty" yaml:"nodeCount,omitempty"`
+	Nodepools                 []Nodepool      `json:"nodepools,omitempty" yaml:"nodepools,omitempty"`
+	ProviderMirror            *ProviderMirror `json:"providerMirror,omitempty" yaml:"providerMirror,omitempty"`
+	PSACT                     string          `json:"psact,omitempty" yaml:"psact,omitempty"`
+	ScalingInput              Scaling         `json:"scalingInput,omitempty" yaml:"scalingInput,omitempty"`
+	SnapshotInput             Snapshots       `json:"snapshotInput,omitempty" yaml:"snapshotInput,omitempty"`
+	StandaloneLogging         bool            `json:"standaloneLogging,omitempty" yaml:"standaloneLogging,omitempty"`
+	TFLogging                 bool            `json:"tfLogging,omitempty" yaml:"tfLogging,omitempty"`
+	UpgradedKubernetesVersion string          `json:"upgradedKubernetesVersion,omitempty" yaml:"upgradedKubernetesVersion,omitempty"`
+	UpgradedProviders         *Providers      `json:"upgradedProviders,omitempty" yaml:"upgradedProviders,omitempty"`
+	WindowsNodeCount          int64           `json:"windowsNodeCount,omitempty" yaml:"windowsNodeCount,omitempty"`
+	WorkingDirRoot            string          `json:"workingDirRoot,omitempty" yaml:"workingDirRoot,omitempty"`
 }
 
 // LoadTFPConfigs loads the TFP configurations from the provided map
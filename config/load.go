@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ExtendsKey is the top level key of a cattle-config file listing the files it is layered on top of.
+	ExtendsKey = "extends"
+	// OverlaysEnvironmentKey is the environment variable holding the overlay files applied on top of CATTLE_TEST_CONFIG.
+	OverlaysEnvironmentKey = "CATTLE_TEST_CONFIG_OVERLAYS"
+)
+
+// LoadConfigFromFiles is a function that will load the given base cattle-config file followed by the given overlay
+// files, merging each one on top of the previous with Merge.
+func LoadConfigFromFiles(base string, overlays ...string) (map[string]any, error) {
+	cattleConfig, err := LoadConfigFromFile(base)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, overlay := range overlays {
+		overlayConfig, err := LoadConfigFromFile(overlay)
+		if err != nil {
+			return nil, err
+		}
+
+		cattleConfig = Merge(cattleConfig, overlayConfig)
+	}
+
+	return cattleConfig, nil
+}
+
+// LoadConfigFromFile is a function that will load the given cattle-config file. If the file has an extends key, the
+// files it lists, relative to the file itself, are loaded first in order and the file is merged on top of them.
+func LoadConfigFromFile(path string) (map[string]any, error) {
+	return loadConfigFromFile(path, map[string]bool{})
+}
+
+// loadConfigFromFile loads the given cattle-config file, tracking the files being loaded to catch extends cycles.
+func loadConfigFromFile(path string, loading map[string]bool) (map[string]any, error) {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if loading[absolutePath] {
+		return nil, fmt.Errorf("cattle-config file %s extends itself", path)
+	}
+
+	loading[absolutePath] = true
+	defer delete(loading, absolutePath)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cattleConfig := map[string]any{}
+
+	err = yaml.Unmarshal(content, &cattleConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cattle-config file %s: %w", path, err)
+	}
+
+	parents, err := extends(cattleConfig[ExtendsKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s in cattle-config file %s: %w", ExtendsKey, path, err)
+	}
+
+	delete(cattleConfig, ExtendsKey)
+
+	merged := map[string]any{}
+	for _, parent := range parents {
+		if !filepath.IsAbs(parent) {
+			parent = filepath.Join(filepath.Dir(path), parent)
+		}
+
+		parentConfig, err := loadConfigFromFile(parent, loading)
+		if err != nil {
+			return nil, err
+		}
+
+		merged = Merge(merged, parentConfig)
+	}
+
+	return Merge(merged, cattleConfig), nil
+}
+
+// extends returns the files listed by the value of an extends key, which is either a single file or a list of files.
+func extends(value any) ([]string, error) {
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{value}, nil
+	case []any:
+		files := []string{}
+		for _, file := range value {
+			fileName, ok := file.(string)
+			if !ok {
+				return nil, fmt.Errorf("must be a file or a list of files")
+			}
+
+			files = append(files, fileName)
+		}
+
+		return files, nil
+	default:
+		return nil, fmt.Errorf("must be a file or a list of files")
+	}
+}
+
+// Merge is a function that will return the result of layering the overlay on top of the base config, without
+// modifying either. Maps are merged key by key, while lists and other values in the overlay replace the base value
+// as a whole. A key set to null in the overlay is removed.
+func Merge(base, overlay map[string]any) map[string]any {
+	merged := make(map[string]any, len(base))
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range overlay {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+
+		baseMap, baseIsMap := merged[key].(map[string]any)
+		overlayMap, overlayIsMap := value.(map[string]any)
+
+		if baseIsMap && overlayIsMap {
+			merged[key] = Merge(baseMap, overlayMap)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const baseConfig = `
+rancher:
+  host: rancher.example.com
+  insecure: true
+terraform:
+  resourcePrefix: tfp
+  awsCredentials:
+    awsAccessKey: access-key
+    awsSecretKey: secret-key
+  awsConfig:
+    region: us-east-2
+    awsSecurityGroupNames:
+      - base-sg
+      - shared-sg
+terratest:
+  nodeCount: 3
+`
+
+func writeConfig(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestLoadConfigFromFilesOverlays(t *testing.T) {
+	dir := t.TempDir()
+
+	base := writeConfig(t, dir, "base.yaml", baseConfig)
+	overlay := writeConfig(t, dir, "ec2_rke2.yaml", `
+terraform:
+  module: ec2_rke2
+  awsConfig:
+    awsSecurityGroupNames:
+      - rke2-sg
+terratest:
+  nodeCount: null
+`)
+
+	cattleConfig, err := LoadConfigFromFiles(base, overlay)
+	require.NoError(t, err)
+
+	rancherConfig, terraformConfig, terratestConfig := LoadTFPConfigs(cattleConfig)
+	require.Equal(t, "rancher.example.com", rancherConfig.Host)
+	require.Equal(t, "ec2_rke2", terraformConfig.Module)
+	require.Equal(t, "access-key", terraformConfig.AWSCredentials.AWSAccessKey)
+	require.Equal(t, "us-east-2", terraformConfig.AWSConfig.Region)
+	require.Equal(t, []string{"rke2-sg"}, terraformConfig.AWSConfig.AWSSecurityGroupNames)
+	require.Zero(t, terratestConfig.NodeCount)
+}
+
+func TestLoadConfigFromFileExtends(t *testing.T) {
+	dir := t.TempDir()
+
+	writeConfig(t, dir, "base.yaml", baseConfig)
+	writeConfig(t, dir, "linode.yaml", `
+terraform:
+  linodeCredentials:
+    linodeToken: token
+`)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "modules"), 0755))
+	module := writeConfig(t, filepath.Join(dir, "modules"), "linode_k3s.yaml", `
+extends:
+  - ../base.yaml
+  - ../linode.yaml
+terraform:
+  module: linode_k3s
+`)
+
+	cattleConfig, err := LoadConfigFromFile(module)
+	require.NoError(t, err)
+	require.NotContains(t, cattleConfig, ExtendsKey)
+
+	_, terraformConfig, _ := LoadTFPConfigs(cattleConfig)
+	require.Equal(t, "linode_k3s", terraformConfig.Module)
+	require.Equal(t, "tfp", terraformConfig.ResourcePrefix)
+	require.Equal(t, "token", terraformConfig.LinodeCredentials.LinodeToken)
+}
+
+func TestLoadConfigFromFileExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+
+	first := writeConfig(t, dir, "first.yaml", "extends: second.yaml\n")
+	writeConfig(t, dir, "second.yaml", "extends: first.yaml\n")
+
+	_, err := LoadConfigFromFile(first)
+	require.ErrorContains(t, err, "extends itself")
+}
+
+func TestMergeDoesNotModifyInputs(t *testing.T) {
+	base := map[string]any{"terraform": map[string]any{"module": "ec2_rke2", "cni": "calico"}}
+	overlay := map[string]any{"terraform": map[string]any{"module": "ec2_k3s"}}
+
+	merged := Merge(base, overlay)
+
+	require.Equal(t, map[string]any{"terraform": map[string]any{"module": "ec2_k3s", "cni": "calico"}}, merged)
+	require.Equal(t, "ec2_rke2", base["terraform"].(map[string]any)["module"])
+}
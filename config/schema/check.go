@@ -0,0 +1,129 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/rancher/tfp-automation/config"
+)
+
+// Check is a function that will check the given decoded cattle-config document against the schema. Unknown keys
+// and values of the wrong type are returned together as ValidationErrors, or nil if there are none.
+func (s *Schema) Check(document any) config.ValidationErrors {
+	var problems config.ValidationErrors
+
+	s.check(document, "", &problems)
+
+	return problems
+}
+
+// check records the problems found in the given value, which is found at the given YAML path.
+func (s *Schema) check(value any, path string, problems *config.ValidationErrors) {
+	if value == nil || s.Type == "" {
+		return
+	}
+
+	add := func(message string) {
+		*problems = append(*problems, config.ValidationError{Path: path, Message: message})
+	}
+
+	switch s.Type {
+	case typeObject:
+		object, ok := value.(map[string]any)
+		if !ok {
+			add(fmt.Sprintf("must be an object, got %s", describe(value)))
+			return
+		}
+
+		s.checkObject(object, path, problems)
+	case typeArray:
+		array, ok := value.([]any)
+		if !ok {
+			add(fmt.Sprintf("must be a list, got %s", describe(value)))
+			return
+		}
+
+		for i, item := range array {
+			s.Items.check(item, fmt.Sprintf("%s[%d]", path, i), problems)
+		}
+	case typeString:
+		if _, ok := value.(string); !ok {
+			add(fmt.Sprintf("must be a string, got %s", describe(value)))
+		}
+	case typeBoolean:
+		if _, ok := value.(bool); !ok {
+			add(fmt.Sprintf("must be a boolean, got %s", describe(value)))
+		}
+	case typeInteger:
+		if number, ok := value.(float64); !ok || number != math.Trunc(number) {
+			add(fmt.Sprintf("must be an integer, got %s", describe(value)))
+		}
+	case typeNumber:
+		if _, ok := value.(float64); !ok {
+			add(fmt.Sprintf("must be a number, got %s", describe(value)))
+		}
+	}
+}
+
+// checkObject records the problems found in the keys of the given object, in key order.
+func (s *Schema) checkObject(object map[string]any, path string, problems *config.ValidationErrors) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		if property, ok := s.Properties[key]; ok {
+			property.check(object[key], keyPath, problems)
+			continue
+		}
+
+		switch additional := s.AdditionalProperties.(type) {
+		case *Schema:
+			additional.check(object[key], keyPath, problems)
+		case bool:
+			if !additional {
+				*problems = append(*problems, config.ValidationError{Path: keyPath, Message: s.unknownKey(key)})
+			}
+		}
+	}
+}
+
+// unknownKey returns the message for a key that is not part of the schema, pointing to the known key it most likely
+// meant when the two only differ in case.
+func (s *Schema) unknownKey(key string) string {
+	for property := range s.Properties {
+		if strings.EqualFold(property, key) {
+			return fmt.Sprintf("is not a known key, did you mean %q?", property)
+		}
+	}
+
+	return "is not a known key"
+}
+
+// describe returns the JSON type name of the given decoded value.
+func describe(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return typeObject
+	case []any:
+		return "list"
+	case string:
+		return typeString
+	case bool:
+		return typeBoolean
+	case float64:
+		return typeNumber
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
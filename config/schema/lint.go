@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"errors"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"sigs.k8s.io/yaml"
+)
+
+// Lint is a function that will check the given decoded cattle-config file against the schema and, when every
+// section decodes, against the rules of the configured module. If standalone is set, the file is also checked for the
+// fields needed to stand up a standalone Rancher server. Every problem found is returned at once.
+func Lint(document map[string]any, standalone bool) config.ValidationErrors {
+	problems := Generate().Check(document)
+
+	rancherConfig := new(rancher.Config)
+	terraformConfig := new(config.TerraformConfig)
+	terratestConfig := new(config.TerratestConfig)
+
+	for key, object := range map[string]any{
+		configs.Rancher:                      rancherConfig,
+		config.TerraformConfigurationFileKey: terraformConfig,
+		config.TerratestConfigurationFileKey: terratestConfig,
+	} {
+		err := decode(document[key], object)
+		if err != nil {
+			// The values of the wrong type have already been reported by the schema check.
+			return problems
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, problem := range problems {
+		seen[problem.Path] = true
+	}
+
+	rules := []error{config.Validate(terraformConfig.Module, rancherConfig, terraformConfig, terratestConfig)}
+	if standalone {
+		rules = append(rules, config.ValidateStandalone(terraformConfig))
+	}
+
+	for _, err := range rules {
+		var validationErrors config.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			continue
+		}
+
+		for _, problem := range validationErrors {
+			if !seen[problem.Path] {
+				seen[problem.Path] = true
+				problems = append(problems, problem)
+			}
+		}
+	}
+
+	return problems
+}
+
+// decode decodes a section of the cattle-config file the same way the shepherd config helpers do, returning an error
+// instead of panicking.
+func decode(section any, object any) error {
+	content, err := yaml.Marshal(section)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(content, object)
+}
@@ -0,0 +1,152 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+)
+
+const (
+	draft = "http://json-schema.org/draft-07/schema#"
+	title = "tfp-automation cattle-config"
+
+	typeArray   = "array"
+	typeBoolean = "boolean"
+	typeInteger = "integer"
+	typeNumber  = "number"
+	typeObject  = "object"
+	typeString  = "string"
+)
+
+var (
+	jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Schema is the subset of JSON Schema needed to describe the cattle-config file.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+// Generate is a function that will build the JSON Schema of the cattle-config file from the struct tags of the
+// rancher, terraform and terratest configs. Sections of the file that tfp-automation does not read are left open.
+func Generate() *Schema {
+	return &Schema{
+		Schema: draft,
+		Title:  title,
+		Type:   typeObject,
+		Properties: map[string]*Schema{
+			configs.Rancher:                      forType(reflect.TypeOf(rancher.Config{}), map[reflect.Type]bool{}),
+			config.TerraformConfigurationFileKey: forType(reflect.TypeOf(config.TerraformConfig{}), map[reflect.Type]bool{}),
+			config.TerratestConfigurationFileKey: forType(reflect.TypeOf(config.TerratestConfig{}), map[reflect.Type]bool{}),
+		},
+		AdditionalProperties: true,
+	}
+}
+
+// JSON is a function that will return the indented JSON encoding of the schema.
+func (s *Schema) JSON() ([]byte, error) {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(content, '\n'), nil
+}
+
+// forType returns the schema of the given Go type, as it is decoded from YAML by the shepherd config helpers. Types
+// that decode themselves, and types already being described further up, are left open.
+func forType(goType reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	for goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+
+	if reflect.PointerTo(goType).Implements(jsonUnmarshaler) || reflect.PointerTo(goType).Implements(textUnmarshaler) {
+		return &Schema{}
+	}
+
+	switch goType.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: typeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: typeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: typeNumber}
+	case reflect.String:
+		return &Schema{Type: typeString}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: typeArray, Items: forType(goType.Elem(), visiting)}
+	case reflect.Map:
+		return &Schema{Type: typeObject, AdditionalProperties: forType(goType.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[goType] {
+			return &Schema{Type: typeObject}
+		}
+
+		visiting[goType] = true
+		defer delete(visiting, goType)
+
+		schema := &Schema{Type: typeObject, Properties: map[string]*Schema{}, AdditionalProperties: false}
+		addFields(schema, goType, visiting)
+
+		return schema
+	default:
+		return &Schema{}
+	}
+}
+
+// addFields adds the properties of the fields of the given struct type to the schema, following the encoding/json
+// rules for field names and embedded structs.
+func addFields(schema *Schema, structType reflect.Type, visiting map[reflect.Type]bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		name, ok := fieldName(field)
+		if !ok {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			addFields(schema, fieldType, visiting)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = forType(field.Type, visiting)
+	}
+}
+
+// fieldName returns the JSON name of the given field, or false if the field is never decoded.
+func fieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+
+	return name, true
+}
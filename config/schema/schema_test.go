@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const schemaFile = "cattle-config.schema.json"
+
+var update = flag.Bool("update", false, "regenerate the checked-in JSON Schema")
+
+const validConfig = `
+rancher:
+  host: rancher.example.com
+  insecure: true
+terraform:
+  module: linode_k3s
+  resourcePrefix: tfp
+  linodeCredentials:
+    linodeToken: token
+  linodeConfig:
+    linodeImage: linode/ubuntu22.04
+    region: us-east
+terratest:
+  nodepools:
+    - quantity: 1
+      etcd: true
+      controlplane: true
+      worker: true
+`
+
+func TestSchemaIsUpToDate(t *testing.T) {
+	content, err := Generate().JSON()
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile(schemaFile, content, 0644))
+	}
+
+	expected, err := os.ReadFile(schemaFile)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(content), "run go test ./config/schema/ -update to regenerate %s", schemaFile)
+}
+
+func TestLint(t *testing.T) {
+	problems := Lint(parse(t, validConfig), false)
+	require.Empty(t, problems)
+}
+
+func TestLintUnknownKeysAndTypes(t *testing.T) {
+	content := validConfig + `
+  nodeCount: three
+  snapshotInput:
+    createsnapshot: true
+  tfLoging: true
+`
+
+	problems := Lint(parse(t, content), false)
+
+	require.Equal(t, []string{
+		"terratest.nodeCount: must be an integer, got string",
+		`terratest.snapshotInput.createsnapshot: is not a known key, did you mean "createSnapshot"?`,
+		"terratest.tfLoging: is not a known key",
+	}, messages(problems))
+}
+
+func TestLintModuleRules(t *testing.T) {
+	content := validConfig + `
+otherSuite:
+  anything: true
+`
+
+	problems := Lint(parse(t, content), true)
+	require.Contains(t, messages(problems), "terraform.standalone: is required for a standalone Rancher server")
+
+	problems = Lint(parse(t, "terraform:\n  module: linode_k3s\n"), false)
+	require.Contains(t, messages(problems), "terraform.linodeCredentials.linodeToken: is required for module linode_k3s")
+}
+
+func parse(t *testing.T, content string) map[string]any {
+	document := map[string]any{}
+	require.NoError(t, yaml.Unmarshal([]byte(content), &document))
+
+	return document
+}
+
+func messages(problems config.ValidationErrors) []string {
+	messages := []string{}
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+
+	return messages
+}
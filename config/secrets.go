@@ -0,0 +1,181 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	EnvScheme    = "env"
+	FileScheme   = "file"
+	SecretScheme = "secret"
+
+	schemeSeparator = "://"
+)
+
+// SecretResolver is the interface implemented for each scheme a secret reference in the config can use.
+type SecretResolver interface {
+	// Resolve returns the value the reference points to. The reference is given without its scheme.
+	Resolve(reference string) (string, error)
+}
+
+// SecretStore is the interface implemented by the backends secret://<store>/<key> references are resolved from.
+type SecretStore interface {
+	// Secret returns the value of the secret with the given key.
+	Secret(key string) (string, error)
+}
+
+var (
+	secretsMutex    sync.RWMutex
+	secretResolvers = map[string]SecretResolver{
+		EnvScheme:    envResolver{},
+		FileScheme:   fileResolver{},
+		SecretScheme: storeResolver{},
+	}
+	secretStores = map[string]SecretStore{}
+)
+
+// RegisterSecretResolver is a function that will register the resolver for the references with the given scheme,
+// replacing any resolver already registered for it.
+func RegisterSecretResolver(scheme string, resolver SecretResolver) {
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+
+	secretResolvers[scheme] = resolver
+}
+
+// RegisterSecretStore is a function that will register the store secret://<name>/<key> references are resolved from,
+// replacing any store already registered with the same name.
+func RegisterSecretStore(name string, store SecretStore) {
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+
+	secretStores[name] = store
+}
+
+// ResolveSecrets is a function that will replace every string value of the given cattle-config that is a secret
+// reference, such as env://NAME, file:///path or secret://store/key, with the value it points to. Values using any
+// other scheme are left untouched. Every reference that fails to resolve is returned at once as ValidationErrors.
+func ResolveSecrets(cattleConfig map[string]any) error {
+	var problems ValidationErrors
+
+	resolveSecrets(cattleConfig, "", &problems)
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return problems
+}
+
+// resolveSecrets resolves the secret references found in the given value, which is found at the given YAML path.
+func resolveSecrets(value any, path string, problems *ValidationErrors) any {
+	switch value := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+
+			value[key] = resolveSecrets(value[key], keyPath, problems)
+		}
+	case []any:
+		for i := range value {
+			value[i] = resolveSecrets(value[i], fmt.Sprintf("%s[%d]", path, i), problems)
+		}
+	case string:
+		scheme, reference, ok := strings.Cut(value, schemeSeparator)
+		if !ok {
+			return value
+		}
+
+		secretsMutex.RLock()
+		resolver, ok := secretResolvers[scheme]
+		secretsMutex.RUnlock()
+
+		if !ok {
+			return value
+		}
+
+		secret, err := resolver.Resolve(reference)
+		if err != nil {
+			*problems = append(*problems, ValidationError{Path: path, Message: fmt.Sprintf("failed to resolve %s: %v", value, err)})
+			return value
+		}
+
+		return secret
+	}
+
+	return value
+}
+
+// envResolver resolves env://NAME references from the environment.
+type envResolver struct{}
+
+func (envResolver) Resolve(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	return value, nil
+}
+
+// fileResolver resolves file:///path references from the contents of the file, without its trailing newline.
+type fileResolver struct{}
+
+func (fileResolver) Resolve(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// storeResolver resolves secret://<store>/<key> references from the registered secret stores.
+type storeResolver struct{}
+
+func (storeResolver) Resolve(reference string) (string, error) {
+	name, key, ok := strings.Cut(reference, "/")
+	if !ok || key == "" {
+		return "", fmt.Errorf("reference must be of the form %s%s<store>/<key>", SecretScheme, schemeSeparator)
+	}
+
+	secretsMutex.RLock()
+	store, ok := secretStores[name]
+	secretsMutex.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("no secret store registered with the name %s", name)
+	}
+
+	return store.Secret(key)
+}
+
+// FileSecretStore is a SecretStore that keeps each secret in its own file under a directory, named after its key. It
+// stands in for a real secret store in tests and local runs.
+type FileSecretStore struct {
+	Dir string
+}
+
+// Secret returns the contents of the file named after the given key, without its trailing newline.
+func (s FileSecretStore) Secret(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid secret key %s", key)
+	}
+
+	return fileResolver{}.Resolve(filepath.Join(s.Dir, filepath.FromSlash(key)))
+}
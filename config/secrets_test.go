@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecrets(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Setenv("TFP_TEST_AWS_SECRET_KEY", "secret-key")
+
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte("apiVersion: v1\nkind: Config\n"), 0600))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "store", "linode"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "store", "linode", "token"), []byte("linode-token\n"), 0600))
+	RegisterSecretStore("test", FileSecretStore{Dir: filepath.Join(dir, "store")})
+
+	cattleConfig := map[string]any{
+		"terraform": map[string]any{
+			"awsCredentials":       map[string]any{"awsSecretKey": "env://TFP_TEST_AWS_SECRET_KEY"},
+			"harvesterCredentials": map[string]any{"kubeconfigContent": "file://" + kubeconfig},
+			"linodeCredentials":    map[string]any{"linodeToken": "secret://test/linode/token"},
+			"standalone":           map[string]any{"rancherChartRepository": "https://releases.rancher.com/server-charts/"},
+		},
+	}
+
+	require.NoError(t, ResolveSecrets(cattleConfig))
+
+	_, terraformConfig, _ := LoadTFPConfigs(cattleConfig)
+	require.Equal(t, "secret-key", terraformConfig.AWSCredentials.AWSSecretKey)
+	require.Equal(t, "apiVersion: v1\nkind: Config", terraformConfig.HarvesterCredentials.KubeconfigContent)
+	require.Equal(t, "linode-token", terraformConfig.LinodeCredentials.LinodeToken)
+	require.Equal(t, "https://releases.rancher.com/server-charts/", terraformConfig.Standalone.RancherChartRepository)
+}
+
+func TestResolveSecretsReportsEveryFailure(t *testing.T) {
+	cattleConfig := map[string]any{
+		"rancher": map[string]any{"adminToken": "env://TFP_TEST_UNSET_VARIABLE"},
+		"terraform": map[string]any{
+			"linodeCredentials": map[string]any{"linodeToken": "secret://missing/linode/token"},
+			"awsConfig":         map[string]any{"awsSecurityGroupNames": []any{"file:///does/not/exist"}},
+		},
+	}
+
+	err := ResolveSecrets(cattleConfig)
+
+	var validationErrors ValidationErrors
+	require.ErrorAs(t, err, &validationErrors)
+
+	paths := []string{}
+	for _, validationError := range validationErrors {
+		paths = append(paths, validationError.Path)
+	}
+
+	require.Equal(t, []string{
+		"rancher.adminToken",
+		"terraform.awsConfig.awsSecurityGroupNames[0]",
+		"terraform.linodeCredentials.linodeToken",
+	}, paths)
+}
+
+func TestFileSecretStoreRejectsKeysOutsideItsDirectory(t *testing.T) {
+	_, err := FileSecretStore{Dir: t.TempDir()}.Secret("../../etc/passwd")
+	require.ErrorContains(t, err, "invalid secret key")
+}
@@ -0,0 +1,380 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/modules"
+)
+
+// ValidationError is a single problem found in the configuration, along with the YAML path of the offending field.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+// Error returns the YAML path and the problem found at it.
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors holds every problem found in the configuration.
+type ValidationErrors []ValidationError
+
+// Error returns every problem found in the configuration, one per line.
+func (e ValidationErrors) Error() string {
+	lines := []string{fmt.Sprintf("invalid configuration, %d problem(s) found:", len(e))}
+	for _, err := range e {
+		lines = append(lines, "  - "+err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// validator collects the problems found while walking the configuration.
+type validator struct {
+	module string
+	scope  string
+	errors ValidationErrors
+}
+
+// Validate is a function that will check the configuration against the fields the given module needs, before any
+// infrastructure is created. Every problem found is returned at once as ValidationErrors.
+func Validate(module string, rancherConfig *rancher.Config, terraformConfig *TerraformConfig, terratestConfig *TerratestConfig) error {
+	v := &validator{module: module, scope: "module " + module}
+
+	if module == "" {
+		v.add(path(TerraformConfigurationFileKey, "module"), "is required")
+		return v.err()
+	}
+
+	if rancherConfig != nil {
+		v.required(rancherConfig.Host, path(configs.Rancher, "host"))
+
+		if rancherConfig.Insecure == nil {
+			v.add(path(configs.Rancher, "insecure"), "is required")
+		}
+	}
+
+	v.required(terraformConfig.ResourcePrefix, path(TerraformConfigurationFileKey, "resourcePrefix"))
+
+	switch {
+	case module == clustertypes.AKS:
+		v.azureCredentials(terraformConfig)
+		v.required(terraformConfig.AzureConfig.ResourceGroup, path(TerraformConfigurationFileKey, "azureConfig", "resourceGroup"))
+		v.required(terraformConfig.AzureConfig.ResourceLocation, path(TerraformConfigurationFileKey, "azureConfig", "resourceLocation"))
+	case module == clustertypes.EKS:
+		v.awsCredentials(terraformConfig)
+		v.required(terraformConfig.AWSConfig.Region, path(TerraformConfigurationFileKey, "awsConfig", "region"))
+		v.notEmpty(terraformConfig.AWSConfig.AWSSubnets, path(TerraformConfigurationFileKey, "awsConfig", "awsSubnets"))
+		v.notEmpty(terraformConfig.AWSConfig.AWSSecurityGroups, path(TerraformConfigurationFileKey, "awsConfig", "awsSecurityGroups"))
+	case module == clustertypes.GKE:
+		v.required(terraformConfig.GoogleCredentials.AuthEncodedJSON, path(TerraformConfigurationFileKey, "googleCredentials", "authEncodedJson"))
+		v.required(terraformConfig.GoogleConfig.ProjectID, path(TerraformConfigurationFileKey, "googleConfig", "projectID"))
+		v.required(terraformConfig.GoogleConfig.Region, path(TerraformConfigurationFileKey, "googleConfig", "region"))
+	case module == modules.Imported:
+		if terraformConfig.AWSCredentials.AWSAccessKey != "" {
+			v.awsCredentials(terraformConfig)
+		}
+	case strings.Contains(module, clustertypes.CUSTOM) || strings.Contains(module, modules.Import) ||
+		strings.HasPrefix(module, modules.Airgap):
+		v.awsInstances(terraformConfig)
+	case strings.HasPrefix(module, modules.EC2):
+		v.awsNodeDriver(terraformConfig)
+	case strings.HasPrefix(module, modules.Azure):
+		v.azureCredentials(terraformConfig)
+		v.required(terraformConfig.AzureConfig.Image, path(TerraformConfigurationFileKey, "azureConfig", "image"))
+		v.required(terraformConfig.AzureConfig.Location, path(TerraformConfigurationFileKey, "azureConfig", "location"))
+	case strings.HasPrefix(module, modules.Harvester):
+		v.required(terraformConfig.HarvesterCredentials.KubeconfigContent, path(TerraformConfigurationFileKey, "harvesterCredentials", "kubeconfigContent"))
+		v.required(terraformConfig.HarvesterConfig.ImageName, path(TerraformConfigurationFileKey, "harvesterConfig", "imageName"))
+		v.required(terraformConfig.HarvesterConfig.VMNamespace, path(TerraformConfigurationFileKey, "harvesterConfig", "vmNamespace"))
+		v.notEmpty(terraformConfig.HarvesterConfig.NetworkNames, path(TerraformConfigurationFileKey, "harvesterConfig", "networkNames"))
+	case strings.HasPrefix(module, modules.Linode):
+		v.required(terraformConfig.LinodeCredentials.LinodeToken, path(TerraformConfigurationFileKey, "linodeCredentials", "linodeToken"))
+		v.required(terraformConfig.LinodeConfig.LinodeImage, path(TerraformConfigurationFileKey, "linodeConfig", "linodeImage"))
+		v.required(terraformConfig.LinodeConfig.Region, path(TerraformConfigurationFileKey, "linodeConfig", "region"))
+	case strings.HasPrefix(module, modules.Vsphere):
+		v.required(terraformConfig.VsphereCredentials.Username, path(TerraformConfigurationFileKey, "vsphereCredentials", "username"))
+		v.required(terraformConfig.VsphereCredentials.Password, path(TerraformConfigurationFileKey, "vsphereCredentials", "password"))
+		v.required(terraformConfig.VsphereCredentials.Vcenter, path(TerraformConfigurationFileKey, "vsphereCredentials", "vcenter"))
+		v.required(terraformConfig.VsphereConfig.DataCenter, path(TerraformConfigurationFileKey, "vsphereConfig", "dataCenter"))
+	default:
+		v.add(path(TerraformConfigurationFileKey, "module"), fmt.Sprintf("%s is not a supported module", module))
+		return v.err()
+	}
+
+	if strings.HasPrefix(module, modules.Airgap) {
+		if terraformConfig.PrivateRegistries == nil {
+			v.add(path(TerraformConfigurationFileKey, "privateRegistries"), "is required for "+v.scope)
+		} else {
+			v.required(terraformConfig.PrivateRegistries.URL, path(TerraformConfigurationFileKey, "privateRegistries", "url"))
+		}
+	}
+
+	if terraformConfig.OutputFormat != "" && terraformConfig.OutputFormat != configs.HCLFormat &&
+		terraformConfig.OutputFormat != configs.JSONFormat {
+		v.add(path(TerraformConfigurationFileKey, "outputFormat"), fmt.Sprintf("%s is not a supported format, use %s or %s",
+			terraformConfig.OutputFormat, configs.HCLFormat, configs.JSONFormat))
+	}
+
+	if terraformConfig.Backend != nil {
+		v.backend(terraformConfig.Backend)
+	}
+
+	if terraformConfig.Tags != nil {
+		v.tags(terraformConfig.Tags)
+	}
+
+	if terratestConfig != nil {
+		v.nodepools(terratestConfig)
+	}
+
+	return v.err()
+}
+
+// ValidateStandalone is a function that will check the configuration against the fields needed to stand up a Rancher
+// server on AWS, as done by the sanity, proxy, airgap and registries suites. Every problem found is returned at once
+// as ValidationErrors.
+func ValidateStandalone(terraformConfig *TerraformConfig) error {
+	v := &validator{scope: "a standalone Rancher server"}
+
+	v.required(terraformConfig.ResourcePrefix, path(TerraformConfigurationFileKey, "resourcePrefix"))
+	v.awsCredentials(terraformConfig)
+	v.required(terraformConfig.PrivateKeyPath, path(TerraformConfigurationFileKey, "privateKeyPath"))
+	v.required(terraformConfig.AWSConfig.Region, path(TerraformConfigurationFileKey, "awsConfig", "region"))
+	v.required(terraformConfig.AWSConfig.AMI, path(TerraformConfigurationFileKey, "awsConfig", "ami"))
+	v.required(terraformConfig.AWSConfig.AWSInstanceType, path(TerraformConfigurationFileKey, "awsConfig", "awsInstanceType"))
+	v.required(terraformConfig.AWSConfig.AWSKeyName, path(TerraformConfigurationFileKey, "awsConfig", "awsKeyName"))
+	v.required(terraformConfig.AWSConfig.AWSSubnetID, path(TerraformConfigurationFileKey, "awsConfig", "awsSubnetID"))
+	v.required(terraformConfig.AWSConfig.AWSVpcID, path(TerraformConfigurationFileKey, "awsConfig", "awsVpcID"))
+	v.required(terraformConfig.AWSConfig.AWSRoute53Zone, path(TerraformConfigurationFileKey, "awsConfig", "awsRoute53Zone"))
+	v.required(terraformConfig.AWSConfig.AWSUser, path(TerraformConfigurationFileKey, "awsConfig", "awsUser"))
+	v.notEmpty(terraformConfig.AWSConfig.AWSSecurityGroups, path(TerraformConfigurationFileKey, "awsConfig", "awsSecurityGroups"))
+
+	if terraformConfig.Tags != nil {
+		v.tags(terraformConfig.Tags)
+	}
+
+	standalone := terraformConfig.Standalone
+	if standalone == nil {
+		v.add(path(TerraformConfigurationFileKey, "standalone"), "is required for "+v.scope)
+		return v.err()
+	}
+
+	v.required(standalone.BootstrapPassword, path(TerraformConfigurationFileKey, "standalone", "bootstrapPassword"))
+	v.required(standalone.CertManagerVersion, path(TerraformConfigurationFileKey, "standalone", "certManagerVersion"))
+	v.required(standalone.RancherChartRepository, path(TerraformConfigurationFileKey, "standalone", "rancherChartRepository"))
+	v.required(standalone.RancherHostname, path(TerraformConfigurationFileKey, "standalone", "rancherHostname"))
+	v.required(standalone.RancherTagVersion, path(TerraformConfigurationFileKey, "standalone", "rancherTagVersion"))
+	v.required(standalone.Repo, path(TerraformConfigurationFileKey, "standalone", "repo"))
+	v.required(standalone.OSUser, path(TerraformConfigurationFileKey, "standalone", "osUser"))
+	v.required(standalone.OSGroup, path(TerraformConfigurationFileKey, "standalone", "osGroup"))
+	v.required(standalone.RKE2Version, path(TerraformConfigurationFileKey, "standalone", "rke2Version"))
+
+	if standalone.UpgradeAirgapRancher || standalone.UpgradeProxyRancher {
+		v.required(standalone.UpgradedRancherChartRepository, path(TerraformConfigurationFileKey, "standalone", "upgradedRancherChartRepository"))
+		v.required(standalone.UpgradedRancherRepo, path(TerraformConfigurationFileKey, "standalone", "upgradedRancherRepo"))
+		v.required(standalone.UpgradedRancherTagVersion, path(TerraformConfigurationFileKey, "standalone", "upgradedRancherTagVersion"))
+	}
+
+	return v.err()
+}
+
+// ValidateNodepool is a function that will check a single nodepool against the rules of the given module. The given
+// path is used as the YAML path of the nodepool in the returned ValidationErrors.
+func ValidateNodepool(module string, pool Nodepool, poolPath string) error {
+	v := &validator{module: module, scope: "module " + module}
+	v.nodepool(pool, poolPath)
+
+	return v.err()
+}
+
+// nodepools checks every nodepool the tests provision or scale with.
+func (v *validator) nodepools(terratestConfig *TerratestConfig) {
+	pools := []struct {
+		path  string
+		pools []Nodepool
+	}{
+		{path(TerratestConfigurationFileKey, "nodepools"), terratestConfig.Nodepools},
+		{path(TerratestConfigurationFileKey, "scalingInput", "scaledUpNodepools"), terratestConfig.ScalingInput.ScaledUpNodepools},
+		{path(TerratestConfigurationFileKey, "scalingInput", "scaledDownNodepools"), terratestConfig.ScalingInput.ScaledDownNodepools},
+	}
+
+	for _, poolList := range pools {
+		for i, pool := range poolList.pools {
+			v.nodepool(pool, fmt.Sprintf("%s[%d]", poolList.path, i))
+		}
+	}
+}
+
+// nodepool checks a single nodepool against the rules of the module being validated.
+func (v *validator) nodepool(pool Nodepool, poolPath string) {
+	switch {
+	case v.module == clustertypes.AKS || v.module == clustertypes.GKE:
+		if pool.Quantity <= 0 {
+			v.add(path(poolPath, "quantity"), "must be greater than 0")
+		}
+	case v.module == clustertypes.EKS:
+		if pool.DesiredSize <= 0 {
+			v.add(path(poolPath, "desiredSize"), "must be greater than 0")
+		}
+	case strings.Contains(v.module, clustertypes.RKE1) || strings.Contains(v.module, clustertypes.RKE2) ||
+		strings.Contains(v.module, clustertypes.K3S):
+		if !pool.Etcd && !pool.Controlplane && !pool.Worker {
+			v.add(poolPath, "no roles selected, at least one of etcd, controlplane or worker is required")
+		}
+
+		if pool.Quantity <= 0 {
+			v.add(path(poolPath, "quantity"), "must be greater than 0")
+		}
+	default:
+		v.add(path(TerraformConfigurationFileKey, "module"), fmt.Sprintf("%s is not a supported module", v.module))
+	}
+}
+
+// awsCredentials checks the AWS credentials.
+func (v *validator) awsCredentials(terraformConfig *TerraformConfig) {
+	v.required(terraformConfig.AWSCredentials.AWSAccessKey, path(TerraformConfigurationFileKey, "awsCredentials", "awsAccessKey"))
+	v.required(terraformConfig.AWSCredentials.AWSSecretKey, path(TerraformConfigurationFileKey, "awsCredentials", "awsSecretKey"))
+}
+
+// azureCredentials checks the Azure credentials.
+func (v *validator) azureCredentials(terraformConfig *TerraformConfig) {
+	v.required(terraformConfig.AzureCredentials.ClientID, path(TerraformConfigurationFileKey, "azureCredentials", "clientId"))
+	v.required(terraformConfig.AzureCredentials.ClientSecret, path(TerraformConfigurationFileKey, "azureCredentials", "clientSecret"))
+	v.required(terraformConfig.AzureCredentials.SubscriptionID, path(TerraformConfigurationFileKey, "azureCredentials", "subscriptionId"))
+}
+
+// backend checks that exactly one remote state backend is set, with the fields it needs.
+func (v *validator) backend(backend *Backend) {
+	backendPath := path(TerraformConfigurationFileKey, "backend")
+
+	set := 0
+	if backend.S3 != nil {
+		set++
+		v.required(backend.S3.Bucket, path(backendPath, "s3", "bucket"))
+		v.required(backend.S3.Region, path(backendPath, "s3", "region"))
+	}
+
+	if backend.HTTP != nil {
+		set++
+		v.required(backend.HTTP.Address, path(backendPath, "http", "address"))
+	}
+
+	if backend.PG != nil {
+		set++
+		v.required(backend.PG.ConnStr, path(backendPath, "pg", "connStr"))
+	}
+
+	if set != 1 {
+		v.add(backendPath, "must set exactly one of s3, http or pg")
+	}
+}
+
+// tags checks that the TTL is a positive duration and that the expiry is an RFC 3339 time.
+func (v *validator) tags(tags *Tags) {
+	if tags.TTL != "" {
+		ttl, err := time.ParseDuration(tags.TTL)
+		if err != nil || ttl <= 0 {
+			v.add(path(TerraformConfigurationFileKey, "tags", "ttl"), fmt.Sprintf("%s is not a positive duration, such as 6h", tags.TTL))
+		}
+	}
+
+	if tags.ExpiresAt != "" {
+		_, err := time.Parse(time.RFC3339, tags.ExpiresAt)
+		if err != nil {
+			v.add(path(TerraformConfigurationFileKey, "tags", "expiresAt"), fmt.Sprintf("%s is not an RFC 3339 time", tags.ExpiresAt))
+		}
+	}
+}
+
+// awsNodeDriver checks the fields the AWS node driver machine configs and node templates are built from.
+func (v *validator) awsNodeDriver(terraformConfig *TerraformConfig) {
+	v.awsCredentials(terraformConfig)
+	v.required(terraformConfig.AWSConfig.Region, path(TerraformConfigurationFileKey, "awsConfig", "region"))
+	v.required(terraformConfig.AWSConfig.AMI, path(TerraformConfigurationFileKey, "awsConfig", "ami"))
+	v.required(terraformConfig.AWSConfig.AWSInstanceType, path(TerraformConfigurationFileKey, "awsConfig", "awsInstanceType"))
+	v.required(terraformConfig.AWSConfig.AWSSubnetID, path(TerraformConfigurationFileKey, "awsConfig", "awsSubnetID"))
+	v.required(terraformConfig.AWSConfig.AWSVpcID, path(TerraformConfigurationFileKey, "awsConfig", "awsVpcID"))
+	v.required(terraformConfig.AWSConfig.AWSZoneLetter, path(TerraformConfigurationFileKey, "awsConfig", "awsZoneLetter"))
+	v.notEmpty(terraformConfig.AWSConfig.AWSSecurityGroupNames, path(TerraformConfigurationFileKey, "awsConfig", "awsSecurityGroupNames"))
+}
+
+// awsInstances checks the fields the AWS instances backing custom, imported and airgap clusters are built from.
+func (v *validator) awsInstances(terraformConfig *TerraformConfig) {
+	v.awsCredentials(terraformConfig)
+	v.required(terraformConfig.PrivateKeyPath, path(TerraformConfigurationFileKey, "privateKeyPath"))
+	v.required(terraformConfig.AWSConfig.Region, path(TerraformConfigurationFileKey, "awsConfig", "region"))
+	v.required(terraformConfig.AWSConfig.AMI, path(TerraformConfigurationFileKey, "awsConfig", "ami"))
+	v.required(terraformConfig.AWSConfig.AWSInstanceType, path(TerraformConfigurationFileKey, "awsConfig", "awsInstanceType"))
+	v.required(terraformConfig.AWSConfig.AWSKeyName, path(TerraformConfigurationFileKey, "awsConfig", "awsKeyName"))
+	v.required(terraformConfig.AWSConfig.AWSSubnetID, path(TerraformConfigurationFileKey, "awsConfig", "awsSubnetID"))
+	v.required(terraformConfig.AWSConfig.AWSUser, path(TerraformConfigurationFileKey, "awsConfig", "awsUser"))
+	v.notEmpty(terraformConfig.AWSConfig.AWSSecurityGroups, path(TerraformConfigurationFileKey, "awsConfig", "awsSecurityGroups"))
+
+	if v.module == modules.CustomEC2RKE2Windows {
+		v.required(terraformConfig.WindowsPrivateKeyPath, path(TerraformConfigurationFileKey, "windowsPrivateKeyPath"))
+		v.required(terraformConfig.AWSConfig.WindowsAMI, path(TerraformConfigurationFileKey, "awsConfig", "windowsAMI"))
+		v.required(terraformConfig.AWSConfig.WindowsAWSUser, path(TerraformConfigurationFileKey, "awsConfig", "windowsAWSUser"))
+		v.required(terraformConfig.AWSConfig.WindowsInstanceType, path(TerraformConfigurationFileKey, "awsConfig", "windowsInstanceType"))
+		v.required(terraformConfig.AWSConfig.WindowsKeyName, path(TerraformConfigurationFileKey, "awsConfig", "windowsKeyName"))
+	}
+
+	if strings.Contains(v.module, clustertypes.CUSTOM) {
+		return
+	}
+
+	standalone := terraformConfig.Standalone
+	if standalone == nil {
+		v.add(path(TerraformConfigurationFileKey, "standalone"), "is required for "+v.scope)
+		return
+	}
+
+	v.required(standalone.OSUser, path(TerraformConfigurationFileKey, "standalone", "osUser"))
+	v.required(standalone.OSGroup, path(TerraformConfigurationFileKey, "standalone", "osGroup"))
+
+	switch v.module {
+	case modules.ImportEC2RKE2:
+		v.required(standalone.RKE2Version, path(TerraformConfigurationFileKey, "standalone", "rke2Version"))
+	case modules.ImportEC2K3s:
+		v.required(standalone.K3SVersion, path(TerraformConfigurationFileKey, "standalone", "k3sVersion"))
+	}
+}
+
+// required records a problem if the given value is empty.
+func (v *validator) required(value, fieldPath string) {
+	if value == "" {
+		v.add(fieldPath, "is required for "+v.scope)
+	}
+}
+
+// notEmpty records a problem if the given list is empty.
+func (v *validator) notEmpty(values []string, fieldPath string) {
+	if len(values) == 0 {
+		v.add(fieldPath, "must have at least one entry for "+v.scope)
+	}
+}
+
+// add records a problem found at the given YAML path.
+func (v *validator) add(fieldPath, message string) {
+	v.errors = append(v.errors, ValidationError{Path: fieldPath, Message: message})
+}
+
+// err returns the problems found, or nil if there are none.
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	return v.errors
+}
+
+// path returns the YAML path made up of the given keys.
+func path(keys ...string) string {
+	return strings.Join(keys, ".")
+}
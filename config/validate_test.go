@@ -0,0 +1,156 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/rancher/shepherd/clients/rancher"
+	aws "github.com/rancher/tfp-automation/config/nodeproviders/aws"
+	"github.com/stretchr/testify/require"
+)
+
+func validEC2Config() (*rancher.Config, *TerraformConfig, *TerratestConfig) {
+	insecure := true
+
+	rancherConfig := &rancher.Config{Host: "rancher.example.com", Insecure: &insecure}
+	terraformConfig := &TerraformConfig{
+		Module:         "ec2_rke2",
+		ResourcePrefix: "tfp",
+		AWSCredentials: aws.Credentials{AWSAccessKey: "access-key", AWSSecretKey: "secret-key"},
+		AWSConfig: aws.Config{
+			AMI:                   "ami-aaaaaaaa",
+			AWSInstanceType:       "t3.xlarge",
+			AWSSecurityGroupNames: []string{"tfp-sg"},
+			AWSSubnetID:           "subnet-aaaaaaaa",
+			AWSVpcID:              "vpc-aaaaaaaa",
+			AWSZoneLetter:         "a",
+			Region:                "us-east-2",
+		},
+	}
+	terratestConfig := &TerratestConfig{Nodepools: []Nodepool{AllRolesNodePool}}
+
+	return rancherConfig, terraformConfig, terratestConfig
+}
+
+func paths(t *testing.T, err error) []string {
+	t.Helper()
+
+	var validationErrors ValidationErrors
+	require.ErrorAs(t, err, &validationErrors)
+
+	paths := []string{}
+	for _, validationError := range validationErrors {
+		paths = append(paths, validationError.Path)
+	}
+
+	return paths
+}
+
+func TestValidate(t *testing.T) {
+	rancherConfig, terraformConfig, terratestConfig := validEC2Config()
+
+	require.NoError(t, Validate(terraformConfig.Module, rancherConfig, terraformConfig, terratestConfig))
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	rancherConfig, terraformConfig, terratestConfig := validEC2Config()
+
+	rancherConfig.Insecure = nil
+	terraformConfig.AWSConfig.AMI = ""
+	terraformConfig.AWSConfig.AWSSecurityGroupNames = nil
+	terratestConfig.Nodepools = append(terratestConfig.Nodepools, Nodepool{Quantity: 0})
+
+	err := Validate(terraformConfig.Module, rancherConfig, terraformConfig, terratestConfig)
+
+	require.Equal(t, []string{
+		"rancher.insecure",
+		"terraform.awsConfig.ami",
+		"terraform.awsConfig.awsSecurityGroupNames",
+		"terratest.nodepools[1]",
+		"terratest.nodepools[1].quantity",
+	}, paths(t, err))
+}
+
+func TestValidateUnsupportedModule(t *testing.T) {
+	rancherConfig, terraformConfig, terratestConfig := validEC2Config()
+
+	err := Validate("ec3_rke2", rancherConfig, terraformConfig, terratestConfig)
+
+	require.Equal(t, []string{"terraform.module"}, paths(t, err))
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	rancherConfig, terraformConfig, terratestConfig := validEC2Config()
+
+	terraformConfig.OutputFormat = "json"
+	require.NoError(t, Validate(terraformConfig.Module, rancherConfig, terraformConfig, terratestConfig))
+
+	terraformConfig.OutputFormat = "yaml"
+
+	err := Validate(terraformConfig.Module, rancherConfig, terraformConfig, terratestConfig)
+	require.Equal(t, []string{"terraform.outputFormat"}, paths(t, err))
+}
+
+func TestValidateBackend(t *testing.T) {
+	rancherConfig, terraformConfig, terratestConfig := validEC2Config()
+
+	terraformConfig.Backend = &Backend{S3: &S3Backend{Bucket: "tfstate", Region: "us-east-1", Endpoint: "http://localhost:9000"}}
+	require.NoError(t, Validate(terraformConfig.Module, rancherConfig, terraformConfig, terratestConfig))
+
+	terraformConfig.Backend = &Backend{S3: &S3Backend{}, HTTP: &HTTPBackend{Address: "http://localhost:8080/state"}}
+
+	err := Validate(terraformConfig.Module, rancherConfig, terraformConfig, terratestConfig)
+	require.ElementsMatch(t, []string{"terraform.backend", "terraform.backend.s3.bucket", "terraform.backend.s3.region"}, paths(t, err))
+
+	terraformConfig.Backend = &Backend{}
+
+	err = Validate(terraformConfig.Module, rancherConfig, terraformConfig, terratestConfig)
+	require.Equal(t, []string{"terraform.backend"}, paths(t, err))
+}
+
+func TestValidateTags(t *testing.T) {
+	rancherConfig, terraformConfig, terratestConfig := validEC2Config()
+
+	terraformConfig.Tags = &Tags{Owner: "qa", TTL: "6h", ExpiresAt: "2026-01-02T12:00:00Z"}
+	require.NoError(t, Validate(terraformConfig.Module, rancherConfig, terraformConfig, terratestConfig))
+
+	terraformConfig.Tags = &Tags{TTL: "-1h", ExpiresAt: "tomorrow"}
+
+	err := Validate(terraformConfig.Module, rancherConfig, terraformConfig, terratestConfig)
+	require.Equal(t, []string{"terraform.tags.ttl", "terraform.tags.expiresAt"}, paths(t, err))
+}
+
+func TestValidateImportNeedsStandalone(t *testing.T) {
+	rancherConfig, terraformConfig, terratestConfig := validEC2Config()
+
+	terraformConfig.AWSConfig.AWSKeyName = "tfp-key"
+	terraformConfig.AWSConfig.AWSSecurityGroups = []string{"sg-aaaaaaaa"}
+	terraformConfig.AWSConfig.AWSUser = "ubuntu"
+	terraformConfig.PrivateKeyPath = "id_rsa"
+
+	err := Validate("ec2_rke2_import", rancherConfig, terraformConfig, terratestConfig)
+	require.Equal(t, []string{"terraform.standalone"}, paths(t, err))
+
+	terraformConfig.Standalone = &Standalone{OSUser: "ubuntu", OSGroup: "ubuntu"}
+
+	err = Validate("ec2_rke2_import", rancherConfig, terraformConfig, terratestConfig)
+	require.Equal(t, []string{"terraform.standalone.rke2Version"}, paths(t, err))
+}
+
+func TestValidateImported(t *testing.T) {
+	rancherConfig := &rancher.Config{Host: "rancher.example.com", Insecure: new(bool)}
+	terraformConfig := &TerraformConfig{ResourcePrefix: "tfp-import"}
+
+	require.NoError(t, Validate("imported", rancherConfig, terraformConfig, &TerratestConfig{}))
+
+	terraformConfig.AWSCredentials.AWSAccessKey = "access-key"
+
+	err := Validate("imported", rancherConfig, terraformConfig, &TerratestConfig{})
+	require.Equal(t, []string{"terraform.awsCredentials.awsSecretKey"}, paths(t, err))
+}
+
+func TestValidateNodepool(t *testing.T) {
+	require.NoError(t, ValidateNodepool("eks", Nodepool{DesiredSize: 1}, "terratest.nodepools[0]"))
+
+	err := ValidateNodepool("eks", Nodepool{Quantity: 1}, "terratest.nodepools[0]")
+	require.EqualError(t, err, "invalid configuration, 1 problem(s) found:\n  - terratest.nodepools[0].desiredSize: must be greater than 0")
+}
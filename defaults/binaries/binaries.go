@@ -0,0 +1,56 @@
+package binaries
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	Terraform = "terraform"
+	OpenTofu  = "tofu"
+
+	BinaryEnvVar = "TERRAFORM_BINARY"
+
+	LocalRegistry     = "terraform.local"
+	OpenTofuRegistry  = "registry.opentofu.org"
+	TerraformRegistry = "registry.terraform.io"
+)
+
+// Binary is a function that will return the binary, terraform or tofu, that the tests run. It is set by the
+// TERRAFORM_BINARY environment variable and defaults to terraform.
+func Binary() (string, error) {
+	binary := os.Getenv(BinaryEnvVar)
+
+	switch binary {
+	case "":
+		return Terraform, nil
+	case Terraform, OpenTofu:
+		return binary, nil
+	default:
+		return "", fmt.Errorf("unsupported %s %q, expected %s or %s", BinaryEnvVar, binary, Terraform, OpenTofu)
+	}
+}
+
+// Registry is a function that will return the hostname of the registry the given binary installs providers from by
+// default.
+func Registry(binary string) string {
+	if binary == OpenTofu {
+		return OpenTofuRegistry
+	}
+
+	return TerraformRegistry
+}
+
+// ProviderSource is a function that will return the source address the given binary installs the provider with the
+// given source from. Terraform resolves sources without a hostname against its own registry, so they are left as is.
+// OpenTofu sources are qualified with the OpenTofu registry, so that providers are never resolved from the wrong
+// registry through a shared plugin cache or lock file. Sources of providers installed locally, such as release
+// candidates, are left as is for both binaries, as both read them from the same plugin directory.
+func ProviderSource(binary, source string) string {
+	if binary != OpenTofu || strings.HasPrefix(source, LocalRegistry+"/") || strings.Count(source, "/") > 1 {
+		return source
+	}
+
+	return OpenTofuRegistry + "/" + source
+}
@@ -0,0 +1,45 @@
+package binaries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinary(t *testing.T) {
+	t.Setenv(BinaryEnvVar, "")
+
+	binary, err := Binary()
+	require.NoError(t, err)
+	require.Equal(t, Terraform, binary)
+
+	t.Setenv(BinaryEnvVar, OpenTofu)
+
+	binary, err = Binary()
+	require.NoError(t, err)
+	require.Equal(t, OpenTofu, binary)
+
+	t.Setenv(BinaryEnvVar, "terragrunt")
+
+	_, err = Binary()
+	require.ErrorContains(t, err, "unsupported TERRAFORM_BINARY")
+}
+
+func TestProviderSource(t *testing.T) {
+	tests := []struct {
+		binary   string
+		source   string
+		expected string
+	}{
+		{Terraform, "rancher/rancher2", "rancher/rancher2"},
+		{Terraform, "terraform.local/local/rancher2", "terraform.local/local/rancher2"},
+		{OpenTofu, "rancher/rancher2", "registry.opentofu.org/rancher/rancher2"},
+		{OpenTofu, "hashicorp/aws", "registry.opentofu.org/hashicorp/aws"},
+		{OpenTofu, "terraform.local/local/rancher2", "terraform.local/local/rancher2"},
+		{OpenTofu, "registry.opentofu.org/rancher/rke", "registry.opentofu.org/rancher/rke"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, ProviderSource(tt.binary, tt.source), "%s %s", tt.binary, tt.source)
+	}
+}
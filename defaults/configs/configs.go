@@ -14,12 +14,27 @@ const (
 	DefaultK8sVersion    = "default"
 	SecondHighestVersion = "second"
 
+	LocalsTF        = "/locals.tf"
 	MainTF          = "/main.tf"
+	OutputsTF       = "/outputs.tf"
+	ProvidersTF     = "/providers.tf"
 	RKEDebugLog     = "/rke_debug.log"
 	TerraformFolder = "/.terraform"
 	TFState         = "/terraform.tfstate"
 	TFStateBackup   = "/terraform.tfstate.backup"
 	TFLockHCL       = "/.terraform.lock.hcl"
+	TFVarsJSON      = "/terraform.tfvars.json"
+	UsersTF         = "/users.tf"
+	VariablesTF     = "/variables.tf"
+
+	GeneratedTFComment = "Generated by tfp-automation from the test configs, any changes are overwritten."
+	GeneratedTFHeader  = "# " + GeneratedTFComment
+	MainTFPlaceholder  = "// Leave blank - main.tf will be set during testing"
+	TFExtension        = ".tf"
+	JSONExtension      = ".json"
+
+	HCLFormat  = "hcl"
+	JSONFormat = "json"
 )
 
 // CreateTestCredentials creates test credentials for the test user, password, cluster name, and pool name.
@@ -1,6 +1,7 @@
 package modules
 
 const (
+	Azure                = "azure"
 	AzureRKE1            = "azure_rke1"
 	AzureRKE2            = "azure_rke2"
 	AzureK3s             = "azure_k3s"
@@ -12,18 +13,24 @@ const (
 	EC2RKE1              = "ec2_rke1"
 	EC2RKE2              = "ec2_rke2"
 	EC2K3s               = "ec2_k3s"
+	Harvester            = "harvester"
 	HarvesterRKE1        = "harvester_rke1"
 	HarvesterRKE2        = "harvester_rke2"
 	HarvesterK3s         = "harvester_k3s"
+	Import               = "import"
+	Imported             = "imported"
 	ImportEC2RKE1        = "ec2_rke1_import"
 	ImportEC2RKE2        = "ec2_rke2_import"
 	ImportEC2K3s         = "ec2_k3s_import"
+	Linode               = "linode"
 	LinodeRKE1           = "linode_rke1"
 	LinodeRKE2           = "linode_rke2"
 	LinodeK3s            = "linode_k3s"
+	Vsphere              = "vsphere"
 	VsphereRKE1          = "vsphere_rke1"
 	VsphereRKE2          = "vsphere_rke2"
 	VsphereK3s           = "vsphere_k3s"
+	Airgap               = "airgap"
 	AirgapRKE1           = "airgap_rke1"
 	AirgapRKE2           = "airgap_rke2"
 	AirgapK3S            = "airgap_k3s"
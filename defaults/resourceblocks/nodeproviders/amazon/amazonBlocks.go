@@ -18,6 +18,7 @@ const (
 	VPCID         = "vpc_id"
 	Zone          = "zone"
 	RootSize      = "root_size"
+	Tags          = "tags"
 
 	NodeGroups   = "node_groups"
 	InstanceType = "instance_type"
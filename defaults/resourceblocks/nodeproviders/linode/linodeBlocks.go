@@ -5,5 +5,6 @@ const (
 	LinodeCredentialConfig = "linode_credential_config"
 	Image                  = "image"
 	RootPass               = "root_pass"
+	Tags                   = "tags"
 	Token                  = "token"
 )
@@ -0,0 +1,265 @@
+package artifacts
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	WorkspaceEnvVar = "WORKSPACE"
+
+	artifactsDir = "artifacts"
+	clustersDir  = "clusters"
+	configDir    = "config"
+	defaultDir   = "tfp-artifacts"
+	journalsDir  = "journals"
+	stateFile    = "state.json"
+
+	awsInstance = "aws_instance"
+	clusterV1   = "rancher2_cluster"
+	clusterV2   = "rancher2_cluster_v2"
+	tfJSON      = ".tf.json"
+	tfVarsJSON  = "terraform.tfvars.json"
+)
+
+// Dir is a function that will return the directory the artifacts of the given test are written to: a directory
+// named after the test under the configured artifactsDir, under $WORKSPACE/artifacts when run by Jenkins, or under the
+// system temp directory otherwise.
+func Dir(testName string, terratestConfig *config.TerratestConfig) string {
+	root := terratestConfig.ArtifactsDir
+	if root == "" {
+		if workspace := os.Getenv(WorkspaceEnvVar); workspace != "" {
+			root = filepath.Join(workspace, artifactsDir)
+		} else {
+			root = filepath.Join(os.TempDir(), defaultDir)
+		}
+	}
+
+	return filepath.Join(root, strings.NewReplacer("/", "_", " ", "_", ":", "_").Replace(testName))
+}
+
+// Collect is a function that will write what is needed to debug the given failed test to its artifacts directory,
+// before its resources are destroyed: a redacted copy of the generated configuration, the redacted output of
+// terraform show -json and, for every cluster in the state, its Rancher objects, node conditions, pods that are not
+// running and the rancher-system-agent, rke2 and k3s journals of its nodes. Nothing collected can fail the test; each
+// artifact that cannot be collected is logged and skipped.
+func Collect(t *testing.T, terraformOptions *terraform.Options) {
+	rancherConfig := new(rancher.Config)
+	shepherdConfig.LoadConfig(configs.Rancher, rancherConfig)
+
+	terraformConfig := new(config.TerraformConfig)
+	shepherdConfig.LoadConfig(config.TerraformConfigurationFileKey, terraformConfig)
+
+	terratestConfig := new(config.TerratestConfig)
+	shepherdConfig.LoadConfig(config.TerratestConfigurationFileKey, terratestConfig)
+
+	dir := Dir(t.Name(), terratestConfig)
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		logrus.Warnf("Failed to create artifacts directory %s. Error: %v", dir, err)
+		return
+	}
+
+	logrus.Infof("Test failed, collecting artifacts in %s...", dir)
+
+	err = copyConfig(terraformOptions.TerraformDir, filepath.Join(dir, configDir))
+	if err != nil {
+		logrus.Warnf("Failed to copy the generated configuration. Error: %v", err)
+	}
+
+	state, err := writeState(t, terraformOptions, filepath.Join(dir, stateFile))
+	if err != nil {
+		logrus.Warnf("Failed to write the Terraform state. Error: %v", err)
+		return
+	}
+
+	for _, instance := range state.instances {
+		collectInstanceJournal(instance, terraformConfig, filepath.Join(dir, journalsDir))
+	}
+
+	if len(state.clusters) == 0 || rancherConfig.Host == "" {
+		return
+	}
+
+	testSession := session.NewSession()
+	defer testSession.Cleanup()
+
+	client, err := rancher.NewClient("", testSession)
+	if err != nil {
+		logrus.Warnf("Failed to create a Rancher client to collect the cluster artifacts. Error: %v", err)
+		return
+	}
+
+	for _, cluster := range state.clusters {
+		collectCluster(client, cluster, filepath.Join(dir, clustersDir, cluster.name), filepath.Join(dir, journalsDir))
+	}
+}
+
+// stateCluster is a Rancher cluster found in the Terraform state.
+type stateCluster struct {
+	name string
+	id   string
+	v2   bool
+}
+
+// stateInstance is an AWS instance found in the Terraform state.
+type stateInstance struct {
+	address  string
+	publicIP string
+}
+
+// state holds the clusters and instances found in the Terraform state.
+type state struct {
+	clusters  []stateCluster
+	instances []stateInstance
+}
+
+// copyConfig copies the generated configuration files in the given working directory to the given directory,
+// redacted. The variable values file and the state are not copied.
+func copyConfig(terraformDir, dir string) error {
+	entries, err := os.ReadDir(terraformDir)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == tfVarsJSON || !(strings.HasSuffix(name, configs.TFExtension) || strings.HasSuffix(name, tfJSON)) {
+			continue
+		}
+
+		contents, err := os.ReadFile(filepath.Join(terraformDir, name))
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(filepath.Join(dir, name), redactConfig(contents), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeState writes the redacted output of terraform show -json to the given file, and returns the clusters and
+// instances found in it.
+func writeState(t *testing.T, terraformOptions *terraform.Options, file string) (*state, error) {
+	show, err := terraform.ShowE(t, terraformOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := parseState([]byte(show))
+	if err != nil {
+		return nil, err
+	}
+
+	redactedState, err := redactState([]byte(show))
+	if err != nil {
+		return nil, err
+	}
+
+	return found, os.WriteFile(file, redactedState, 0644)
+}
+
+// showResource is a resource of the terraform show -json output.
+type showResource struct {
+	Address string         `json:"address"`
+	Type    string         `json:"type"`
+	Values  map[string]any `json:"values"`
+}
+
+// showModule is a module of the terraform show -json output.
+type showModule struct {
+	Resources    []showResource `json:"resources"`
+	ChildModules []showModule   `json:"child_modules"`
+}
+
+// parseState returns the Rancher clusters and the AWS instances of the given terraform show -json output.
+func parseState(show []byte) (*state, error) {
+	var parsed struct {
+		Values struct {
+			RootModule showModule `json:"root_module"`
+		} `json:"values"`
+	}
+
+	err := json.Unmarshal(show, &parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	found := &state{}
+	found.add(parsed.Values.RootModule)
+
+	return found, nil
+}
+
+// add adds the Rancher clusters and AWS instances of the given module and its child modules.
+func (s *state) add(module showModule) {
+	for _, resource := range module.Resources {
+		name, _ := resource.Values["name"].(string)
+
+		switch resource.Type {
+		case clusterV2:
+			id, _ := resource.Values["cluster_v1_id"].(string)
+			s.clusters = append(s.clusters, stateCluster{name: name, id: id, v2: true})
+		case clusterV1:
+			id, _ := resource.Values["id"].(string)
+			s.clusters = append(s.clusters, stateCluster{name: name, id: id})
+		case awsInstance:
+			publicIP, _ := resource.Values["public_ip"].(string)
+			s.instances = append(s.instances, stateInstance{address: resource.Address, publicIP: publicIP})
+		}
+	}
+
+	for _, childModule := range module.ChildModules {
+		s.add(childModule)
+	}
+}
+
+// writeYAML writes the given object to the given file as YAML, with the values of its sensitive fields and the
+// registered sensitive values redacted.
+func writeYAML(file string, object any) error {
+	contents, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	var generic any
+
+	err = json.Unmarshal(contents, &generic)
+	if err != nil {
+		return err
+	}
+
+	contents, err = yaml.Marshal(redactFields(generic))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, redactConfig(contents), 0644)
+}
+
+// fileName returns the given name with the characters that cannot be used in a file name replaced.
+func fileName(name string) string {
+	return strings.NewReplacer("/", "_", " ", "_", ":", "_", "[", "_", "]", "", "\"", "").Replace(name)
+}
@@ -0,0 +1,105 @@
+package artifacts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/stretchr/testify/require"
+)
+
+const show = `{
+  "format_version": "1.0",
+  "values": {
+    "outputs": {
+      "kubeconfig": {"sensitive": true, "value": "apiVersion: v1"},
+      "cluster_id": {"sensitive": false, "value": "c-abc"}
+    },
+    "root_module": {
+      "resources": [
+        {
+          "address": "rancher2_cluster_v2.tfp-rke2",
+          "type": "rancher2_cluster_v2",
+          "values": {"name": "tfp-rke2", "cluster_v1_id": "c-m-abc", "cluster_registration_token": [{"command": "curl | sh"}]},
+          "sensitive_values": {"cluster_registration_token": true}
+        },
+        {
+          "address": "rancher2_cloud_credential.tfp-rke2",
+          "type": "rancher2_cloud_credential",
+          "values": {"name": "tfp-rke2", "amazonec2_credential_config": [{"access_key": "AKIA", "default_region": "us-east-2"}]},
+          "sensitive_values": {"amazonec2_credential_config": [{"access_key": true}]}
+        }
+      ],
+      "child_modules": [
+        {
+          "resources": [
+            {"address": "module.nodes.aws_instance.tfp-custom[0]", "type": "aws_instance", "values": {"public_ip": "203.0.113.10"}},
+            {"address": "rancher2_cluster.tfp-rke1", "type": "rancher2_cluster", "values": {"name": "tfp-rke1", "id": "c-abc"}}
+          ]
+        }
+      ]
+    }
+  }
+}`
+
+func TestDir(t *testing.T) {
+	t.Setenv(WorkspaceEnvVar, "")
+	require.Equal(t, filepath.Join(os.TempDir(), defaultDir, "TestProvision_3_nodes"), Dir("TestProvision/3 nodes", &config.TerratestConfig{}))
+
+	t.Setenv(WorkspaceEnvVar, "/var/jenkins/workspace/tfp")
+	require.Equal(t, "/var/jenkins/workspace/tfp/artifacts/TestProvision", Dir("TestProvision", &config.TerratestConfig{}))
+
+	require.Equal(t, "/tmp/out/TestProvision", Dir("TestProvision", &config.TerratestConfig{ArtifactsDir: "/tmp/out"}))
+}
+
+func TestCopyConfig(t *testing.T) {
+	terraformDir := t.TempDir()
+	dir := filepath.Join(t.TempDir(), configDir)
+
+	files := map[string]string{
+		"main.tf":               "resource \"rancher2_user\" \"user\" {\n  password = \"inlined\"\n  name     = \"tfp\"\n}\n",
+		"tfp-rke2.tf.json":      `{"resource": {"rancher2_cloud_credential": {"c": {"secret_key": "inlined"}}}}`,
+		"terraform.tfvars.json": `{"aws_secret_key": "secret"}`,
+		"terraform.tfstate":     `{}`,
+	}
+
+	for name, contents := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(terraformDir, name), []byte(contents), 0644))
+	}
+
+	require.NoError(t, copyConfig(terraformDir, dir))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	mainTF, err := os.ReadFile(filepath.Join(dir, "main.tf"))
+	require.NoError(t, err)
+	require.Contains(t, string(mainTF), `password = "REDACTED"`)
+	require.Contains(t, string(mainTF), `name     = "tfp"`)
+
+	clusterTF, err := os.ReadFile(filepath.Join(dir, "tfp-rke2.tf.json"))
+	require.NoError(t, err)
+	require.Equal(t, `{"resource": {"rancher2_cloud_credential": {"c": {"secret_key": "REDACTED"}}}}`, string(clusterTF))
+}
+
+func TestParseState(t *testing.T) {
+	found, err := parseState([]byte(show))
+	require.NoError(t, err)
+
+	require.Equal(t, []stateCluster{{name: "tfp-rke2", id: "c-m-abc", v2: true}, {name: "tfp-rke1", id: "c-abc"}}, found.clusters)
+	require.Equal(t, []stateInstance{{address: "module.nodes.aws_instance.tfp-custom[0]", publicIP: "203.0.113.10"}}, found.instances)
+	require.Equal(t, "module.nodes.aws_instance.tfp-custom_0", fileName(found.instances[0].address))
+}
+
+func TestRedactState(t *testing.T) {
+	redactedState, err := redactState([]byte(show))
+	require.NoError(t, err)
+
+	require.NotContains(t, string(redactedState), "apiVersion: v1")
+	require.NotContains(t, string(redactedState), "curl | sh")
+	require.NotContains(t, string(redactedState), "AKIA")
+	require.Contains(t, string(redactedState), `"value": "c-abc"`)
+	require.Contains(t, string(redactedState), `"default_region": "us-east-2"`)
+}
@@ -0,0 +1,222 @@
+package artifacts
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rancher/norman/types"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/shepherd/clients/rancher"
+	v1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/sshkeys"
+	"github.com/rancher/shepherd/extensions/workloads/pods"
+	"github.com/rancher/shepherd/pkg/nodes"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	"github.com/rancher/tfp-automation/framework/redact"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	clusterFile  = "cluster.yaml"
+	machinesFile = "machines.yaml"
+	nodesFile    = "nodes.yaml"
+	podsFile     = "pods.txt"
+
+	clusterIDFilter     = "clusterId"
+	clusterNameLabel    = "cluster.x-k8s.io/cluster-name"
+	fleetDefault        = "fleet-default"
+	journalCommand      = "sudo journalctl --no-pager -u rancher-system-agent -u rke2-server -u rke2-agent -u k3s -u k3s-agent"
+	labelSelectorFilter = "labelSelector"
+	windowsSuffix       = "-windows"
+)
+
+// collectCluster writes the provisioning cluster and machines, the node conditions and the pods that are not running
+// of the given cluster to the given directory, and the journals of its node driver machines to the given journals
+// directory.
+func collectCluster(client *rancher.Client, cluster stateCluster, dir, journals string) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		logrus.Warnf("Failed to create artifacts directory %s. Error: %v", dir, err)
+		return
+	}
+
+	if cluster.v2 {
+		collectProvisioningCluster(client, cluster.name, dir, journals)
+	}
+
+	if cluster.id == "" {
+		return
+	}
+
+	err = collectNodes(client, cluster.id, filepath.Join(dir, nodesFile))
+	if err != nil {
+		logrus.Warnf("Failed to collect the nodes of cluster %s. Error: %v", cluster.name, err)
+	}
+
+	err = collectPods(client, cluster.id, filepath.Join(dir, podsFile))
+	if err != nil {
+		logrus.Warnf("Failed to collect the pods of cluster %s. Error: %v", cluster.name, err)
+	}
+}
+
+// collectProvisioningCluster writes the provisioning.cattle.io cluster with the given name and its machines to the
+// given directory, and the journals of its node driver machines to the given journals directory.
+func collectProvisioningCluster(client *rancher.Client, name, dir, journals string) {
+	cluster, err := client.Steve.SteveType(stevetypes.Provisioning).ByID(fleetDefault + "/" + name)
+	if err != nil {
+		logrus.Warnf("Failed to get provisioning cluster %s. Error: %v", name, err)
+		return
+	}
+
+	err = writeYAML(filepath.Join(dir, clusterFile), cluster.JSONResp)
+	if err != nil {
+		logrus.Warnf("Failed to write provisioning cluster %s. Error: %v", name, err)
+	}
+
+	query := url.Values{labelSelectorFilter: []string{clusterNameLabel + "=" + name}}
+
+	machines, err := client.Steve.SteveType(stevetypes.Machine).NamespacedSteveClient(fleetDefault).List(query)
+	if err != nil {
+		logrus.Warnf("Failed to list the machines of cluster %s. Error: %v", name, err)
+		return
+	}
+
+	var machineObjects []map[string]any
+	for _, machine := range machines.Data {
+		machineObjects = append(machineObjects, machine.JSONResp)
+	}
+
+	err = writeYAML(filepath.Join(dir, machinesFile), machineObjects)
+	if err != nil {
+		logrus.Warnf("Failed to write the machines of cluster %s. Error: %v", name, err)
+	}
+
+	clusterSpec := &provv1.ClusterSpec{}
+
+	err = v1.ConvertToK8sType(cluster.Spec, clusterSpec)
+	if err != nil || clusterSpec.RKEConfig == nil || len(clusterSpec.RKEConfig.MachinePools) == 0 {
+		return
+	}
+
+	sshUser, err := sshkeys.GetSSHUser(client, cluster)
+	if err != nil {
+		logrus.Warnf("Failed to get the SSH user of cluster %s. Error: %v", name, err)
+		return
+	}
+
+	for _, machine := range machines.Data {
+		node, err := sshkeys.GetSSHNodeFromMachine(client, sshUser, &machine)
+		if err != nil {
+			logrus.Warnf("Failed to get the SSH key of machine %s. Error: %v", machine.Name, err)
+			continue
+		}
+
+		collectJournal(node, machine.Name, journals)
+	}
+}
+
+// collectNodes writes the name, state and conditions of each node of the given cluster to the given file.
+func collectNodes(client *rancher.Client, clusterID, file string) error {
+	nodeList, err := client.Management.Node.List(&types.ListOpts{Filters: map[string]any{clusterIDFilter: clusterID}})
+	if err != nil {
+		return err
+	}
+
+	var nodeConditions []map[string]any
+	for _, node := range nodeList.Data {
+		nodeConditions = append(nodeConditions, map[string]any{
+			"name":              node.NodeName,
+			"state":             node.State,
+			"transitioning":     node.TransitioningMessage,
+			"conditions":        node.Conditions,
+			"externalIpAddress": node.ExternalIPAddress,
+			"ipAddress":         node.IPAddress,
+		})
+	}
+
+	return writeYAML(file, nodeConditions)
+}
+
+// collectPods writes the pods of the given cluster that are not running, with the reason reported by
+// pods.IsPodReady, to the given file. Unlike pods.StatusPods, the pods are only listed once, as the test has already
+// failed.
+func collectPods(client *rancher.Client, clusterID, file string) error {
+	downstreamClient, err := client.Steve.ProxyDownstream(clusterID)
+	if err != nil {
+		return err
+	}
+
+	podList, err := downstreamClient.SteveType(pods.PodResourceSteveType).List(nil)
+	if err != nil {
+		return err
+	}
+
+	var notRunning []string
+	for _, pod := range podList.Data {
+		ready, err := pods.IsPodReady(&pod)
+		switch {
+		case err != nil:
+			notRunning = append(notRunning, fmt.Sprintf("%s/%s: %v", pod.Namespace, pod.Name, err))
+		case !ready:
+			state := "not ready"
+			if pod.State != nil {
+				state = pod.State.Name
+			}
+
+			notRunning = append(notRunning, fmt.Sprintf("%s/%s: %s", pod.Namespace, pod.Name, state))
+		}
+	}
+
+	return os.WriteFile(file, []byte(strings.Join(notRunning, "\n")+"\n"), 0644)
+}
+
+// collectInstanceJournal writes the journal of the given AWS instance to the given journals directory, using the
+// private key and user of the given Terraform config. Windows instances and instances without a public IP, such as
+// the airgapped nodes, are skipped.
+func collectInstanceJournal(instance stateInstance, terraformConfig *config.TerraformConfig, journals string) {
+	if instance.publicIP == "" || strings.Contains(instance.address, windowsSuffix) || terraformConfig.PrivateKeyPath == "" {
+		return
+	}
+
+	sshKey, err := os.ReadFile(terraformConfig.PrivateKeyPath)
+	if err != nil {
+		logrus.Warnf("Failed to read the private key to collect the journal of %s. Error: %v", instance.address, err)
+		return
+	}
+
+	node := &nodes.Node{
+		NodeID:          instance.address,
+		PublicIPAddress: instance.publicIP,
+		SSHUser:         terraformConfig.AWSConfig.AWSUser,
+		SSHKey:          sshKey,
+	}
+
+	collectJournal(node, instance.address, journals)
+}
+
+// collectJournal writes the rancher-system-agent, rke2 and k3s journal of the given node, redacted, to the given
+// journals directory.
+func collectJournal(node *nodes.Node, name, journals string) {
+	err := os.MkdirAll(journals, 0755)
+	if err != nil {
+		logrus.Warnf("Failed to create artifacts directory %s. Error: %v", journals, err)
+		return
+	}
+
+	output, err := node.ExecuteCommand(journalCommand)
+	if err != nil {
+		logrus.Warnf("Failed to collect the journal of %s. Error: %v", name, err)
+		if output == "" {
+			return
+		}
+	}
+
+	err = os.WriteFile(filepath.Join(journals, fileName(name)+".log"), []byte(redact.String(output)), 0644)
+	if err != nil {
+		logrus.Warnf("Failed to write the journal of %s. Error: %v", name, err)
+	}
+}
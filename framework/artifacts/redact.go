@@ -0,0 +1,111 @@
+package artifacts
+
+import (
+	"encoding/json"
+
+	"github.com/rancher/tfp-automation/framework/redact"
+)
+
+// redactConfig returns the given generated configuration, or any other text, with the registered sensitive values and
+// the values of its sensitive attributes redacted.
+func redactConfig(contents []byte) []byte {
+	return []byte(redact.String(string(contents)))
+}
+
+// redactState returns the given terraform show -json output, indented, with the values Terraform marks as sensitive,
+// the sensitive outputs, the values of sensitive fields and the registered sensitive values redacted.
+func redactState(state []byte) ([]byte, error) {
+	var show map[string]any
+
+	err := json.Unmarshal(state, &show)
+	if err != nil {
+		return nil, err
+	}
+
+	if values, ok := show["values"].(map[string]any); ok {
+		if outputs, ok := values["outputs"].(map[string]any); ok {
+			for _, output := range outputs {
+				if output, ok := output.(map[string]any); ok && output["sensitive"] == true {
+					output["value"] = redact.Mask
+				}
+			}
+		}
+
+		if rootModule, ok := values["root_module"].(map[string]any); ok {
+			redactModule(rootModule)
+		}
+	}
+
+	contents, err := json.MarshalIndent(redactFields(show), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return redactConfig(contents), nil
+}
+
+// redactModule redacts the sensitive values of every resource of the given state module and its child modules.
+func redactModule(module map[string]any) {
+	resources, _ := module["resources"].([]any)
+	for _, resource := range resources {
+		if resource, ok := resource.(map[string]any); ok {
+			resource["values"] = redactSensitive(resource["values"], resource["sensitive_values"])
+		}
+	}
+
+	childModules, _ := module["child_modules"].([]any)
+	for _, childModule := range childModules {
+		if childModule, ok := childModule.(map[string]any); ok {
+			redactModule(childModule)
+		}
+	}
+}
+
+// redactSensitive returns the given value with every part marked true in the given sensitive_values tree redacted.
+func redactSensitive(value, sensitive any) any {
+	switch sensitive := sensitive.(type) {
+	case bool:
+		if sensitive {
+			return redact.Mask
+		}
+	case map[string]any:
+		if values, ok := value.(map[string]any); ok {
+			for key, nested := range sensitive {
+				if _, ok := values[key]; ok {
+					values[key] = redactSensitive(values[key], nested)
+				}
+			}
+		}
+	case []any:
+		if values, ok := value.([]any); ok {
+			for i, nested := range sensitive {
+				if i < len(values) {
+					values[i] = redactSensitive(values[i], nested)
+				}
+			}
+		}
+	}
+
+	return value
+}
+
+// redactFields returns the given value with the strings held by sensitive fields redacted, at any depth.
+func redactFields(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, nested := range value {
+			if _, ok := nested.(string); ok && redact.SensitiveName.MatchString(key) {
+				value[key] = redact.Mask
+				continue
+			}
+
+			value[key] = redactFields(nested)
+		}
+	case []any:
+		for i, nested := range value {
+			value[i] = redactFields(nested)
+		}
+	}
+
+	return value
+}
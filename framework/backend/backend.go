@@ -0,0 +1,323 @@
+package backend
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	HTTP = "http"
+	PG   = "pg"
+	S3   = "s3"
+
+	WorkspaceEnvVar  = "TF_WORKSPACE"
+	DefaultWorkspace = "default"
+
+	awsAccessKeyEnvVar = "AWS_ACCESS_KEY_ID"
+	awsSecretKeyEnvVar = "AWS_SECRET_ACCESS_KEY"
+	httpPasswordEnvVar = "TF_HTTP_PASSWORD"
+	httpUsernameEnvVar = "TF_HTTP_USERNAME"
+	pgConnStrEnvVar    = "PG_CONN_STR"
+
+	defaultKey                = "terraform.tfstate"
+	defaultWorkspaceKeyPrefix = "tfp-automation"
+
+	address                 = "address"
+	backendBlock            = "backend"
+	bucket                  = "bucket"
+	endpoints               = "endpoints"
+	key                     = "key"
+	lockAddress             = "lock_address"
+	region                  = "region"
+	schemaName              = "schema_name"
+	skipCredentialsValidate = "skip_credentials_validation"
+	skipMetadataAPICheck    = "skip_metadata_api_check"
+	skipRegionValidation    = "skip_region_validation"
+	skipRequestingAccountID = "skip_requesting_account_id"
+	terraformBlock          = "terraform"
+	unlockAddress           = "unlock_address"
+	useLockfile             = "use_lockfile"
+	usePathStyle            = "use_path_style"
+	workspaceKeyPrefix      = "workspace_key_prefix"
+)
+
+// runStartedLayout is the layout of the time a run started at, as held by the name of its working directory.
+const runStartedLayout = "20060102T150405Z"
+
+var (
+	invalidWorkspaceChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+	// runStarted matches the time a run started at in the name of its working directory or workspace, followed by the
+	// random suffix of the working directory.
+	runStarted = regexp.MustCompile(`-(\d{8}T\d{6}Z)-\d+$`)
+)
+
+// Type is a function that will return the type of the given backend, s3, http or pg, or an empty string when no
+// remote backend is set and the state is kept locally.
+func Type(backend *config.Backend) string {
+	switch {
+	case backend == nil:
+		return ""
+	case backend.S3 != nil:
+		return S3
+	case backend.HTTP != nil:
+		return HTTP
+	case backend.PG != nil:
+		return PG
+	default:
+		return ""
+	}
+}
+
+// UsesWorkspaces is a function that will return whether each run keeps its state in its own workspace of the given
+// backend. The http backend has no workspaces, so each run keeps its state at its own address instead.
+func UsesWorkspaces(backend *config.Backend) bool {
+	backendType := Type(backend)
+
+	return backendType == S3 || backendType == PG
+}
+
+// SetBlock is a function that will set the backend block for the given backend in the given terraform block body.
+// Only the settings without secrets are written; the credentials are passed through the environment by SetOptions.
+func SetBlock(tfBlockBody *hclwrite.Body, backend *config.Backend) {
+	backendType := Type(backend)
+	if backendType == "" {
+		return
+	}
+
+	backendBlockBody := tfBlockBody.AppendNewBlock(backendBlock, []string{backendType}).Body()
+
+	switch backendType {
+	case S3:
+		stateKey := backend.S3.Key
+		if stateKey == "" {
+			stateKey = defaultKey
+		}
+
+		prefix := backend.S3.WorkspaceKeyPrefix
+		if prefix == "" {
+			prefix = defaultWorkspaceKeyPrefix
+		}
+
+		backendBlockBody.SetAttributeValue(bucket, cty.StringVal(backend.S3.Bucket))
+		backendBlockBody.SetAttributeValue(key, cty.StringVal(stateKey))
+		backendBlockBody.SetAttributeValue(region, cty.StringVal(backend.S3.Region))
+		backendBlockBody.SetAttributeValue(workspaceKeyPrefix, cty.StringVal(prefix))
+
+		if backend.S3.UseLockfile {
+			backendBlockBody.SetAttributeValue(useLockfile, cty.BoolVal(true))
+		}
+
+		if backend.S3.Endpoint != "" {
+			backendBlockBody.SetAttributeValue(endpoints, cty.ObjectVal(map[string]cty.Value{
+				S3: cty.StringVal(backend.S3.Endpoint),
+			}))
+			backendBlockBody.SetAttributeValue(usePathStyle, cty.BoolVal(true))
+			backendBlockBody.SetAttributeValue(skipCredentialsValidate, cty.BoolVal(true))
+			backendBlockBody.SetAttributeValue(skipMetadataAPICheck, cty.BoolVal(true))
+			backendBlockBody.SetAttributeValue(skipRegionValidation, cty.BoolVal(true))
+			backendBlockBody.SetAttributeValue(skipRequestingAccountID, cty.BoolVal(true))
+		}
+	case PG:
+		if backend.PG.SchemaName != "" {
+			backendBlockBody.SetAttributeValue(schemaName, cty.StringVal(backend.PG.SchemaName))
+		}
+	}
+}
+
+// SetOptions is a function that will set the credentials of the given backend as environment variables of the given
+// options. For the http backend, the state of the given run is kept at its own address, passed as backend config.
+func SetOptions(terraformOptions *terraform.Options, backend *config.Backend, runID string) {
+	backendType := Type(backend)
+	if backendType == "" {
+		return
+	}
+
+	if terraformOptions.EnvVars == nil {
+		terraformOptions.EnvVars = map[string]string{}
+	}
+
+	envVars := map[string]string{}
+
+	switch backendType {
+	case S3:
+		envVars[awsAccessKeyEnvVar] = backend.S3.AccessKey
+		envVars[awsSecretKeyEnvVar] = backend.S3.SecretKey
+	case HTTP:
+		envVars[httpUsernameEnvVar] = backend.HTTP.Username
+		envVars[httpPasswordEnvVar] = backend.HTTP.Password
+
+		if terraformOptions.BackendConfig == nil {
+			terraformOptions.BackendConfig = map[string]any{}
+		}
+
+		runAddresses := map[string]string{
+			address:       backend.HTTP.Address,
+			lockAddress:   backend.HTTP.LockAddress,
+			unlockAddress: backend.HTTP.UnlockAddress,
+		}
+
+		for name, runAddress := range runAddresses {
+			if runAddress != "" {
+				terraformOptions.BackendConfig[name] = strings.TrimSuffix(runAddress, "/") + "/" + runID
+			}
+		}
+	case PG:
+		envVars[pgConnStrEnvVar] = backend.PG.ConnStr
+	}
+
+	for name, value := range envVars {
+		if value != "" {
+			terraformOptions.EnvVars[name] = value
+		}
+	}
+}
+
+// Configure is a function that will set up the given options to keep the state of the given run in the remote
+// backend set by the given Terraform config. For backends with workspaces, the run's workspace is created and selected
+// through TF_WORKSPACE, so that runs sharing the backend never share state.
+func Configure(t *testing.T, terraformOptions *terraform.Options, terraformConfig *config.TerraformConfig, runID string) {
+	if Type(terraformConfig.Backend) == "" {
+		return
+	}
+
+	runID = Workspace(runID)
+	SetOptions(terraformOptions, terraformConfig.Backend, runID)
+
+	logrus.Infof("Keeping Terraform state in the %s backend for run %s.", Type(terraformConfig.Backend), runID)
+
+	if !UsesWorkspaces(terraformConfig.Backend) {
+		return
+	}
+
+	scratchOptions, err := scratchOptions(t.TempDir(), terraformOptions, terraformConfig.Backend)
+	require.NoError(t, err)
+
+	terraform.Init(t, scratchOptions)
+	terraform.WorkspaceSelectOrNew(t, scratchOptions, runID)
+
+	terraformOptions.EnvVars[WorkspaceEnvVar] = runID
+}
+
+// Workspaces is a function that will return the workspaces, other than the default one, of the remote backend that the
+// given options are initialized against.
+func Workspaces(t *testing.T, terraformOptions *terraform.Options) ([]string, error) {
+	options, err := defaultWorkspaceOptions(terraformOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := terraform.RunTerraformCommandE(t, options, "workspace", "list")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseWorkspaces(out), nil
+}
+
+// parseWorkspaces returns the sorted workspaces, other than the default one, listed in the given output of terraform
+// workspace list.
+func parseWorkspaces(out string) []string {
+	workspaces := []string{}
+
+	for _, line := range strings.Split(out, "\n") {
+		workspace := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+		if workspace != "" && workspace != DefaultWorkspace {
+			workspaces = append(workspaces, workspace)
+		}
+	}
+
+	sort.Strings(workspaces)
+
+	return workspaces
+}
+
+// DeleteWorkspace is a function that will delete the given workspace, once its resources are destroyed, from the
+// remote backend that the given options are initialized against.
+func DeleteWorkspace(t *testing.T, terraformOptions *terraform.Options, workspace string) error {
+	options, err := defaultWorkspaceOptions(terraformOptions)
+	if err != nil {
+		return err
+	}
+
+	_, err = terraform.WorkspaceDeleteE(t, options, workspace)
+	if err != nil {
+		logrus.Errorf("Failed to delete the %s workspace. Error: %v", workspace, err)
+		return err
+	}
+
+	return nil
+}
+
+// Workspace is a function that will return the given run ID with the characters not allowed in workspace names
+// replaced.
+func Workspace(runID string) string {
+	return invalidWorkspaceChars.ReplaceAllString(runID, "_")
+}
+
+// RunPrefix is a function that will return the prefix of the name of the working directory of a run of the given test,
+// started at the given time. The workspace of the run is named after its working directory, so it holds the time too.
+func RunPrefix(testName string, started time.Time) string {
+	return Workspace(testName) + "-" + started.UTC().Format(runStartedLayout) + "-"
+}
+
+// RunStarted is a function that will return the time the run with the given working directory or workspace name
+// started at, and false if the name does not hold it.
+func RunStarted(runID string) (time.Time, bool) {
+	match := runStarted.FindStringSubmatch(runID)
+	if match == nil {
+		return time.Time{}, false
+	}
+
+	started, err := time.Parse(runStartedLayout, match[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return started, true
+}
+
+// defaultWorkspaceOptions returns a copy of the given options that runs against the default workspace.
+func defaultWorkspaceOptions(terraformOptions *terraform.Options) (*terraform.Options, error) {
+	options, err := terraformOptions.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	delete(options.EnvVars, WorkspaceEnvVar)
+
+	return options, nil
+}
+
+// scratchOptions writes a module holding only the given backend to the given directory and returns a copy of the
+// given options that runs against it, on the default workspace.
+func scratchOptions(dir string, terraformOptions *terraform.Options, backend *config.Backend) (*terraform.Options, error) {
+	newFile := hclwrite.NewEmptyFile()
+	SetBlock(newFile.Body().AppendNewBlock(terraformBlock, nil).Body(), backend)
+
+	err := os.WriteFile(dir+configs.MainTF, newFile.Bytes(), 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := defaultWorkspaceOptions(terraformOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	options.TerraformDir = dir
+
+	return options, nil
+}
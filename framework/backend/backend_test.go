@@ -0,0 +1,93 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetBlock(t *testing.T) {
+	newFile := hclwrite.NewEmptyFile()
+	SetBlock(newFile.Body(), &config.Backend{S3: &config.S3Backend{
+		AccessKey:   "minioadmin",
+		Bucket:      "tfstate",
+		Endpoint:    "http://localhost:9000",
+		Region:      "us-east-1",
+		SecretKey:   "minioadmin",
+		UseLockfile: true,
+	}})
+
+	require.Equal(t, `backend "s3" {
+  bucket               = "tfstate"
+  key                  = "terraform.tfstate"
+  region               = "us-east-1"
+  workspace_key_prefix = "tfp-automation"
+  use_lockfile         = true
+  endpoints = {
+    s3 = "http://localhost:9000"
+  }
+  use_path_style              = true
+  skip_credentials_validation = true
+  skip_metadata_api_check     = true
+  skip_region_validation      = true
+  skip_requesting_account_id  = true
+}
+`, string(newFile.Bytes()))
+
+	newFile = hclwrite.NewEmptyFile()
+	SetBlock(newFile.Body(), &config.Backend{HTTP: &config.HTTPBackend{Address: "http://localhost:8080/state", Password: "secret"}})
+	require.Equal(t, "backend \"http\" {\n}\n", string(newFile.Bytes()))
+
+	newFile = hclwrite.NewEmptyFile()
+	SetBlock(newFile.Body(), nil)
+	require.Empty(t, newFile.Bytes())
+}
+
+func TestSetOptions(t *testing.T) {
+	terraformOptions := &terraform.Options{EnvVars: map[string]string{"TF_CLI_CONFIG_FILE": "/tmp/.terraformrc"}}
+	SetOptions(terraformOptions, &config.Backend{S3: &config.S3Backend{AccessKey: "access", SecretKey: "secret"}}, "run")
+
+	require.Equal(t, map[string]string{
+		"TF_CLI_CONFIG_FILE":    "/tmp/.terraformrc",
+		"AWS_ACCESS_KEY_ID":     "access",
+		"AWS_SECRET_ACCESS_KEY": "secret",
+	}, terraformOptions.EnvVars)
+	require.Empty(t, terraformOptions.BackendConfig)
+
+	terraformOptions = &terraform.Options{}
+	SetOptions(terraformOptions, &config.Backend{HTTP: &config.HTTPBackend{
+		Address:     "http://localhost:8080/state/",
+		LockAddress: "http://localhost:8080/lock",
+		Username:    "user",
+	}}, "run")
+
+	require.Equal(t, map[string]any{
+		"address":      "http://localhost:8080/state/run",
+		"lock_address": "http://localhost:8080/lock/run",
+	}, terraformOptions.BackendConfig)
+	require.Equal(t, map[string]string{"TF_HTTP_USERNAME": "user"}, terraformOptions.EnvVars)
+}
+
+func TestWorkspaces(t *testing.T) {
+	require.Equal(t, "TestTfpProvisioning_ec2-123", Workspace("TestTfpProvisioning/ec2-123"))
+	require.Equal(t, []string{"TestA", "TestB"}, parseWorkspaces("* default\n  TestB\n  TestA\n\n"))
+	require.Empty(t, parseWorkspaces("* default\n"))
+}
+
+func TestRunStarted(t *testing.T) {
+	started := time.Date(2026, 1, 2, 12, 30, 45, 0, time.UTC)
+
+	prefix := RunPrefix("TestProvisioning/RKE2 3 nodes", started)
+	require.Equal(t, "TestProvisioning_RKE2_3_nodes-20260102T123045Z-", prefix)
+
+	runStartedAt, ok := RunStarted(prefix + "1234567890")
+	require.True(t, ok)
+	require.Equal(t, started, runStartedAt)
+
+	_, ok = RunStarted("TestProvisioning_RKE2_3_nodes-1234567890")
+	require.False(t, ok)
+}
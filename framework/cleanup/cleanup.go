@@ -6,18 +6,45 @@ import (
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/shepherd/pkg/config"
+	configuration "github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/framework/artifacts"
+	"github.com/rancher/tfp-automation/framework/backend"
 	"github.com/sirupsen/logrus"
 )
 
-// Cleanup is a function that will run terraform destroy and cleanup Terraform resources.
+// Cleanup is a function that will run terraform destroy and cleanup Terraform resources. When the state is kept in a
+// workspace of a remote backend, the workspace is deleted as well. When destroyClusters is set in the terratest config,
+// only the resources of those clusters are destroyed, and the rest of the module, its state and its files are kept.
+// When the test has failed, its artifacts are collected first, whether or not the resources are cleaned up.
 func Cleanup(t *testing.T, terraformOptions *terraform.Options, keyPath string) {
+	if t.Failed() {
+		artifacts.Collect(t, terraformOptions)
+	}
+
 	rancherConfig := new(rancher.Config)
 	config.LoadConfig(configs.Rancher, rancherConfig)
 
 	if *rancherConfig.Cleanup {
+		terratestConfig := new(configuration.TerratestConfig)
+		config.LoadConfig(configuration.TerratestConfigurationFileKey, terratestConfig)
+
+		if len(terratestConfig.DestroyClusters) > 0 {
+			for _, resourcePrefix := range terratestConfig.DestroyClusters {
+				logrus.Infof("Cleaning up the Terraform resources of cluster %s...", resourcePrefix)
+				DestroyCluster(t, terraformOptions, keyPath, resourcePrefix)
+			}
+
+			return
+		}
+
 		logrus.Infof("Cleaning up Terraform resources...")
 		terraform.Destroy(t, terraformOptions)
+
+		if workspace := terraformOptions.EnvVars[backend.WorkspaceEnvVar]; workspace != "" {
+			backend.DeleteWorkspace(t, terraformOptions, workspace)
+		}
+
 		TFFilesCleanup(keyPath)
 	}
 }
@@ -7,19 +7,16 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// TFFilesCleanup is a function that will cleanup the main.tf file and terraform.tfstate files.
+// TFFilesCleanup is a function that will cleanup the main.tf file, the .tf files written by ConfigTF, the input
+// variable files and terraform.tfstate files. The state files are missing when the state is kept in a remote backend.
 func TFFilesCleanup(keyPath string) error {
-	file, err := os.Create(keyPath + configs.MainTF)
+	err := ResetMainTF(keyPath)
 	if err != nil {
-		logrus.Errorf("Failed to overwrite main.tf file. Error: %v", err)
 		return err
 	}
 
-	defer file.Close()
-
-	_, err = file.WriteString("// Leave blank - main.tf will be set during testing")
+	err = RemoveGeneratedFiles(keyPath)
 	if err != nil {
-		logrus.Errorf("Failed to write to main.tf file. Error: %v", err)
 		return err
 	}
 
@@ -29,12 +26,23 @@ func TFFilesCleanup(keyPath string) error {
 		delete_file = keyPath + delete_file
 		err = os.Remove(delete_file)
 
-		if err != nil {
+		if err != nil && !os.IsNotExist(err) {
 			logrus.Errorf("Failed to delete terraform.tfstate, terraform.tfstate.backup, and terraform.lock.hcl files. Error: %v", err)
 			return err
 		}
 	}
 
+	variablesFiles := [2]string{configs.VariablesTF, configs.TFVarsJSON}
+
+	for _, variablesFile := range variablesFiles {
+		err = os.Remove(keyPath + variablesFile)
+
+		if err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("Failed to delete variables.tf and terraform.tfvars.json files. Error: %v", err)
+			return err
+		}
+	}
+
 	err = os.RemoveAll(keyPath + configs.TerraformFolder)
 	if err != nil {
 		logrus.Errorf("Failed to delete .terraform folder. Error: %v", err)
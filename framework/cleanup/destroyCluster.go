@@ -0,0 +1,104 @@
+package cleanup
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/stretchr/testify/require"
+)
+
+const resource = "resource"
+
+// ClusterTargets is a function that will return the address of every resource declared in the .tf or .tf.json file
+// ConfigTF wrote for the cluster with the given resourcePrefix.
+func ClusterTargets(keyPath, resourcePrefix string) ([]string, error) {
+	clusterFile := keyPath + "/" + resourcePrefix + configs.TFExtension
+
+	contents, err := os.ReadFile(clusterFile)
+	if os.IsNotExist(err) {
+		return jsonClusterTargets(clusterFile+configs.JSONExtension, resourcePrefix)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	file, diags := hclwrite.ParseConfig(contents, clusterFile, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	targets := []string{}
+
+	for _, block := range file.Body().Blocks() {
+		if block.Type() != resource || len(block.Labels()) != 2 {
+			continue
+		}
+
+		targets = append(targets, strings.Join(block.Labels(), "."))
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no resources found for the %s cluster in %s", resourcePrefix, clusterFile)
+	}
+
+	return targets, nil
+}
+
+// jsonClusterTargets returns the address of every resource declared in the given .tf.json file.
+func jsonClusterTargets(clusterFile, resourcePrefix string) ([]string, error) {
+	contents, err := os.ReadFile(clusterFile)
+	if err != nil {
+		return nil, err
+	}
+
+	module := struct {
+		Resource map[string]map[string]json.RawMessage `json:"resource"`
+	}{}
+
+	err = json.Unmarshal(contents, &module)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []string{}
+
+	for resourceType, resources := range module.Resource {
+		for resourceName := range resources {
+			targets = append(targets, resourceType+"."+resourceName)
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no resources found for the %s cluster in %s", resourcePrefix, clusterFile)
+	}
+
+	sort.Strings(targets)
+
+	return targets, nil
+}
+
+// DestroyCluster is a function that will run terraform destroy targeting only the resources of the cluster with the
+// given resourcePrefix, leaving the other clusters, the providers and the test user in place. The configs passed to
+// ConfigTF afterwards must no longer include the destroyed cluster, or the next apply creates it again.
+func DestroyCluster(t *testing.T, terraformOptions *terraform.Options, keyPath, resourcePrefix string) {
+	targets, err := ClusterTargets(keyPath, resourcePrefix)
+	require.NoError(t, err)
+
+	previousTargets := terraformOptions.Targets
+	terraformOptions.Targets = targets
+
+	defer func() {
+		terraformOptions.Targets = previousTargets
+	}()
+
+	terraform.Destroy(t, terraformOptions)
+}
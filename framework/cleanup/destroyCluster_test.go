@@ -0,0 +1,47 @@
+package cleanup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestroyCluster(t *testing.T) {
+	keyPath := t.TempDir()
+	argsFile := filepath.Join(keyPath, "args")
+
+	// The fake terraform binary records the arguments it is called with, one per line.
+	binary := filepath.Join(keyPath, "terraform")
+	err := os.WriteFile(binary, []byte("#!/bin/sh\nprintf '%s\\n' \"$@\" > "+argsFile+"\n"), 0700)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(keyPath, "tfp-rke2.tf"), []byte(`resource "rancher2_cluster_v2" "tfp-rke2" {
+  name = "tfp-rke2"
+}
+
+resource "rancher2_machine_config_v2" "tfp-rke2" {
+  generate_name = "tfp-rke2"
+}
+`), 0644)
+	require.NoError(t, err)
+
+	terraformOptions := &terraform.Options{
+		TerraformBinary: binary,
+		TerraformDir:    keyPath,
+		Targets:         []string{"previous"},
+		NoColor:         true,
+	}
+
+	DestroyCluster(t, terraformOptions, keyPath, "tfp-rke2")
+
+	args, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	require.Contains(t, string(args), "-target\nrancher2_cluster_v2.tfp-rke2\n")
+	require.Contains(t, string(args), "-target\nrancher2_machine_config_v2.tfp-rke2\n")
+	require.NotContains(t, string(args), "previous")
+
+	require.Equal(t, []string{"previous"}, terraformOptions.Targets)
+}
@@ -0,0 +1,106 @@
+package cleanup
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/sirupsen/logrus"
+)
+
+// GeneratedFiles is a function that will return the .tf and .tf.json files in the given working directory that were
+// written by ConfigTF, recognized by their header, so that hand-written files such as outputs.tf are left untouched.
+func GeneratedFiles(keyPath string) ([]string, error) {
+	tfFiles, err := filepath.Glob(filepath.Join(keyPath, "*"+configs.TFExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	tfJSONFiles, err := filepath.Glob(filepath.Join(keyPath, "*"+configs.TFExtension+configs.JSONExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	tfFiles = append(tfFiles, tfJSONFiles...)
+
+	generatedFiles := []string{}
+
+	for _, tfFile := range tfFiles {
+		generated, err := isGenerated(tfFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if generated {
+			generatedFiles = append(generatedFiles, tfFile)
+		}
+	}
+
+	return generatedFiles, nil
+}
+
+// RemoveGeneratedFiles is a function that will delete the .tf and .tf.json files in the given working directory that
+// were written by ConfigTF, such as providers.tf, users.tf, locals.tf and the file of each cluster.
+func RemoveGeneratedFiles(keyPath string) error {
+	generatedFiles, err := GeneratedFiles(keyPath)
+	if err != nil {
+		logrus.Errorf("Failed to list generated .tf files. Error: %v", err)
+		return err
+	}
+
+	for _, generatedFile := range generatedFiles {
+		err = os.Remove(generatedFile)
+		if err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("Failed to delete %s file. Error: %v", filepath.Base(generatedFile), err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResetMainTF is a function that will overwrite the main.tf file in the given working directory with its placeholder.
+func ResetMainTF(keyPath string) error {
+	err := os.WriteFile(keyPath+configs.MainTF, []byte(configs.MainTFPlaceholder), 0644)
+	if err != nil {
+		logrus.Errorf("Failed to overwrite main.tf file. Error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// isGenerated checks if the first line of the given file is the header ConfigTF writes, or, for a .tf.json file, if its
+// "//" property is the comment ConfigTF writes.
+func isGenerated(tfFile string) (bool, error) {
+	if strings.HasSuffix(tfFile, configs.JSONExtension) {
+		contents, err := os.ReadFile(tfFile)
+		if err != nil {
+			return false, err
+		}
+
+		module := map[string]any{}
+		if json.Unmarshal(contents, &module) != nil {
+			return false, nil
+		}
+
+		return module["//"] == configs.GeneratedTFComment, nil
+	}
+
+	file, err := os.Open(tfFile)
+	if err != nil {
+		return false, err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		return false, scanner.Err()
+	}
+
+	return scanner.Text() == configs.GeneratedTFHeader, nil
+}
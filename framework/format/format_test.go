@@ -0,0 +1,61 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// render writes the given expression as the value of an attribute, and checks that the result is valid HCL.
+func render(t *testing.T, expression hclwrite.Tokens) string {
+	file := hclwrite.NewEmptyFile()
+	file.Body().SetAttributeRaw("value", expression)
+
+	_, diags := hclsyntax.ParseConfig(file.Bytes(), "main.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	return string(file.Bytes())
+}
+
+func TestListOfStringsKeepsDuplicates(t *testing.T) {
+	require.Equal(t, "value = [\"a\", \"b\", \"a\"]\n", render(t, ListOfStrings([]string{"a", "b", "a"})))
+	require.Equal(t, "value = []\n", render(t, ListOfStrings(nil)))
+}
+
+func TestReferences(t *testing.T) {
+	instance := Index(Reference("aws_instance", "tfp-windows"), Reference("count", "index"))
+
+	require.Equal(t, "value = aws_instance.tfp-windows[count.index].public_ip\n", render(t, Attribute(instance, "public_ip")))
+	require.Equal(t, "value = [null_resource.one, null_resource.two]\n",
+		render(t, ListOfReferences(Reference("null_resource", "one"), Reference("null_resource", "two"))))
+}
+
+func TestString(t *testing.T) {
+	command := FunctionCall("replace", Reference("local", "command"), hclwrite.TokensForValue(cty.StringVal("curl")))
+
+	require.Equal(t, "value = \"say \\\"$${x}\\\" ${replace(local.command, \"curl\")}\"\n",
+		render(t, String(Literal(`say "${x}" `), Interpolate(command))))
+}
+
+func TestHeredoc(t *testing.T) {
+	require.Equal(t, "value = <<EOF\ncni: $${calico}\nEOF\n", render(t, Heredoc("cni: ${calico}")))
+	require.Equal(t, "value = <<EOT\nEOF\nEOT\n", render(t, Heredoc("EOF\n")))
+}
+
+func TestFile(t *testing.T) {
+	require.Equal(t, "value = file(\"/tmp/key.pem\")\n", render(t, File("/tmp/key.pem")))
+}
+
+func TestObject(t *testing.T) {
+	object := Object(map[string]hclwrite.Tokens{
+		"kubeconfig": Reference("rancher2_cluster_v2", "tfp", "kube_config"),
+		"cluster_id": Reference("rancher2_cluster_v2", "tfp", "cluster_v1_id"),
+	})
+
+	require.Equal(t, "value = {\n  cluster_id = rancher2_cluster_v2.tfp.cluster_v1_id\n  kubeconfig = rancher2_cluster_v2.tfp.kube_config\n}\n",
+		render(t, object))
+}
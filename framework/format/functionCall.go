@@ -0,0 +1,17 @@
+package format
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// FunctionCall is a function that will format a call of the given function with the given arguments, such as
+// length(aws_instance.name), into HCL tokens.
+func FunctionCall(name string, arguments ...hclwrite.Tokens) hclwrite.Tokens {
+	return hclwrite.TokensForFunctionCall(name, cloneAll(arguments)...)
+}
+
+// File is a function that will format a call of the file function reading the given path into HCL tokens.
+func File(path string) hclwrite.Tokens {
+	return FunctionCall("file", hclwrite.TokensForValue(cty.StringVal(path)))
+}
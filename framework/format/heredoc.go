@@ -0,0 +1,52 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+var heredocDelimiters = []string{"EOF", "EOT", "END", "CONTENT"}
+
+// Heredoc is a function that will format the given content into a HCL heredoc string. Template sequences are escaped
+// so the content is kept as is, and the delimiter is chosen so that it does not appear as a line of the content.
+func Heredoc(content string) hclwrite.Tokens {
+	content = strings.NewReplacer("${", "$${", "%{", "%%{").Replace(content)
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	lines = lines[:len(lines)-1]
+
+	delimiter := heredocDelimiter(lines)
+
+	tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOHeredoc, Bytes: []byte("<<" + delimiter + "\n")}}
+	for _, line := range lines {
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenStringLit, Bytes: []byte(line)})
+	}
+
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCHeredoc, Bytes: []byte(delimiter)})
+}
+
+// heredocDelimiter returns the first delimiter that does not close the heredoc early on any of the given lines.
+func heredocDelimiter(lines []string) string {
+	used := map[string]bool{}
+	for _, line := range lines {
+		used[strings.TrimSpace(line)] = true
+	}
+
+	for _, delimiter := range heredocDelimiters {
+		if !used[delimiter] {
+			return delimiter
+		}
+	}
+
+	delimiter := heredocDelimiters[0]
+	for used[delimiter] {
+		delimiter += "_"
+	}
+
+	return delimiter
+}
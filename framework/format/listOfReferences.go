@@ -0,0 +1,11 @@
+package format
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// ListOfReferences is a function that will format a list of references, such as the ones used by depends_on, into a
+// HCL list.
+func ListOfReferences(references ...hclwrite.Tokens) hclwrite.Tokens {
+	return hclwrite.TokensForTuple(cloneAll(references))
+}
@@ -1,26 +1,16 @@
 package format
 
 import (
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // ListOfStrings is a function that will format a list of strings into a HCL list.
 func ListOfStrings(list []string) hclwrite.Tokens {
-	stringOfValues := ``
-
+	values := []hclwrite.Tokens{}
 	for _, value := range list {
-		if value != list[len(list)-1] {
-			stringOfValues += `"` + value + `"` + `, `
-		}
-		if value == list[len(list)-1] {
-			stringOfValues += `"` + value + `"`
-		}
-	}
-
-	formattedList := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(`[` + stringOfValues + `]`)},
+		values = append(values, hclwrite.TokensForValue(cty.StringVal(value)))
 	}
 
-	return formattedList
+	return hclwrite.TokensForTuple(values)
 }
@@ -0,0 +1,28 @@
+package format
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// Object is a function that will format the given attributes into a HCL object. The attributes are written in sorted
+// order, so that the generated files do not change between runs.
+func Object(attributes map[string]hclwrite.Tokens) hclwrite.Tokens {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	objectAttributes := []hclwrite.ObjectAttrTokens{}
+	for _, name := range names {
+		objectAttributes = append(objectAttributes, hclwrite.ObjectAttrTokens{
+			Name:  hclwrite.TokensForIdentifier(name),
+			Value: clone(attributes[name]),
+		})
+	}
+
+	return hclwrite.TokensForObject(objectAttributes)
+}
@@ -0,0 +1,41 @@
+package format
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// Reference is a function that will format a reference to the given root name and attribute names, such as
+// rancher2_cloud_credential.name.id, into HCL tokens.
+func Reference(root string, attributes ...string) hclwrite.Tokens {
+	traversal := hcl.Traversal{hcl.TraverseRoot{Name: root}}
+	for _, attribute := range attributes {
+		traversal = append(traversal, hcl.TraverseAttr{Name: attribute})
+	}
+
+	return hclwrite.TokensForTraversal(traversal)
+}
+
+// Attribute is a function that will append an access of the given attribute names to the given expression.
+func Attribute(expression hclwrite.Tokens, attributes ...string) hclwrite.Tokens {
+	tokens := clone(expression)
+	for _, attribute := range attributes {
+		tokens = append(tokens,
+			&hclwrite.Token{Type: hclsyntax.TokenDot, Bytes: []byte(".")},
+			&hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte(attribute)},
+		)
+	}
+
+	return tokens
+}
+
+// Index is a function that will append an index of the given key, such as count.index, to the given expression.
+func Index(expression, key hclwrite.Tokens) hclwrite.Tokens {
+	tokens := clone(expression)
+	tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")})
+	tokens = append(tokens, clone(key)...)
+	tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+
+	return tokens
+}
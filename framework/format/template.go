@@ -0,0 +1,33 @@
+package format
+
+import (
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// String is a function that will format the given literals and interpolations into a quoted HCL template string.
+func String(parts ...hclwrite.Tokens) hclwrite.Tokens {
+	tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)}}
+	for _, part := range parts {
+		tokens = append(tokens, clone(part)...)
+	}
+
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)})
+}
+
+// Literal is a function that will format the given text as a literal part of a template string. Quotes, backslashes
+// and template sequences are escaped so the text is kept as is.
+func Literal(text string) hclwrite.Tokens {
+	quoted := hclwrite.TokensForValue(cty.StringVal(text))
+
+	return quoted[1 : len(quoted)-1]
+}
+
+// Interpolate is a function that will format the given expression as an interpolated part of a template string.
+func Interpolate(expression hclwrite.Tokens) hclwrite.Tokens {
+	tokens := hclwrite.Tokens{{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte("${")}}
+	tokens = append(tokens, clone(expression)...)
+
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte("}")})
+}
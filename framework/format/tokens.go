@@ -0,0 +1,27 @@
+package format
+
+import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// clone returns a deep copy of the given tokens, so that formatting an expression built from them does not change the
+// spacing of other expressions sharing the same tokens.
+func clone(tokens hclwrite.Tokens) hclwrite.Tokens {
+	cloned := make(hclwrite.Tokens, 0, len(tokens))
+	for _, token := range tokens {
+		copied := *token
+		cloned = append(cloned, &copied)
+	}
+
+	return cloned
+}
+
+// cloneAll returns a deep copy of each of the given expressions.
+func cloneAll(expressions []hclwrite.Tokens) []hclwrite.Tokens {
+	cloned := make([]hclwrite.Tokens, 0, len(expressions))
+	for _, expression := range expressions {
+		cloned = append(cloned, clone(expression))
+	}
+
+	return cloned
+}
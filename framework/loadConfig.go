@@ -0,0 +1,65 @@
+package framework
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/redact"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const mergedConfigFile = "cattle-config.yaml"
+
+// LoadCattleConfig is a function that will load the cattle-config file set by CATTLE_TEST_CONFIG, layered with the
+// files it extends and the overlay files set by CATTLE_TEST_CONFIG_OVERLAYS, and resolve the secret references in it.
+// The resulting config is written to a temporary file only readable by the current user, and CATTLE_TEST_CONFIG
+// points to it for the rest of the test, so that the shepherd clients read the same config. The sensitive values of
+// the config are masked in the logs from then on.
+func LoadCattleConfig(t *testing.T) map[string]any {
+	cattleConfig, mergedConfig, err := LoadMergedConfig(t.TempDir())
+	require.NoError(t, err)
+
+	redact.Register(cattleConfig)
+
+	t.Setenv(shepherdConfig.ConfigEnvironmentKey, mergedConfig)
+	t.Setenv(config.OverlaysEnvironmentKey, "")
+
+	return cattleConfig
+}
+
+// LoadMergedConfig is a function that will load the cattle-config file set by CATTLE_TEST_CONFIG, layered with the
+// files it extends and the overlay files set by CATTLE_TEST_CONFIG_OVERLAYS, and resolve the secret references in it.
+// The resulting config is written to a file only readable by the current user in the given directory, and both the
+// config and the path of that file are returned.
+func LoadMergedConfig(dir string) (map[string]any, string, error) {
+	base := os.Getenv(shepherdConfig.ConfigEnvironmentKey)
+	overlays := filepath.SplitList(os.Getenv(config.OverlaysEnvironmentKey))
+
+	cattleConfig, err := config.LoadConfigFromFiles(base, overlays...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = config.ResolveSecrets(cattleConfig)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := yaml.Marshal(cattleConfig)
+	if err != nil {
+		return nil, "", err
+	}
+
+	mergedConfig := filepath.Join(dir, mergedConfigFile)
+
+	err = os.WriteFile(mergedConfig, content, 0600)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return cattleConfig, mergedConfig, nil
+}
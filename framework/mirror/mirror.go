@@ -0,0 +1,239 @@
+package mirror
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/binaries"
+	"github.com/rancher/tfp-automation/framework/format"
+	"github.com/rancher/tfp-automation/framework/providers"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	CLIConfigEnvVar = "TF_CLI_CONFIG_FILE"
+
+	cliConfigFile     = ".terraformrc"
+	defaultMirrorPath = ".terraform.d/plugins"
+	providerPrefix    = "terraform-provider-"
+	zip               = ".zip"
+
+	direct               = "direct"
+	exclude              = "exclude"
+	filesystemMirror     = "filesystem_mirror"
+	include              = "include"
+	path                 = "path"
+	providerInstallation = "provider_installation"
+)
+
+// Installation is a provider, with its address in the hostname/namespace/type form and its version constraint, that
+// is installed from the filesystem mirror at the given path.
+type Installation struct {
+	Address string
+	Version string
+	Mirror  string
+}
+
+// CLIConfig is a function that will write a CLI config, installing providers from filesystem mirrors, to the given
+// directory and return its path. Providers with a mirror set in the providers section of the given Terraform config
+// are installed from that mirror. Release candidates and other terraform.local providers, and every provider when the
+// providerMirror of the given Terratest config is offline, are installed from the default mirror. The mirrors are
+// validated to hold each of them first. An empty path is returned when no provider is installed from a mirror, so
+// that the binary keeps its default installation.
+func CLIConfig(dir string, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig) (string, error) {
+	offline := terratestConfig.ProviderMirror != nil && terratestConfig.ProviderMirror.Offline
+
+	binary, err := binaries.Binary()
+	if err != nil {
+		return "", err
+	}
+
+	defaultMirror, err := mirrorPath(terratestConfig)
+	if err != nil {
+		return "", err
+	}
+
+	installations, err := Installations(binary, defaultMirror, offline, providers.Configured(terraformConfig))
+	if err != nil {
+		return "", err
+	}
+
+	if len(installations) == 0 {
+		return "", nil
+	}
+
+	err = Validate(Platform(), installations)
+	if err != nil {
+		return "", err
+	}
+
+	offlineMirror := ""
+	if offline {
+		offlineMirror = defaultMirror
+	}
+
+	cliConfig := filepath.Join(dir, cliConfigFile)
+
+	err = WriteCLIConfig(cliConfig, installations, offlineMirror)
+	if err != nil {
+		return "", err
+	}
+
+	logrus.Infof("Installing providers from filesystem mirrors with the CLI config %s", cliConfig)
+
+	return cliConfig, nil
+}
+
+// Installations is a function that will return the given providers that the given binary installs from a filesystem
+// mirror. Providers with a mirror set are installed from it; terraform.local providers, and every provider when
+// offline, are installed from the given default mirror.
+func Installations(binary, defaultMirror string, offline bool, configured []providers.Provider) ([]Installation, error) {
+	installations := []Installation{}
+
+	for _, provider := range configured {
+		var mirror string
+
+		switch {
+		case provider.Mirror != "":
+			providerMirror, err := filepath.Abs(provider.Mirror)
+			if err != nil {
+				return nil, err
+			}
+
+			mirror = providerMirror
+		case provider.IsLocal() || offline:
+			mirror = defaultMirror
+		default:
+			continue
+		}
+
+		installations = append(installations, Installation{
+			Address: provider.Address(binary),
+			Version: provider.Version,
+			Mirror:  mirror,
+		})
+	}
+
+	return installations, nil
+}
+
+// Platform is a function that will return the platform, such as linux_amd64, that providers are installed for.
+func Platform() string {
+	return runtime.GOOS + "_" + runtime.GOARCH
+}
+
+// Validate is a function that will return an error unless the mirror of each of the given installations holds the
+// provider for the given platform, either unpacked, as scripts/setup-provider.sh installs them, or packed. When the
+// version constraint allows a range of versions, any version of the provider is accepted.
+func Validate(platform string, installations []Installation) error {
+	var errs []error
+
+	for _, installation := range installations {
+		name := installation.Address[strings.LastIndex(installation.Address, "/")+1:]
+		providerDir := filepath.Join(installation.Mirror, filepath.FromSlash(installation.Address))
+
+		version, exact := providers.Provider{Version: installation.Version}.ExactVersion()
+		if !exact {
+			version = "*"
+		}
+
+		unpacked, err := filepath.Glob(filepath.Join(providerDir, version, platform, providerPrefix+name+"*"))
+		if err != nil {
+			return err
+		}
+
+		packed, err := filepath.Glob(filepath.Join(providerDir, providerPrefix+name+"_"+version+"_"+platform+zip))
+		if err != nil {
+			return err
+		}
+
+		if len(unpacked) == 0 && len(packed) == 0 {
+			errs = append(errs, fmt.Errorf("filesystem mirror %s has no %s %s provider for %s", installation.Mirror,
+				installation.Address, installation.Version, platform))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// WriteCLIConfig is a function that will write a CLI config to the given file, installing each of the given
+// providers from its filesystem mirror. When an offline mirror is given, every other provider is installed from it;
+// otherwise every other provider is installed from its registry.
+func WriteCLIConfig(file string, installations []Installation, offlineMirror string) error {
+	newFile := hclwrite.NewEmptyFile()
+	rootBody := newFile.Body()
+
+	installationBody := rootBody.AppendNewBlock(providerInstallation, nil).Body()
+
+	mirrors := []string{}
+	addresses := map[string][]string{}
+	excluded := []string{}
+
+	for _, installation := range installations {
+		if installation.Mirror == offlineMirror {
+			continue
+		}
+
+		if _, ok := addresses[installation.Mirror]; !ok {
+			mirrors = append(mirrors, installation.Mirror)
+		}
+
+		if !slices.Contains(addresses[installation.Mirror], installation.Address) {
+			addresses[installation.Mirror] = append(addresses[installation.Mirror], installation.Address)
+			excluded = append(excluded, installation.Address)
+		}
+	}
+
+	for i, mirror := range mirrors {
+		if i > 0 {
+			installationBody.AppendNewline()
+		}
+
+		mirrorBody := installationBody.AppendNewBlock(filesystemMirror, nil).Body()
+		mirrorBody.SetAttributeValue(path, cty.StringVal(mirror))
+		mirrorBody.SetAttributeRaw(include, format.ListOfStrings(addresses[mirror]))
+	}
+
+	if len(mirrors) > 0 {
+		installationBody.AppendNewline()
+	}
+
+	if offlineMirror != "" {
+		mirrorBody := installationBody.AppendNewBlock(filesystemMirror, nil).Body()
+		mirrorBody.SetAttributeValue(path, cty.StringVal(offlineMirror))
+	} else {
+		directBody := installationBody.AppendNewBlock(direct, nil).Body()
+		directBody.SetAttributeRaw(exclude, format.ListOfStrings(excluded))
+	}
+
+	err := os.WriteFile(file, newFile.Bytes(), 0644)
+	if err != nil {
+		logrus.Errorf("Failed to write CLI config %s. Error: %v", file, err)
+		return err
+	}
+
+	return nil
+}
+
+// mirrorPath returns the path of the filesystem mirror set by the given config, or the plugin directory
+// scripts/setup-provider.sh installs providers to.
+func mirrorPath(terratestConfig *config.TerratestConfig) (string, error) {
+	if terratestConfig.ProviderMirror != nil && terratestConfig.ProviderMirror.Path != "" {
+		return filepath.Abs(terratestConfig.ProviderMirror.Path)
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, defaultMirrorPath), nil
+}
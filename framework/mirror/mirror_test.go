@@ -0,0 +1,162 @@
+package mirror
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/providers"
+	"github.com/stretchr/testify/require"
+)
+
+const platform = "linux_amd64"
+
+// setProviderVersions sets the provider versions the generators read from the environment.
+func setProviderVersions(t *testing.T, rancher2Version string) {
+	t.Setenv("TERRAFORM_BINARY", "")
+	t.Setenv("RANCHER2_PROVIDER_VERSION", rancher2Version)
+	t.Setenv("RKE_PROVIDER_VERSION", "")
+	t.Setenv("AWS_PROVIDER_VERSION", "5.53.0")
+	t.Setenv("LOCALS_PROVIDER_VERSION", "")
+}
+
+// installProvider installs an empty provider binary to the given mirror, in the layout scripts/setup-provider.sh uses.
+func installProvider(t *testing.T, mirrorPath, source, version, platform string) {
+	name := filepath.Base(source)
+	dir := filepath.Join(mirrorPath, source, version, platform)
+
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, providerPrefix+name), nil, 0755))
+}
+
+func TestInstallations(t *testing.T) {
+	configured := []providers.Provider{
+		{Name: "rancher2", Source: "terraform.local/local/rancher2", Version: "5.1.0-rc1"},
+		{Name: "aws", Source: "hashicorp/aws", Version: "5.53.0"},
+		{Name: "local", Source: "hashicorp/local", Version: "2.5.1", Mirror: "/local-mirror"},
+	}
+
+	installations, err := Installations("terraform", "/mirror", false, configured)
+	require.NoError(t, err)
+	require.Equal(t, []Installation{
+		{Address: "terraform.local/local/rancher2", Version: "5.1.0-rc1", Mirror: "/mirror"},
+		{Address: "registry.terraform.io/hashicorp/local", Version: "2.5.1", Mirror: "/local-mirror"},
+	}, installations)
+
+	installations, err = Installations("tofu", "/mirror", true, configured)
+	require.NoError(t, err)
+	require.Equal(t, []Installation{
+		{Address: "terraform.local/local/rancher2", Version: "5.1.0-rc1", Mirror: "/mirror"},
+		{Address: "registry.opentofu.org/hashicorp/aws", Version: "5.53.0", Mirror: "/mirror"},
+		{Address: "registry.opentofu.org/hashicorp/local", Version: "2.5.1", Mirror: "/local-mirror"},
+	}, installations)
+
+	installations, err = Installations("terraform", "/mirror", false, configured[1:2])
+	require.NoError(t, err)
+	require.Empty(t, installations)
+}
+
+func TestValidate(t *testing.T) {
+	mirrorPath := t.TempDir()
+	installations := []Installation{
+		{Address: "terraform.local/local/rancher2", Version: "5.1.0-rc1", Mirror: mirrorPath},
+		{Address: "registry.terraform.io/hashicorp/aws", Version: "5.53.0", Mirror: mirrorPath},
+	}
+
+	installProvider(t, mirrorPath, "terraform.local/local/rancher2", "5.1.0-rc1", platform)
+
+	err := Validate(platform, installations)
+	require.ErrorContains(t, err, "has no registry.terraform.io/hashicorp/aws 5.53.0 provider for linux_amd64")
+	require.NotContains(t, err.Error(), "rancher2")
+
+	packedDir := filepath.Join(mirrorPath, "registry.terraform.io", "hashicorp", "aws")
+	require.NoError(t, os.MkdirAll(packedDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(packedDir, "terraform-provider-aws_5.53.0_linux_amd64.zip"), nil, 0644))
+
+	require.NoError(t, Validate(platform, installations))
+	require.Error(t, Validate("darwin_arm64", installations))
+
+	constraint := []Installation{{Address: "registry.terraform.io/hashicorp/aws", Version: "~> 5.0", Mirror: mirrorPath}}
+	require.NoError(t, Validate(platform, constraint))
+}
+
+func TestWriteCLIConfig(t *testing.T) {
+	cliConfig := filepath.Join(t.TempDir(), cliConfigFile)
+	installations := []Installation{
+		{Address: "terraform.local/local/rancher2", Version: "5.1.0-rc1", Mirror: "/mirror"},
+		{Address: "registry.terraform.io/hashicorp/aws", Version: "5.53.0", Mirror: "/mirror"},
+		{Address: "registry.terraform.io/hashicorp/local", Version: "2.5.1", Mirror: "/local-mirror"},
+	}
+
+	require.NoError(t, WriteCLIConfig(cliConfig, installations[:1], ""))
+
+	content, err := os.ReadFile(cliConfig)
+	require.NoError(t, err)
+	require.Equal(t, `provider_installation {
+  filesystem_mirror {
+    path    = "/mirror"
+    include = ["terraform.local/local/rancher2"]
+  }
+
+  direct {
+    exclude = ["terraform.local/local/rancher2"]
+  }
+}
+`, string(content))
+
+	require.NoError(t, WriteCLIConfig(cliConfig, installations, "/mirror"))
+
+	content, err = os.ReadFile(cliConfig)
+	require.NoError(t, err)
+	require.Equal(t, `provider_installation {
+  filesystem_mirror {
+    path    = "/local-mirror"
+    include = ["registry.terraform.io/hashicorp/local"]
+  }
+
+  filesystem_mirror {
+    path = "/mirror"
+  }
+}
+`, string(content))
+}
+
+func TestCLIConfig(t *testing.T) {
+	setProviderVersions(t, "5.1.0-rc1")
+	mirrorPath := t.TempDir()
+	terratestConfig := &config.TerratestConfig{ProviderMirror: &config.ProviderMirror{Path: mirrorPath}}
+
+	_, err := CLIConfig(t.TempDir(), nil, terratestConfig)
+	require.ErrorContains(t, err, "has no terraform.local/local/rancher2 5.1.0-rc1 provider")
+
+	installProvider(t, mirrorPath, "terraform.local/local/rancher2", "5.1.0-rc1", Platform())
+
+	cliConfig, err := CLIConfig(t.TempDir(), nil, terratestConfig)
+	require.NoError(t, err)
+	require.FileExists(t, cliConfig)
+
+	setProviderVersions(t, "5.1.0")
+
+	cliConfig, err = CLIConfig(t.TempDir(), nil, terratestConfig)
+	require.NoError(t, err)
+	require.Empty(t, cliConfig)
+
+	setProviderVersions(t, "")
+	awsMirror := t.TempDir()
+	terraformConfig := &config.TerraformConfig{
+		Providers: &config.Providers{
+			Rancher2: &config.Provider{Version: "5.1.0-rc1"},
+			AWS:      &config.Provider{Version: "5.53.0", Mirror: awsMirror},
+		},
+	}
+
+	_, err = CLIConfig(t.TempDir(), terraformConfig, terratestConfig)
+	require.ErrorContains(t, err, "has no registry.terraform.io/hashicorp/aws 5.53.0 provider")
+
+	installProvider(t, awsMirror, "registry.terraform.io/hashicorp/aws", "5.53.0", Platform())
+
+	cliConfig, err = CLIConfig(t.TempDir(), terraformConfig, terratestConfig)
+	require.NoError(t, err)
+	require.FileExists(t, cliConfig)
+}
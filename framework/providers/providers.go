@@ -0,0 +1,245 @@
+package providers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/binaries"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+)
+
+const (
+	localNamespace = "local"
+	versionPrefix  = "="
+)
+
+// Provider is a provider the generated modules require, with the source and version constraint set in its
+// required_providers entry, and the filesystem mirror it is installed from, if any.
+type Provider struct {
+	Name    string
+	Source  string
+	Version string
+	Mirror  string
+}
+
+// provider is a provider the generators know, along with its default source and the environment variable that
+// overrides its version.
+type provider struct {
+	name          string
+	source        string
+	versionEnvVar string
+}
+
+var providers = []provider{
+	{name: defaults.Rancher2, source: defaults.Rancher2Source, versionEnvVar: "RANCHER2_PROVIDER_VERSION"},
+	{name: defaults.Aws, source: defaults.AwsSource, versionEnvVar: "AWS_PROVIDER_VERSION"},
+	{name: defaults.Local, source: defaults.LocalSource, versionEnvVar: "LOCALS_PROVIDER_VERSION"},
+	{name: defaults.RKE, source: defaults.RKESource, versionEnvVar: "RKE_PROVIDER_VERSION"},
+}
+
+// Get is a function that will return the provider with the given name, as set by the providers section of the given
+// config. The version environment variable of the provider, such as RANCHER2_PROVIDER_VERSION, overrides the version
+// set in the config, unless the config sets ignoreVersionEnvVar for the provider. Release candidates without a source set are sourced from terraform.local/local. An error is
+// returned if the provider is unknown or no version is set.
+func Get(name string, terraformConfig *config.TerraformConfig) (Provider, error) {
+	for _, known := range providers {
+		if known.name != name {
+			continue
+		}
+
+		resolved := Provider{Name: name}
+		ignoreVersionEnvVar := false
+
+		if configured := configuredProvider(name, terraformConfig); configured != nil {
+			resolved.Source = configured.Source
+			resolved.Version = configured.Version
+			resolved.Mirror = configured.Mirror
+			ignoreVersionEnvVar = configured.IgnoreVersionEnvVar
+		}
+
+		if version := os.Getenv(known.versionEnvVar); version != "" && !ignoreVersionEnvVar {
+			resolved.Version = version
+		}
+
+		if resolved.Version == "" {
+			return Provider{}, fmt.Errorf("no version set for the %s provider, set %s.providers.%s.version or %s", name,
+				config.TerraformConfigurationFileKey, name, known.versionEnvVar)
+		}
+
+		if resolved.Source == "" {
+			resolved.Source = known.source
+			if resolved.IsRC() {
+				resolved.Source = strings.Join([]string{binaries.LocalRegistry, localNamespace, name}, "/")
+			}
+		}
+
+		return resolved, nil
+	}
+
+	return Provider{}, fmt.Errorf("unknown provider %s", name)
+}
+
+// Configured is a function that will return every provider that has a version set, either in the providers section
+// of the given config or by its version environment variable.
+func Configured(terraformConfig *config.TerraformConfig) []Provider {
+	configured := []Provider{}
+
+	for _, known := range providers {
+		resolved, err := Get(known.name, terraformConfig)
+		if err != nil {
+			continue
+		}
+
+		configured = append(configured, resolved)
+	}
+
+	return configured
+}
+
+// VersionEnvVar is a function that will return the environment variable that overrides the version of the provider
+// with the given name, or an empty string if the provider is unknown.
+func VersionEnvVar(name string) string {
+	for _, known := range providers {
+		if known.name == name {
+			return known.versionEnvVar
+		}
+	}
+
+	return ""
+}
+
+// Merge is a function that will return the given providers section with each provider set in the given overrides
+// replaced as a whole, so that a source set for one version is not carried over to another.
+func Merge(current, overrides *config.Providers) *config.Providers {
+	merged := &config.Providers{}
+	if current != nil {
+		*merged = *current
+	}
+
+	if overrides == nil {
+		return merged
+	}
+
+	if overrides.Rancher2 != nil {
+		merged.Rancher2 = overrides.Rancher2
+	}
+
+	if overrides.AWS != nil {
+		merged.AWS = overrides.AWS
+	}
+
+	if overrides.Local != nil {
+		merged.Local = overrides.Local
+	}
+
+	if overrides.RKE != nil {
+		merged.RKE = overrides.RKE
+	}
+
+	return merged
+}
+
+// IgnoreVersionEnvVars is a function that will return the given providers section with ignoreVersionEnvVar set for
+// each of the providers with the given names, so that Get resolves their versions from the config alone. A provider
+// that is not set gets an entry holding only ignoreVersionEnvVar.
+func IgnoreVersionEnvVars(configured *config.Providers, names []string) *config.Providers {
+	ignored := Merge(configured, nil)
+
+	for _, name := range names {
+		entry := config.Provider{}
+		if current := configuredProvider(name, &config.TerraformConfig{Providers: ignored}); current != nil {
+			entry = *current
+		}
+
+		entry.IgnoreVersionEnvVar = true
+
+		switch name {
+		case defaults.Rancher2:
+			ignored.Rancher2 = &entry
+		case defaults.Aws:
+			ignored.AWS = &entry
+		case defaults.Local:
+			ignored.Local = &entry
+		case defaults.RKE:
+			ignored.RKE = &entry
+		}
+	}
+
+	return ignored
+}
+
+// Names is a function that will return the names of the providers set in the given providers section.
+func Names(configured *config.Providers) []string {
+	names := []string{}
+	if configured == nil {
+		return names
+	}
+
+	for _, known := range providers {
+		if configuredProvider(known.name, &config.TerraformConfig{Providers: configured}) != nil {
+			names = append(names, known.name)
+		}
+	}
+
+	return names
+}
+
+// IsRC is a function that will check if the version of the provider is a release candidate.
+func (p Provider) IsRC() bool {
+	return strings.Contains(p.Version, defaults.Rc)
+}
+
+// IsLocal is a function that will check if the provider is sourced from terraform.local, where release candidates and
+// locally built providers are installed.
+func (p Provider) IsLocal() bool {
+	return strings.HasPrefix(p.Source, binaries.LocalRegistry+"/")
+}
+
+// RequiredSource is a function that will return the source the given binary is set to install the provider from in
+// the required_providers block.
+func (p Provider) RequiredSource(binary string) string {
+	return binaries.ProviderSource(binary, p.Source)
+}
+
+// Address is a function that will return the fully qualified hostname/namespace/type address the given binary
+// installs the provider from, which is also its path in a filesystem mirror.
+func (p Provider) Address(binary string) string {
+	if strings.Count(p.Source, "/") > 1 {
+		return p.Source
+	}
+
+	return binaries.Registry(binary) + "/" + p.Source
+}
+
+// ExactVersion is a function that will return the single version the version constraint of the provider allows, and
+// false if it allows a range of versions.
+func (p Provider) ExactVersion() (string, bool) {
+	version := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(p.Version), versionPrefix))
+	if strings.ContainsAny(version, "<>~!=, ") {
+		return "", false
+	}
+
+	return version, true
+}
+
+// configuredProvider returns the entry of the providers section of the given config for the given provider, if any.
+func configuredProvider(name string, terraformConfig *config.TerraformConfig) *config.Provider {
+	if terraformConfig == nil || terraformConfig.Providers == nil {
+		return nil
+	}
+
+	switch name {
+	case defaults.Rancher2:
+		return terraformConfig.Providers.Rancher2
+	case defaults.Aws:
+		return terraformConfig.Providers.AWS
+	case defaults.Local:
+		return terraformConfig.Providers.Local
+	case defaults.RKE:
+		return terraformConfig.Providers.RKE
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,137 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/stretchr/testify/require"
+)
+
+// unsetProviderVersions clears the provider version environment variables, so that only the config sets versions.
+func unsetProviderVersions(t *testing.T) {
+	for _, known := range providers {
+		t.Setenv(known.versionEnvVar, "")
+	}
+}
+
+func TestGet(t *testing.T) {
+	unsetProviderVersions(t)
+
+	terraformConfig := &config.TerraformConfig{
+		Providers: &config.Providers{
+			Rancher2: &config.Provider{Version: "5.1.0"},
+			AWS:      &config.Provider{Source: "example/aws", Version: "~> 5.0", Mirror: "/mirror"},
+		},
+	}
+
+	rancher2, err := Get("rancher2", terraformConfig)
+	require.NoError(t, err)
+	require.Equal(t, Provider{Name: "rancher2", Source: "rancher/rancher2", Version: "5.1.0"}, rancher2)
+
+	aws, err := Get("aws", terraformConfig)
+	require.NoError(t, err)
+	require.Equal(t, Provider{Name: "aws", Source: "example/aws", Version: "~> 5.0", Mirror: "/mirror"}, aws)
+
+	_, err = Get("rke", terraformConfig)
+	require.EqualError(t, err, "no version set for the rke provider, set terraform.providers.rke.version or RKE_PROVIDER_VERSION")
+
+	_, err = Get("google", terraformConfig)
+	require.Error(t, err)
+
+	t.Setenv("RANCHER2_PROVIDER_VERSION", "5.2.0-rc1")
+
+	rancher2, err = Get("rancher2", terraformConfig)
+	require.NoError(t, err)
+	require.Equal(t, Provider{Name: "rancher2", Source: "terraform.local/local/rancher2", Version: "5.2.0-rc1"}, rancher2)
+	require.True(t, rancher2.IsLocal())
+
+	rke, err := Get("rke", nil)
+	require.Error(t, err)
+	require.Empty(t, rke)
+}
+
+func TestConfigured(t *testing.T) {
+	unsetProviderVersions(t)
+	t.Setenv("LOCALS_PROVIDER_VERSION", "2.5.1")
+
+	terraformConfig := &config.TerraformConfig{
+		Providers: &config.Providers{Rancher2: &config.Provider{Version: "5.1.0"}},
+	}
+
+	require.Equal(t, []Provider{
+		{Name: "rancher2", Source: "rancher/rancher2", Version: "5.1.0"},
+		{Name: "local", Source: "hashicorp/local", Version: "2.5.1"},
+	}, Configured(terraformConfig))
+}
+
+func TestAddress(t *testing.T) {
+	aws := Provider{Name: "aws", Source: "hashicorp/aws"}
+	require.Equal(t, "registry.terraform.io/hashicorp/aws", aws.Address("terraform"))
+	require.Equal(t, "registry.opentofu.org/hashicorp/aws", aws.Address("tofu"))
+	require.Equal(t, "hashicorp/aws", aws.RequiredSource("terraform"))
+	require.Equal(t, "registry.opentofu.org/hashicorp/aws", aws.RequiredSource("tofu"))
+
+	local := Provider{Name: "rancher2", Source: "terraform.local/local/rancher2"}
+	require.Equal(t, "terraform.local/local/rancher2", local.Address("tofu"))
+}
+
+func TestExactVersion(t *testing.T) {
+	for constraint, expected := range map[string]string{"5.1.0": "5.1.0", "= 5.1.0": "5.1.0", " =5.1.0-rc1 ": "5.1.0-rc1"} {
+		version, exact := Provider{Version: constraint}.ExactVersion()
+		require.True(t, exact, constraint)
+		require.Equal(t, expected, version)
+	}
+
+	for _, constraint := range []string{"~> 5.0", ">= 5.0, < 6.0", "!= 5.1.0"} {
+		_, exact := Provider{Version: constraint}.ExactVersion()
+		require.False(t, exact, constraint)
+	}
+}
+
+func TestIgnoreVersionEnvVars(t *testing.T) {
+	unsetProviderVersions(t)
+	t.Setenv("RANCHER2_PROVIDER_VERSION", "5.2.0-rc1")
+	t.Setenv("AWS_PROVIDER_VERSION", "5.60.0")
+
+	configured := &config.Providers{
+		Rancher2: &config.Provider{Version: "5.1.0"},
+		AWS:      &config.Provider{Version: "5.53.0"},
+	}
+
+	ignored := IgnoreVersionEnvVars(configured, []string{"rancher2", "rke"})
+	require.Equal(t, &config.Providers{
+		Rancher2: &config.Provider{Version: "5.1.0", IgnoreVersionEnvVar: true},
+		AWS:      &config.Provider{Version: "5.53.0"},
+		RKE:      &config.Provider{IgnoreVersionEnvVar: true},
+	}, ignored)
+	require.False(t, configured.Rancher2.IgnoreVersionEnvVar)
+
+	terraformConfig := &config.TerraformConfig{Providers: ignored}
+
+	rancher2, err := Get("rancher2", terraformConfig)
+	require.NoError(t, err)
+	require.Equal(t, "5.1.0", rancher2.Version)
+
+	aws, err := Get("aws", terraformConfig)
+	require.NoError(t, err)
+	require.Equal(t, "5.60.0", aws.Version)
+}
+
+func TestMerge(t *testing.T) {
+	current := &config.Providers{
+		Rancher2: &config.Provider{Source: "rancher/rancher2", Version: "5.0.0"},
+		AWS:      &config.Provider{Version: "5.53.0"},
+	}
+	overrides := &config.Providers{Rancher2: &config.Provider{Version: "5.1.0-rc1"}}
+
+	merged := Merge(current, overrides)
+	require.Equal(t, &config.Providers{
+		Rancher2: &config.Provider{Version: "5.1.0-rc1"},
+		AWS:      &config.Provider{Version: "5.53.0"},
+	}, merged)
+	require.Equal(t, "5.0.0", current.Rancher2.Version)
+
+	require.Equal(t, overrides, Merge(nil, overrides))
+	require.Equal(t, []string{"rancher2", "aws"}, Names(merged))
+	require.Empty(t, Names(nil))
+}
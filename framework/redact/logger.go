@@ -0,0 +1,61 @@
+package redact
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/sirupsen/logrus"
+)
+
+var hookOnce sync.Once
+
+// hook is a logrus hook that masks the message and the fields of every log entry.
+type hook struct{}
+
+// Levels returns every logrus level, as any of them can hold a sensitive value.
+func (hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire masks the message and the string and error fields of the given entry, before it is formatted.
+func (hook) Fire(entry *logrus.Entry) error {
+	entry.Message = String(entry.Message)
+
+	for key, value := range entry.Data {
+		switch value := value.(type) {
+		case string:
+			entry.Data[key] = String(value)
+		case error:
+			if masked := String(value.Error()); masked != value.Error() {
+				entry.Data[key] = masked
+			}
+		}
+	}
+
+	return nil
+}
+
+// installHook adds the hook to the standard logrus logger, once.
+func installHook() {
+	hookOnce.Do(func() {
+		logrus.AddHook(hook{})
+	})
+}
+
+// testLogger is a terratest logger that masks every message before passing it to the wrapped logger.
+type testLogger struct {
+	logger *logger.Logger
+}
+
+// Logf masks the given message and logs it with the wrapped logger.
+func (l testLogger) Logf(t testing.TestingT, format string, args ...any) {
+	l.logger.Logf(t, "%s", String(fmt.Sprintf(format, args...)))
+}
+
+// Logger is a function that will return a terratest logger that masks every message, such as the Terraform output
+// logged during apply, before passing it to the given logger.
+func Logger(wrapped *logger.Logger) *logger.Logger {
+	return logger.New(testLogger{logger: wrapped})
+}
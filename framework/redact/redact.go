@@ -0,0 +1,168 @@
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Mask is the text that sensitive values are replaced with.
+const Mask = "REDACTED"
+
+// minLength is the length under which a value is not registered, as masking it would mangle unrelated output.
+const minLength = 4
+
+var (
+	// SensitiveName matches the names of the attributes and fields holding credentials.
+	SensitiveName = regexp.MustCompile(`(?i)(password|passwd|rootpass|token|secret|private_key|privatekey|access_key|` +
+		`accesskey|conn_str|connstr|kubeconfig|kube_config|client_key|ssh_key|sp_key|spkey|auth_encoded_json|authencodedjson)`)
+
+	// notSensitiveName matches the names of the fields that match SensitiveName but only hold a reference to a
+	// credential, such as the name of a secret or the path of a private key.
+	notSensitiveName = regexp.MustCompile(`(?i)(name|endpoint|path|prefix|url)$`)
+
+	// sensitiveAttribute matches a quoted value assigned to a sensitive attribute, in HCL or JSON.
+	sensitiveAttribute = regexp.MustCompile(`(?i)("?[\w-]*(?:password|passwd|rootpass|token|secret|private_key|` +
+		`privatekey|access_key|accesskey|conn_str|connstr|kubeconfig|kube_config|client_key|ssh_key|sp_key|spkey|` +
+		`auth_encoded_json|authencodedjson)[\w-]*"?\s*[=:]\s*)"(?:[^"\\]|\\.)*"`)
+
+	mu       sync.RWMutex
+	secrets  = map[string]bool{}
+	replacer = strings.NewReplacer()
+)
+
+// Register is a function that will collect the sensitive values held by the given configs, such as the loaded
+// rancher.Config and TerraformConfig or the cattle-config map, so that they are masked by String. The first call also
+// installs the logrus hook that masks every log entry.
+func Register(configs ...any) {
+	installHook()
+	Add(Values(configs...)...)
+}
+
+// Add is a function that will register the given sensitive values, so that they are masked by String. Values shorter
+// than four characters are ignored. Multi-line values are also registered line by line, and escaped, as they are
+// written to the generated configuration.
+func Add(values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	added := false
+	for _, value := range values {
+		candidates := []string{value}
+
+		if escaped := escape(value); escaped != value {
+			candidates = append(candidates, escaped)
+		}
+
+		if strings.Contains(value, "\n") {
+			candidates = append(candidates, strings.Split(value, "\n")...)
+		}
+
+		for _, candidate := range candidates {
+			candidate = strings.TrimSpace(candidate)
+			if len(candidate) < minLength || secrets[candidate] {
+				continue
+			}
+
+			secrets[candidate] = true
+			added = true
+		}
+	}
+
+	if !added {
+		return
+	}
+
+	sorted := make([]string, 0, len(secrets))
+	for secret := range secrets {
+		sorted = append(sorted, secret)
+	}
+
+	// The longest values go first, so that a value holding another one is masked as a whole.
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+
+		return sorted[i] < sorted[j]
+	})
+
+	oldNew := make([]string, 0, 2*len(sorted))
+	for _, secret := range sorted {
+		oldNew = append(oldNew, secret, Mask)
+	}
+
+	replacer = strings.NewReplacer(oldNew...)
+}
+
+// Values is a function that will return the strings held by the sensitive fields of the given configs, at any depth.
+// Fields that only reference a credential, such as privateKeyPath or tlsSecretName, are left out.
+func Values(configs ...any) []string {
+	var values []string
+
+	for _, config := range configs {
+		contents, err := json.Marshal(config)
+		if err != nil {
+			continue
+		}
+
+		var generic any
+
+		err = json.Unmarshal(contents, &generic)
+		if err != nil {
+			continue
+		}
+
+		values = appendValues(values, "", generic)
+	}
+
+	return values
+}
+
+// String is a function that will return the given text with every registered value, and every quoted value assigned
+// to a sensitive attribute, masked.
+func String(text string) string {
+	mu.RLock()
+	masked := replacer.Replace(text)
+	mu.RUnlock()
+
+	return sensitiveAttribute.ReplaceAllString(masked, `${1}"`+Mask+`"`)
+}
+
+// Logf is a function that will log the given message with t.Log, masked.
+func Logf(t testing.TB, format string, args ...any) {
+	t.Helper()
+	t.Log(String(fmt.Sprintf(format, args...)))
+}
+
+// appendValues appends the strings held by the sensitive fields of the given value to the given values. The items of
+// a list are checked against the name of the list.
+func appendValues(values []string, name string, value any) []string {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, nested := range value {
+			values = appendValues(values, key, nested)
+		}
+	case []any:
+		for _, nested := range value {
+			values = appendValues(values, name, nested)
+		}
+	case string:
+		if value != "" && SensitiveName.MatchString(name) && !notSensitiveName.MatchString(name) {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// escape returns the given value escaped as it is inside a quoted HCL or JSON string.
+func escape(value string) string {
+	quoted := strconv.Quote(value)
+	return quoted[1 : len(quoted)-1]
+}
@@ -0,0 +1,86 @@
+package redact
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	terratest "github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/config/nodeproviders/aws"
+	"github.com/rancher/tfp-automation/config/nodeproviders/linode"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValues(t *testing.T) {
+	rancherConfig := &rancher.Config{Host: "rancher.example.com", AdminToken: "token-abcde:secret", AdminPassword: "admin-password"}
+	terraformConfig := &config.TerraformConfig{
+		AWSCredentials:    aws.Credentials{AWSAccessKey: "AKIAEXAMPLE", AWSSecretKey: "aws-secret-key"},
+		LinodeCredentials: linode.Credentials{LinodeToken: "linode-token"},
+		PrivateKeyPath:    "/home/jenkins/.ssh/id_rsa",
+		PrivateRegistries: &config.PrivateRegistries{
+			AuthConfigSecretName: "registry-auth",
+			Password:             "registry-password",
+			TLSSecretName:        "registry-tls",
+			URL:                  "registry.example.com",
+		},
+	}
+
+	require.ElementsMatch(t, []string{
+		"token-abcde:secret",
+		"admin-password",
+		"AKIAEXAMPLE",
+		"aws-secret-key",
+		"linode-token",
+		"registry-password",
+	}, Values(rancherConfig, terraformConfig))
+
+	cattleConfig := map[string]any{"rancher": map[string]any{"adminToken": "token-fghij:secret", "host": "rancher.example.com"}}
+	require.Equal(t, []string{"token-fghij:secret"}, Values(cattleConfig))
+}
+
+func TestString(t *testing.T) {
+	Add("s3cr3t-value", "abc", "-----BEGIN KEY-----\nbase64-key-material\n-----END KEY-----")
+
+	require.Equal(t, "key is REDACTED, abc stays", String("key is s3cr3t-value, abc stays"))
+	require.Equal(t, `value = "REDACTED"`, String(`value = "-----BEGIN KEY-----\nbase64-key-material\n-----END KEY-----"`))
+	require.Equal(t, "REDACTED", String("base64-key-material"))
+	require.Equal(t, `token_key = "REDACTED"`+"\n"+`name      = "tfp"`, String(`token_key = "inlined"`+"\n"+`name      = "tfp"`))
+	require.Equal(t, `{"aws_secret_key": "REDACTED"}`, String(`{"aws_secret_key": "inlined"}`))
+}
+
+func TestHook(t *testing.T) {
+	Register(map[string]any{"linodeCredentials": map[string]any{"linodeToken": "hooked-linode-token"}})
+
+	var output bytes.Buffer
+
+	out := logrus.StandardLogger().Out
+	logrus.SetOutput(&output)
+	t.Cleanup(func() { logrus.SetOutput(out) })
+
+	logrus.WithField("token", "hooked-linode-token").Infof("Using hooked-linode-token")
+
+	require.NotContains(t, output.String(), "hooked-linode-token")
+	require.Contains(t, output.String(), "Using REDACTED")
+}
+
+func TestLogger(t *testing.T) {
+	Add("terraform-output-secret")
+
+	var logged []string
+
+	wrapped := logger.New(recorder(func(message string) { logged = append(logged, message) }))
+	Logger(wrapped).Logf(t, "aws_secret_key: %s", "terraform-output-secret")
+
+	require.Equal(t, []string{"aws_secret_key: REDACTED"}, logged)
+}
+
+// recorder is a terratest logger that passes every message to a function.
+type recorder func(message string)
+
+func (r recorder) Logf(_ terratest.TestingT, format string, args ...any) {
+	r(fmt.Sprintf(format, args...))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -29,11 +30,11 @@ func SetAD(terraformConfig *config.TerraformConfig, newFile *hclwrite.File, root
 
 	adBlockBody.SetAttributeValue(port, cty.NumberIntVal(int64(terraformConfig.ADConfig.Port)))
 	adBlockBody.SetAttributeValue(servers, cty.ListVal([]cty.Value{cty.StringVal(terraformConfig.ADConfig.Servers[0])}))
-	adBlockBody.SetAttributeValue(serviceAccountPassword, cty.StringVal(terraformConfig.ADConfig.ServiceAccountPassword))
+	adBlockBody.SetAttributeRaw(serviceAccountPassword, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.ADServiceAccountPassword)))
 	adBlockBody.SetAttributeValue(serviceAccountUsername, cty.StringVal(terraformConfig.ADConfig.ServiceAccountUsername))
 	adBlockBody.SetAttributeValue(userSearchBase, cty.StringVal(terraformConfig.ADConfig.UserSearchBase))
 	adBlockBody.SetAttributeValue(testUsername, cty.StringVal(terraformConfig.ADConfig.TestUsername))
-	adBlockBody.SetAttributeValue(testPassword, cty.StringVal(terraformConfig.ADConfig.TestPassword))
+	adBlockBody.SetAttributeRaw(testPassword, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.ADTestPassword)))
 
 	_, err := file.Write(newFile.Bytes())
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -30,7 +31,7 @@ func SetAzureAD(rancherConfig *rancher.Config, terraformConfig *config.Terraform
 	azureADBlockBody := azureADBlock.Body()
 
 	azureADBlockBody.SetAttributeValue(applicationID, cty.StringVal(terraformConfig.AzureADConfig.ApplicationID))
-	azureADBlockBody.SetAttributeValue(applicationSecret, cty.StringVal(terraformConfig.AzureADConfig.ApplicationSecret))
+	azureADBlockBody.SetAttributeRaw(applicationSecret, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.AzureADApplicationSecret)))
 	azureADBlockBody.SetAttributeValue(authEndpoint, cty.StringVal(terraformConfig.AzureADConfig.AuthEndpoint))
 	azureADBlockBody.SetAttributeValue(graphEndpoint, cty.StringVal(terraformConfig.AzureADConfig.GraphEndpoint))
 	azureADBlockBody.SetAttributeValue(rancherURL, cty.StringVal("https://"+rancherConfig.Host))
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -23,7 +24,7 @@ func SetGithub(terraformConfig *config.TerraformConfig, newFile *hclwrite.File,
 	githubBlockBody := githubBlock.Body()
 
 	githubBlockBody.SetAttributeValue(clientID, cty.StringVal(terraformConfig.GithubConfig.ClientID))
-	githubBlockBody.SetAttributeValue(clientSecret, cty.StringVal(terraformConfig.GithubConfig.ClientSecret))
+	githubBlockBody.SetAttributeRaw(clientSecret, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.GithubClientSecret)))
 
 	_, err := file.Write(newFile.Bytes())
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -30,10 +31,10 @@ func SetOpenLDAP(terraformConfig *config.TerraformConfig, newFile *hclwrite.File
 	openLDAPBlockBody.SetAttributeValue(port, cty.NumberIntVal(int64(terraformConfig.OpenLDAPConfig.Port)))
 	openLDAPBlockBody.SetAttributeValue(servers, cty.ListVal([]cty.Value{cty.StringVal(terraformConfig.OpenLDAPConfig.Servers[0])}))
 	openLDAPBlockBody.SetAttributeValue(serviceAccountDistinguisedName, cty.StringVal(terraformConfig.OpenLDAPConfig.ServiceAccountDistinguisedName))
-	openLDAPBlockBody.SetAttributeValue(serviceAccountPassword, cty.StringVal(terraformConfig.OpenLDAPConfig.ServiceAccountPassword))
+	openLDAPBlockBody.SetAttributeRaw(serviceAccountPassword, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.OpenLDAPServiceAccountPassword)))
 	openLDAPBlockBody.SetAttributeValue(userSearchBase, cty.StringVal(terraformConfig.OpenLDAPConfig.UserSearchBase))
 	openLDAPBlockBody.SetAttributeValue(testUsername, cty.StringVal(terraformConfig.OpenLDAPConfig.TestUsername))
-	openLDAPBlockBody.SetAttributeValue(testPassword, cty.StringVal(terraformConfig.OpenLDAPConfig.TestPassword))
+	openLDAPBlockBody.SetAttributeRaw(testPassword, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.OpenLDAPTestPassword)))
 
 	_, err := file.Write(newFile.Bytes())
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -35,7 +36,7 @@ func SetOkta(rancherConfig *rancher.Config, terraformConfig *config.TerraformCon
 	oktaBlockBody.SetAttributeValue(idpMetadataContent, cty.StringVal(terraformConfig.OktaConfig.IdpMetadataContent))
 	oktaBlockBody.SetAttributeValue(rancherAPIHost, cty.StringVal("https://"+rancherConfig.Host))
 	oktaBlockBody.SetAttributeValue(spCert, cty.StringVal(terraformConfig.OktaConfig.SPCert))
-	oktaBlockBody.SetAttributeValue(spKey, cty.StringVal(terraformConfig.OktaConfig.SPKey))
+	oktaBlockBody.SetAttributeRaw(spKey, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.OktaSPKey)))
 	oktaBlockBody.SetAttributeValue(uidField, cty.StringVal(terraformConfig.OktaConfig.UIDField))
 	oktaBlockBody.SetAttributeValue(userNameField, cty.StringVal(terraformConfig.OktaConfig.UserNameField))
 
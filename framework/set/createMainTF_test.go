@@ -0,0 +1,142 @@
+package set
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/golden"
+	airgapAWS "github.com/rancher/tfp-automation/framework/set/resources/airgap/aws"
+	airgapRancher "github.com/rancher/tfp-automation/framework/set/resources/airgap/rancher"
+	airgapRKE2 "github.com/rancher/tfp-automation/framework/set/resources/airgap/rke2"
+	proxyRancher "github.com/rancher/tfp-automation/framework/set/resources/proxy/rancher"
+	proxyRKE2 "github.com/rancher/tfp-automation/framework/set/resources/proxy/rke2"
+	"github.com/rancher/tfp-automation/framework/set/resources/proxy/squid"
+	registry "github.com/rancher/tfp-automation/framework/set/resources/registries/createRegistry"
+	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
+	"github.com/rancher/tfp-automation/framework/set/resources/sanity/aws"
+	sanityRancher "github.com/rancher/tfp-automation/framework/set/resources/sanity/rancher"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	standaloneFixture = "testdata/standalone/rancher.yaml"
+
+	bastionPublicDNS   = "bastion.example.com"
+	registryPublicDNS  = "registry.example.com"
+	serverOnePublicDNS = "server1.example.com"
+	serverTwoPublicDNS = "server2.example.com"
+	serverThreeDNS     = "server3.example.com"
+	serverOnePrivateIP = "10.0.0.1"
+	serverTwoPrivateIP = "10.0.0.2"
+	serverThreeIP      = "10.0.0.3"
+)
+
+// stage writes one step of a standalone CreateMainTF builder. The builders run terraform between steps, so the tests
+// replay the same steps with fixed outputs instead.
+type stage func(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+	terratestConfig *config.TerratestConfig) (*os.File, error)
+
+func TestCreateMainTFGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		stages []stage
+	}{
+		{
+			name: "sanity",
+			stages: []stage{
+				func(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+					terratestConfig *config.TerratestConfig) (*os.File, error) {
+					return aws.CreateAWSResources(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig,
+						[]string{"rke2_server1", "rke2_server2", "rke2_server3"})
+				},
+				func(file *os.File, newFile *hclwrite.File, _, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+					_ *config.TerratestConfig) (*os.File, error) {
+					return rke2.CreateRKE2Cluster(file, newFile, rootBody, terraformConfig, serverOnePublicDNS, serverOnePrivateIP,
+						serverTwoPublicDNS, serverThreeDNS)
+				},
+				func(file *os.File, newFile *hclwrite.File, _, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+					_ *config.TerratestConfig) (*os.File, error) {
+					return sanityRancher.CreateRancher(file, newFile, rootBody, terraformConfig, serverOnePublicDNS)
+				},
+			},
+		},
+		{
+			name: "proxy",
+			stages: []stage{
+				func(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+					terratestConfig *config.TerratestConfig) (*os.File, error) {
+					return aws.CreateAWSResources(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig,
+						[]string{"rke2_bastion", "rke2_server1", "rke2_server2", "rke2_server3"})
+				},
+				func(file *os.File, newFile *hclwrite.File, _, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+					_ *config.TerratestConfig) (*os.File, error) {
+					return squid.CreateSquidProxy(file, newFile, rootBody, terraformConfig, bastionPublicDNS)
+				},
+				func(file *os.File, newFile *hclwrite.File, _, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+					_ *config.TerratestConfig) (*os.File, error) {
+					return proxyRKE2.CreateRKE2Cluster(file, newFile, rootBody, terraformConfig, bastionPublicDNS, serverOnePublicDNS,
+						serverOnePrivateIP, serverTwoPublicDNS, serverThreeDNS)
+				},
+				func(file *os.File, newFile *hclwrite.File, _, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+					_ *config.TerratestConfig) (*os.File, error) {
+					return proxyRancher.CreateProxiedRancher(file, newFile, rootBody, terraformConfig, serverOnePublicDNS, bastionPublicDNS)
+				},
+			},
+		},
+		{
+			name: "airgap",
+			stages: []stage{
+				func(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+					terratestConfig *config.TerratestConfig) (*os.File, error) {
+					return airgapAWS.CreateAWSResources(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig)
+				},
+				func(file *os.File, newFile *hclwrite.File, _, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+					_ *config.TerratestConfig) (*os.File, error) {
+					return registry.CreateNonAuthenticatedRegistry(file, newFile, rootBody, terraformConfig, registryPublicDNS, "non_auth_registry")
+				},
+				func(file *os.File, newFile *hclwrite.File, _, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+					_ *config.TerratestConfig) (*os.File, error) {
+					return airgapRKE2.CreateAirgapRKE2Cluster(file, newFile, rootBody, terraformConfig, bastionPublicDNS, registryPublicDNS,
+						serverOnePrivateIP, serverTwoPrivateIP, serverThreeIP)
+				},
+				func(file *os.File, newFile *hclwrite.File, _, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
+					_ *config.TerratestConfig) (*os.File, error) {
+					return airgapRancher.CreateAirgapRancher(file, newFile, rootBody, terraformConfig, bastionPublicDNS, registryPublicDNS)
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setProviderVersions(t)
+			golden.SetupHome(t)
+
+			configMap := golden.LoadFixture(t, standaloneFixture)
+			_, terraformConfig, terratestConfig := config.LoadTFPConfigs(configMap[0])
+
+			outputDir := t.TempDir()
+
+			file, err := os.Create(filepath.Join(outputDir, "main.tf"))
+			require.NoError(t, err)
+
+			defer file.Close()
+
+			newFile := hclwrite.NewEmptyFile()
+			rootBody := newFile.Body()
+
+			tfBlockBody := rootBody.AppendNewBlock("terraform", nil).Body()
+
+			for _, stage := range tt.stages {
+				_, err = stage(file, newFile, tfBlockBody, rootBody, terraformConfig, terratestConfig)
+				require.NoError(t, err)
+			}
+
+			requireNoInlinedSecrets(t, outputDir)
+			golden.Assert(t, filepath.Join(goldenDir, "standalone", tt.name+".golden.tf"), newFile.Bytes())
+		})
+	}
+}
@@ -12,6 +12,7 @@ const (
 
 	DependsOn    = "depends_on"
 	GenerateName = "generate_name"
+	ID           = "id"
 	Defaults     = "defaults"
 	Index        = "index"
 	File         = "file"
@@ -119,6 +120,7 @@ const (
 	PublicIp       = "public_ip"
 	PrivateIp      = "private_ip"
 	Length         = "length"
+	Replace        = "replace"
 
 	ApiUrl            = "api_url"
 	Aws               = "aws"
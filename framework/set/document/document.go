@@ -0,0 +1,187 @@
+package document
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Document is a format-agnostic Terraform configuration, holding the blocks of a generated file in the order they
+// were written, so that it can be written as either HCL or Terraform JSON syntax.
+type Document struct {
+	Blocks []*Block
+}
+
+// Block is a block of the configuration, such as a resource, along with its labels and body.
+type Block struct {
+	Type       string
+	Labels     []string
+	Attributes []*Attribute
+	Blocks     []*Block
+}
+
+// Attribute is an argument of a block body.
+type Attribute struct {
+	Name       string
+	Expression Expression
+}
+
+// Expression is the value of an attribute, one of Literal, Template, Raw, Tuple or Object.
+type Expression interface {
+	expression()
+}
+
+// Literal is a constant value, such as a string, a number or a bool.
+type Literal struct {
+	Value cty.Value
+}
+
+// Template is a string interpolating other expressions. Its parts are string Literals and Raw expressions.
+type Template struct {
+	Parts []Expression
+}
+
+// Raw is any other expression, such as a reference or a function call, kept as its HCL source.
+type Raw struct {
+	Source string
+}
+
+// Tuple is a list of expressions.
+type Tuple struct {
+	Elements []Expression
+}
+
+// Object is a map of expressions, in the order its items were written.
+type Object struct {
+	Items []ObjectItem
+}
+
+// ObjectItem is a single key and value of an Object.
+type ObjectItem struct {
+	Key   Expression
+	Value Expression
+}
+
+func (Literal) expression()  {}
+func (Template) expression() {}
+func (Raw) expression()      {}
+func (Tuple) expression()    {}
+func (Object) expression()   {}
+
+// Parse is a function that will build the Document of the given HCL native syntax, such as the bytes of the
+// hclwrite.File every generator writes to.
+func Parse(filename string, src []byte) (*Document, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	p := parser{src: src}
+	_, blocks := p.body(file.Body.(*hclsyntax.Body))
+
+	return &Document{Blocks: blocks}, nil
+}
+
+// parser builds Documents from the syntax tree of the given source.
+type parser struct {
+	src []byte
+}
+
+// body returns the attributes of the given body, in the order they were written, and its nested blocks.
+func (p parser) body(body *hclsyntax.Body) ([]*Attribute, []*Block) {
+	syntaxAttributes := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attribute := range body.Attributes {
+		syntaxAttributes = append(syntaxAttributes, attribute)
+	}
+
+	sort.Slice(syntaxAttributes, func(i, j int) bool {
+		return syntaxAttributes[i].SrcRange.Start.Byte < syntaxAttributes[j].SrcRange.Start.Byte
+	})
+
+	attributes := make([]*Attribute, 0, len(syntaxAttributes))
+	for _, attribute := range syntaxAttributes {
+		attributes = append(attributes, &Attribute{Name: attribute.Name, Expression: p.expression(attribute.Expr)})
+	}
+
+	blocks := make([]*Block, 0, len(body.Blocks))
+	for _, block := range body.Blocks {
+		blockAttributes, nestedBlocks := p.body(block.Body)
+
+		blocks = append(blocks, &Block{
+			Type:       block.Type,
+			Labels:     block.Labels,
+			Attributes: blockAttributes,
+			Blocks:     nestedBlocks,
+		})
+	}
+
+	return attributes, blocks
+}
+
+// expression returns the Expression of the given syntax tree node.
+func (p parser) expression(expr hclsyntax.Expression) Expression {
+	switch e := expr.(type) {
+	case *hclsyntax.LiteralValueExpr:
+		return Literal{Value: e.Val}
+	case *hclsyntax.TemplateExpr:
+		if e.IsStringLiteral() {
+			value, _ := e.Value(nil)
+			return Literal{Value: value}
+		}
+
+		parts := make([]Expression, 0, len(e.Parts))
+		for _, part := range e.Parts {
+			if literal, ok := part.(*hclsyntax.LiteralValueExpr); ok {
+				parts = append(parts, Literal{Value: literal.Val})
+				continue
+			}
+
+			parts = append(parts, p.raw(part))
+		}
+
+		return Template{Parts: parts}
+	case *hclsyntax.TemplateWrapExpr:
+		return Template{Parts: []Expression{p.raw(e.Wrapped)}}
+	case *hclsyntax.TupleConsExpr:
+		elements := make([]Expression, 0, len(e.Exprs))
+		for _, element := range e.Exprs {
+			elements = append(elements, p.expression(element))
+		}
+
+		return Tuple{Elements: elements}
+	case *hclsyntax.ObjectConsExpr:
+		items := make([]ObjectItem, 0, len(e.Items))
+		for _, item := range e.Items {
+			items = append(items, ObjectItem{Key: p.objectKey(item.KeyExpr), Value: p.expression(item.ValueExpr)})
+		}
+
+		return Object{Items: items}
+	default:
+		return p.raw(expr)
+	}
+}
+
+// objectKey returns the Expression of an object key. A bare identifier is a literal key, as in HCL.
+func (p parser) objectKey(expr hclsyntax.Expression) Expression {
+	key, ok := expr.(*hclsyntax.ObjectConsKeyExpr)
+	if !ok {
+		return p.expression(expr)
+	}
+
+	if !key.ForceNonLiteral {
+		if keyword := hcl.ExprAsKeyword(key.Wrapped); keyword != "" {
+			return Literal{Value: cty.StringVal(keyword)}
+		}
+	}
+
+	return p.expression(key.Wrapped)
+}
+
+// raw returns the given expression as its HCL source.
+func (p parser) raw(expr hclsyntax.Expression) Raw {
+	srcRange := expr.Range()
+
+	return Raw{Source: string(srcRange.SliceBytes(p.src))}
+}
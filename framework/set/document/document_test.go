@@ -0,0 +1,107 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const module = `resource "aws_instance" "node" {
+  count      = 2
+  ami        = "ami-aaaaaaaa"
+  depends_on = [aws_instance.bastion]
+  tags = {
+    Name            = "${var.prefix}-node"
+    "rancher.io/id" = var.id
+  }
+
+  provisioner "file" {
+    content     = "echo $${HOME} %%{ok}"
+    destination = "/tmp/script.sh"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["bash /tmp/script.sh ${self.private_ip} && exit 0"]
+  }
+
+  lifecycle {
+    ignore_changes = [ami]
+  }
+}
+
+resource "aws_instance" "bastion" {
+  ami = null
+}
+
+variable "id" {
+  type      = string
+  sensitive = true
+}
+
+locals {
+  first = 1
+}
+
+locals {
+  second = true
+}
+`
+
+func TestJSON(t *testing.T) {
+	doc, err := Parse("module.tf", []byte(module))
+	require.NoError(t, err)
+
+	moduleJSON, err := doc.JSON("generated")
+	require.NoError(t, err)
+
+	require.JSONEq(t, `{
+  "//": "generated",
+  "resource": {
+    "aws_instance": {
+      "node": {
+        "count": 2,
+        "ami": "ami-aaaaaaaa",
+        "depends_on": ["aws_instance.bastion"],
+        "tags": {
+          "Name": "${var.prefix}-node",
+          "rancher.io/id": "${var.id}"
+        },
+        "provisioner": [
+          {"file": {"content": "echo $${HOME} %%{ok}", "destination": "/tmp/script.sh"}},
+          {"remote-exec": {"inline": ["bash /tmp/script.sh ${self.private_ip} && exit 0"]}}
+        ],
+        "lifecycle": [{"ignore_changes": ["ami"]}]
+      },
+      "bastion": {"ami": null}
+    }
+  },
+  "variable": {"id": {"type": "string", "sensitive": true}},
+  "locals": [{"first": 1}, {"second": true}]
+}`, string(moduleJSON))
+
+	require.Contains(t, string(moduleJSON), `"provisioner": [`+"\n", "the JSON is indented")
+	require.Contains(t, string(moduleJSON), `&& exit 0`, "HTML characters are not escaped")
+}
+
+func TestParseKeepsOrder(t *testing.T) {
+	doc, err := Parse("module.tf", []byte(module))
+	require.NoError(t, err)
+
+	node := doc.Blocks[0]
+	require.Equal(t, []string{"aws_instance", "node"}, node.Labels)
+
+	names := []string{}
+	for _, attribute := range node.Attributes {
+		names = append(names, attribute.Name)
+	}
+
+	require.Equal(t, []string{"count", "ami", "depends_on", "tags"}, names)
+
+	name := node.Attributes[3].Expression.(Object).Items[0]
+	require.Equal(t, "Name", name.Key.(Literal).Value.AsString())
+	require.Equal(t, Raw{Source: "var.prefix"}, name.Value.(Template).Parts[0])
+	require.Equal(t, "-node", name.Value.(Template).Parts[1].(Literal).Value.AsString())
+
+	_, err = Parse("invalid.tf", []byte(`resource "aws_instance" {`))
+	require.Error(t, err)
+}
@@ -0,0 +1,276 @@
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const commentKey = "//"
+
+// plainArguments are the arguments, per block type, that Terraform JSON syntax reads as plain strings holding
+// references or types, rather than as string templates.
+var plainArguments = map[string][]string{
+	"data":      {"depends_on", "provider"},
+	"lifecycle": {"ignore_changes", "replace_triggered_by"},
+	"module":    {"depends_on"},
+	"output":    {"depends_on"},
+	"resource":  {"depends_on", "provider"},
+	"variable":  {"type"},
+}
+
+// JSON is a function that will write the document in Terraform JSON syntax, with the given comment set as the "//"
+// property of the root object. Top-level blocks are grouped by type and labels, and nested blocks are written as
+// arrays so that their order, such as the order of provisioners, is kept.
+func (d *Document) JSON(comment string) ([]byte, error) {
+	root := &object{}
+	if comment != "" {
+		root.set(commentKey, comment)
+	}
+
+	for _, block := range d.Blocks {
+		body, err := bodyObject(block)
+		if err != nil {
+			return nil, err
+		}
+
+		path := append([]string{block.Type}, block.Labels...)
+		parent := root
+
+		for _, key := range path[:len(path)-1] {
+			child, ok := parent.get(key)
+			if !ok {
+				child = &object{}
+				parent.set(key, child)
+			}
+
+			parent, ok = child.(*object)
+			if !ok {
+				return nil, fmt.Errorf("cannot write %s %s as JSON, %s is used both as a label and a block", block.Type,
+					strings.Join(block.Labels, "."), key)
+			}
+		}
+
+		leaf := path[len(path)-1]
+
+		// Blocks sharing a type and labels, such as several locals blocks, are written as an array.
+		switch existing, _ := parent.get(leaf); existing := existing.(type) {
+		case nil:
+			parent.set(leaf, body)
+		case []any:
+			parent.set(leaf, append(existing, body))
+		default:
+			parent.set(leaf, []any{existing, body})
+		}
+	}
+
+	return marshal(root, "  ")
+}
+
+// bodyObject returns the JSON object of the body of the given block.
+func bodyObject(block *Block) (*object, error) {
+	body := &object{}
+
+	for _, attribute := range block.Attributes {
+		plain := slices.Contains(plainArguments[block.Type], attribute.Name)
+
+		value, err := jsonValue(attribute.Expression, plain)
+		if err != nil {
+			return nil, err
+		}
+
+		body.set(attribute.Name, value)
+	}
+
+	for _, nested := range block.Blocks {
+		nestedBody, err := bodyObject(nested)
+		if err != nil {
+			return nil, err
+		}
+
+		var value any = nestedBody
+		for i := len(nested.Labels) - 1; i >= 0; i-- {
+			labelled := &object{}
+			labelled.set(nested.Labels[i], value)
+			value = labelled
+		}
+
+		existing, _ := body.get(nested.Type)
+		blocks, _ := existing.([]any)
+		body.set(nested.Type, append(blocks, value))
+	}
+
+	return body, nil
+}
+
+// jsonValue returns the JSON value of the given expression. Expressions are written as string templates, unless plain
+// is set, in which case references are written as they are.
+func jsonValue(expr Expression, plain bool) (any, error) {
+	switch e := expr.(type) {
+	case Literal:
+		if e.Value.IsNull() {
+			return nil, nil
+		}
+
+		if e.Value.Type() == cty.String {
+			if plain {
+				return e.Value.AsString(), nil
+			}
+
+			return escapeTemplate(e.Value.AsString()), nil
+		}
+
+		value, err := ctyjson.Marshal(e.Value, e.Value.Type())
+		if err != nil {
+			return nil, err
+		}
+
+		return json.RawMessage(value), nil
+	case Template:
+		var template strings.Builder
+
+		for _, part := range e.Parts {
+			switch part := part.(type) {
+			case Literal:
+				template.WriteString(escapeTemplate(part.Value.AsString()))
+			case Raw:
+				template.WriteString("${" + part.Source + "}")
+			default:
+				return nil, fmt.Errorf("unexpected template part %T", part)
+			}
+		}
+
+		return template.String(), nil
+	case Raw:
+		if plain {
+			return e.Source, nil
+		}
+
+		return "${" + e.Source + "}", nil
+	case Tuple:
+		elements := make([]any, 0, len(e.Elements))
+
+		for _, element := range e.Elements {
+			value, err := jsonValue(element, plain)
+			if err != nil {
+				return nil, err
+			}
+
+			elements = append(elements, value)
+		}
+
+		return elements, nil
+	case Object:
+		items := &object{}
+
+		for _, item := range e.Items {
+			key, err := jsonValue(item.Key, false)
+			if err != nil {
+				return nil, err
+			}
+
+			keyString, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("object keys must be strings, got %s", key)
+			}
+
+			value, err := jsonValue(item.Value, false)
+			if err != nil {
+				return nil, err
+			}
+
+			items.set(keyString, value)
+		}
+
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unexpected expression %T", expr)
+	}
+}
+
+// escapeTemplate escapes the template sequences of a literal string, as every JSON string is read as a template.
+func escapeTemplate(literal string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(literal)
+}
+
+// object is a JSON object that keeps its keys in the order they were set.
+type object struct {
+	keys   []string
+	values map[string]any
+}
+
+// get returns the value set for the given key.
+func (o *object) get(key string) (any, bool) {
+	value, ok := o.values[key]
+
+	return value, ok
+}
+
+// set sets the value of the given key, keeping the position of a key that is already set.
+func (o *object) set(key string, value any) {
+	if o.values == nil {
+		o.values = map[string]any{}
+	}
+
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+
+	o.values[key] = value
+}
+
+// MarshalJSON writes the object with its keys in order.
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("{")
+
+	for i, key := range o.keys {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+
+		keyJSON, err := marshal(key, "")
+		if err != nil {
+			return nil, err
+		}
+
+		valueJSON, err := marshal(o.values[key], "")
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(keyJSON)
+		buffer.WriteString(":")
+		buffer.Write(valueJSON)
+	}
+
+	buffer.WriteString("}")
+
+	return buffer.Bytes(), nil
+}
+
+// marshal encodes the given value without escaping HTML characters, which are common in expressions such as "&&".
+func marshal(value any, indent string) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+
+	err := encoder.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if indent == "" {
+		return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,98 @@
+package generators
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+)
+
+// Input holds everything a ModuleGenerator needs to write a single cluster into its <resourcePrefix>.tf file.
+type Input struct {
+	RancherConfig   *rancher.Config
+	TerraformConfig *config.TerraformConfig
+	TerratestConfig *config.TerratestConfig
+	ConfigMap       []map[string]any
+	NewFile         *hclwrite.File
+	RootBody        *hclwrite.Body
+	File            *os.File
+	RBACRole        config.Role
+	IsWindows       bool
+}
+
+// ModuleGenerator is the interface every cluster generator implements to be picked up by set.ConfigTF.
+type ModuleGenerator interface {
+	// Modules returns the module names the generator is able to handle.
+	Modules() []string
+	// RequiredProviders returns the Terraform providers, besides rancher2, that the generated HCL needs.
+	RequiredProviders() []string
+	// Generate writes the HCL for the cluster described by the input.
+	Generate(input *Input) error
+}
+
+var (
+	mutex      sync.RWMutex
+	generators = map[string]ModuleGenerator{}
+)
+
+// Register is a function that will register a generator for each of the modules it handles. Registering the same
+// module twice is a programming error and will panic.
+func Register(generator ModuleGenerator) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for _, module := range generator.Modules() {
+		if _, ok := generators[module]; ok {
+			panic(fmt.Sprintf("generator already registered for module %s", module))
+		}
+
+		generators[module] = generator
+	}
+}
+
+// Lookup is a function that will return the generator registered for the given module.
+func Lookup(module string) (ModuleGenerator, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	generator, ok := generators[module]
+
+	return generator, ok
+}
+
+// IsSupported is a function that will check if a generator is registered for the given module.
+func IsSupported(module string) bool {
+	_, ok := Lookup(module)
+
+	return ok
+}
+
+// SupportedModules is a function that will return the sorted list of every registered module.
+func SupportedModules() []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	supportedModules := make([]string, 0, len(generators))
+	for module := range generators {
+		supportedModules = append(supportedModules, module)
+	}
+
+	sort.Strings(supportedModules)
+
+	return supportedModules
+}
+
+// RequiresProvider is a function that will check if the generator registered for the given module needs the provider.
+func RequiresProvider(module, provider string) bool {
+	generator, ok := Lookup(module)
+	if !ok {
+		return false
+	}
+
+	return slices.Contains(generator.RequiredProviders(), provider)
+}
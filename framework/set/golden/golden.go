@@ -0,0 +1,155 @@
+package golden
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/rancher/tfp-automation/framework/set/tags"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	goldenSuffix = ".golden"
+	repoPath     = "go/src/github.com/rancher/tfp-automation"
+)
+
+var (
+	update = flag.Bool("update", false, "rewrite the golden files with the generated output instead of comparing against them")
+
+	randomToken = regexp.MustCompile(`\btoken-[a-z0-9]{5}\b`)
+)
+
+// LoadFixture is a function that will load a fixture file holding a list of cattle configs.
+func LoadFixture(t *testing.T, fixturePath string) []map[string]any {
+	content, err := os.ReadFile(fixturePath)
+	require.NoError(t, err)
+
+	var configMap []map[string]any
+	err = yaml.Unmarshal(content, &configMap)
+	require.NoError(t, err)
+	require.NotEmpty(t, configMap, "fixture %s holds no cattle configs", fixturePath)
+
+	return configMap
+}
+
+// SetupHome is a function that will point HOME to a temporary directory laid out the way the generators expect the
+// tfp-automation repository to be checked out. The environment variables the generated tags are read from are
+// cleared, so that the output does not depend on the CI job running the tests. It returns the temporary home directory.
+func SetupHome(t *testing.T) string {
+	homeDir := t.TempDir()
+	checkoutDir := filepath.Join(homeDir, repoPath)
+
+	require.NoError(t, os.MkdirAll(checkoutDir, 0755))
+	require.NoError(t, os.Symlink(filepath.Join(repoRoot(t), "framework"), filepath.Join(checkoutDir, "framework")))
+
+	t.Setenv("HOME", homeDir)
+
+	for _, envVar := range []string{tags.BuildNumberEnvVar, tags.JobNameEnvVar, tags.TestNameEnvVar} {
+		t.Setenv(envVar, "")
+	}
+
+	return homeDir
+}
+
+// AssertDir is a function that will compare every file in dir against the golden files in goldenDir. A file named
+// providers.tf is compared against providers.golden.tf.
+func AssertDir(t *testing.T, goldenDir, dir string) {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	generated := map[string][]byte{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		require.NoError(t, err)
+
+		generated[goldenName(entry.Name())] = content
+	}
+
+	if *update {
+		require.NoError(t, os.RemoveAll(goldenDir))
+		require.NoError(t, os.MkdirAll(goldenDir, 0755))
+	}
+
+	goldenEntries, err := os.ReadDir(goldenDir)
+	require.NoError(t, err, "missing golden files, run the test with -update to create them")
+
+	for _, entry := range goldenEntries {
+		_, ok := generated[entry.Name()]
+		require.True(t, ok, "golden file %s was not generated, run the test with -update to remove it", entry.Name())
+	}
+
+	names := make([]string, 0, len(generated))
+	for name := range generated {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		Assert(t, filepath.Join(goldenDir, name), generated[name])
+	}
+}
+
+// Assert is a function that will compare the output against the golden file, or rewrite the golden file when the
+// -update flag is set.
+func Assert(t *testing.T, goldenPath string, output []byte) {
+	output = Normalize(output)
+
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(goldenPath), 0755))
+		require.NoError(t, os.WriteFile(goldenPath, output, 0644))
+
+		return
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	require.NoError(t, err, "missing golden file, run the test with -update to create it")
+	require.Equal(t, string(expected), string(output), "output differs from %s, run the test with -update if the change is expected", goldenPath)
+}
+
+// Normalize is a function that will mask the values that change from one run to the next, such as random tokens.
+func Normalize(output []byte) []byte {
+	return randomToken.ReplaceAll(output, []byte("token-xxxxx"))
+}
+
+// goldenName returns the golden file name of a generated file, e.g. main.tf becomes main.golden.tf.
+func goldenName(name string) string {
+	extension := filepath.Ext(name)
+
+	return strings.TrimSuffix(name, extension) + goldenSuffix + extension
+}
+
+// repoRoot walks up from the working directory of the test until it finds the go.mod file of the repository.
+func repoRoot(t *testing.T) string {
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		require.NotEqual(t, dir, parent, "go.mod not found above the test directory")
+
+		dir = parent
+	}
+}
+
+// RequireValidHCL is a function that will fail the test if the content is not syntactically valid HCL.
+func RequireValidHCL(t *testing.T, name string, content []byte) {
+	_, diags := hclsyntax.ParseConfig(content, name, hcl.Pos{Line: 1, Column: 1})
+	require.False(t, diags.HasErrors(), "%s is not valid HCL: %s", name, diags.Error())
+}
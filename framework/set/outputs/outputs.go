@@ -0,0 +1,66 @@
+package outputs
+
+import (
+	"bytes"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/framework/format"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	output      = "output"
+	sensitive   = "sensitive"
+	clusterV1ID = "cluster_v1_id"
+	kubeConfig  = "kube_config"
+	token       = "token"
+
+	ClusterID         = "cluster_id"
+	V1ClusterID       = "v1_cluster_id"
+	RegistrationToken = "registration_token"
+	Kubeconfig        = "kubeconfig"
+)
+
+// SetClusterOutputs is a function that will set the output block of the cluster created by the given resource in the
+// main.tf file. The output is named after the resource prefix of the cluster and holds its cluster ID, v1 cluster ID,
+// registration token and kubeconfig, so that tests can read them back after terraform apply without looking the cluster
+// up by name. The output is marked as sensitive.
+func SetClusterOutputs(rootBody *hclwrite.Body, resourceType, resourceName, outputName string) {
+	cluster := format.Reference(resourceType, resourceName)
+
+	clusterID := format.Attribute(cluster, defaults.ID)
+	v1ClusterID := format.Attribute(cluster, defaults.ID)
+
+	if resourceType == defaults.ClusterV2 {
+		clusterID = format.Attribute(cluster, clusterV1ID)
+	}
+
+	registrationToken := format.Attribute(format.Index(format.Attribute(cluster, defaults.ClusterRegistrationToken),
+		hclwrite.TokensForValue(cty.NumberIntVal(0))), token)
+
+	if !endsWithBlankLine(rootBody) {
+		rootBody.AppendNewline()
+	}
+
+	outputBlock := rootBody.AppendNewBlock(output, []string{outputName})
+	outputBlockBody := outputBlock.Body()
+
+	outputBlockBody.SetAttributeRaw(defaults.Value, format.Object(map[string]hclwrite.Tokens{
+		ClusterID:         clusterID,
+		V1ClusterID:       v1ClusterID,
+		RegistrationToken: registrationToken,
+		Kubeconfig:        format.Attribute(cluster, kubeConfig),
+	}))
+	outputBlockBody.SetAttributeValue(sensitive, cty.True)
+
+	rootBody.AppendNewline()
+}
+
+// endsWithBlankLine returns whether the given body already ends with a blank line, as not every generator leaves one
+// after its last block.
+func endsWithBlankLine(rootBody *hclwrite.Body) bool {
+	content := rootBody.BuildTokens(nil).Bytes()
+
+	return len(content) == 0 || bytes.HasSuffix(content, []byte("\n\n"))
+}
@@ -0,0 +1,53 @@
+package airgap
+
+import (
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/generators"
+	"github.com/rancher/tfp-automation/framework/set/outputs"
+)
+
+type airgapGenerator struct{}
+
+func init() {
+	generators.Register(airgapGenerator{})
+}
+
+// Modules returns the airgap modules.
+func (airgapGenerator) Modules() []string {
+	return []string{modules.AirgapRKE1, modules.AirgapRKE2, modules.AirgapK3S}
+}
+
+// RequiredProviders returns the providers needed to create the airgap nodes.
+func (airgapGenerator) RequiredProviders() []string {
+	return []string{defaults.Aws, defaults.Local}
+}
+
+// Generate sets the airgap RKE1 or RKE2/K3s configurations in the main.tf file.
+func (airgapGenerator) Generate(input *generators.Input) error {
+	var err error
+
+	resourcePrefix := input.TerraformConfig.ResourcePrefix
+
+	if input.TerraformConfig.Module == modules.AirgapRKE1 {
+		_, err = SetAirgapRKE1(input.RancherConfig, input.TerraformConfig, input.TerratestConfig, input.ConfigMap, input.NewFile,
+			input.RootBody, input.File)
+		if err != nil {
+			return err
+		}
+
+		outputs.SetClusterOutputs(input.RootBody, defaults.Cluster, resourcePrefix, resourcePrefix)
+
+		return nil
+	}
+
+	_, err = SetAirgapRKE2K3s(input.RancherConfig, input.TerraformConfig, input.TerratestConfig, input.ConfigMap, input.NewFile,
+		input.RootBody, input.File)
+	if err != nil {
+		return err
+	}
+
+	outputs.SetClusterOutputs(input.RootBody, defaults.ClusterV2, resourcePrefix, resourcePrefix)
+
+	return nil
+}
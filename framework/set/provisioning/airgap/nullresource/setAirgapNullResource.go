@@ -1,10 +1,10 @@
 package nullresource
 
 import (
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -20,19 +20,12 @@ const (
 // SetAirgapNullResource is a function that will set the airgap null_resource configurations in the main.tf file,
 // to register the nodes to the cluster
 func SetAirgapNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, description string,
-	dependsOn []string) (*hclwrite.Body, error) {
+	dependsOn []hclwrite.Tokens) (*hclwrite.Body, error) {
 	nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, description})
 	nullResourceBlockBody := nullResourceBlock.Body()
 
 	if len(dependsOn) > 0 {
-		var dependsOnValue hclwrite.Tokens
-		for _, dep := range dependsOn {
-			dependsOnValue = hclwrite.Tokens{
-				{Type: hclsyntax.TokenIdent, Bytes: []byte(dep)},
-			}
-		}
-
-		nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, dependsOnValue)
+		nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, format.ListOfReferences(dependsOn...))
 	}
 
 	provisionerBlock := nullResourceBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.RemoteExec})
@@ -41,18 +34,14 @@ func SetAirgapNullResource(rootBody *hclwrite.Body, terraformConfig *config.Terr
 	connectionBlock := provisionerBlockBody.AppendNewBlock(defaults.Connection, nil)
 	connectionBlockBody := connectionBlock.Body()
 
-	var bastionHostExpression string
+	var bastionHost hclwrite.Tokens
 
 	if terraformConfig.Module == modules.ImportEC2K3s {
-		bastionHostExpression = `"${` + defaults.AwsInstance + `.` + k3sServerOne + `.` + defaults.PublicIp + `}"`
+		bastionHost = format.Reference(defaults.AwsInstance, k3sServerOne, defaults.PublicIp)
 	} else if terraformConfig.Module == modules.ImportEC2RKE2 {
-		bastionHostExpression = `"${` + defaults.AwsInstance + `.` + rke2ServerOne + `.` + defaults.PublicIp + `}"`
+		bastionHost = format.Reference(defaults.AwsInstance, rke2ServerOne, defaults.PublicIp)
 	} else {
-		bastionHostExpression = defaults.AwsInstance + `.` + bastion + `.` + defaults.PublicIp
-	}
-
-	bastionHost := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(bastionHostExpression)},
+		bastionHost = format.Reference(defaults.AwsInstance, bastion, defaults.PublicIp)
 	}
 
 	connectionBlockBody.SetAttributeRaw(defaults.Host, bastionHost)
@@ -60,12 +49,7 @@ func SetAirgapNullResource(rootBody *hclwrite.Body, terraformConfig *config.Terr
 	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.Ssh))
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.AWSUser))
 
-	keyPathExpression := defaults.File + `("` + terraformConfig.PrivateKeyPath + `")`
-	keyPath := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(keyPathExpression)},
-	}
-
-	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, keyPath)
+	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, format.File(terraformConfig.PrivateKeyPath))
 	connectionBlockBody.SetAttributeValue(defaults.Timeout, cty.StringVal(terraformConfig.AWSConfig.Timeout))
 
 	return provisionerBlockBody, nil
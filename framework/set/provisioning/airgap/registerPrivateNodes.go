@@ -4,15 +4,15 @@ import (
 	"encoding/base64"
 	"os"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 )
 
 // registerPrivateNodes is a function that will register the private nodes to the cluster
 func registerPrivateNodes(provisionerBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig, bastionPublicIP, nodePrivateIP,
-	registrationCommand string) error {
+	registrationCommand hclwrite.Tokens) error {
 	privateKey, err := os.ReadFile(terraformConfig.PrivateKeyPath)
 	if err != nil {
 		return nil
@@ -20,15 +20,23 @@ func registerPrivateNodes(provisionerBlockBody *hclwrite.Body, terraformConfig *
 
 	encodedPEMFile := base64.StdEncoding.EncodeToString([]byte(privateKey))
 
-	newCommand := `\"` + registrationCommand + `\"`
+	command := format.String(
+		format.Literal("/tmp/register-nodes.sh "+encodedPEMFile+" "+terraformConfig.Standalone.OSUser+" "+terraformConfig.Standalone.OSGroup+" "),
+		format.Interpolate(bastionPublicIP),
+		format.Literal(" "),
+		format.Interpolate(nodePrivateIP),
+		format.Literal(` "`),
+		registrationCommand,
+		format.Literal(`" `+terraformConfig.PrivateRegistries.SystemDefaultRegistry),
+	)
 
-	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.Tokens{
-		{Type: hclsyntax.TokenOQuote, Bytes: []byte(`["`), SpacesBefore: 1},
-		{Type: hclsyntax.TokenStringLit, Bytes: []byte("/tmp/register-nodes.sh " + encodedPEMFile + " " +
-			terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " + bastionPublicIP + " " +
-			nodePrivateIP + " " + newCommand + " " + terraformConfig.PrivateRegistries.SystemDefaultRegistry)},
-		{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"]`), SpacesBefore: 1},
-	})
+	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.TokensForTuple([]hclwrite.Tokens{command}))
 
 	return nil
 }
+
+// registrationCommand is a function that will return the template parts running the given node command with the given
+// role flags.
+func registrationCommand(nodeCommand hclwrite.Tokens, roleFlags string) hclwrite.Tokens {
+	return append(format.Interpolate(nodeCommand), format.Literal(" "+roleFlags)...)
+}
@@ -1,12 +1,12 @@
 package airgap
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/airgap/nullresource"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/locals"
@@ -14,6 +14,7 @@ import (
 	airgap "github.com/rancher/tfp-automation/framework/set/resources/airgap/aws"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/aws"
 	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // // SetAirgapRKE1 is a function that will set the airgap RKE1 cluster configurations in the main.tf file.
@@ -51,14 +52,14 @@ func SetAirgapRKE1(rancherConfig *rancher.Config, terraformConfig *config.Terraf
 	registrationCommands, nodePrivateIPs := getRKE1RegistrationCommands(terraformConfig.ResourcePrefix)
 
 	for _, instance := range instances {
-		var dependsOn []string
+		var dependsOn []hclwrite.Tokens
 
 		// Depending on the airgapped node, add the specific dependsOn expression.
-		bastionScriptExpression := "[" + defaults.NullResource + `.copy_script_to_bastion` + "]"
-		nodeOneExpression := "[" + defaults.NullResource + `.register_` + airgapNodeOne + "]"
-		nodeTwoExpression := "[" + defaults.NullResource + `.register_` + airgapNodeTwo + "]"
+		bastionScriptExpression := format.Reference(defaults.NullResource, copyScriptToBastion)
+		nodeOneExpression := format.Reference(defaults.NullResource, "register_"+airgapNodeOne)
+		nodeTwoExpression := format.Reference(defaults.NullResource, "register_"+airgapNodeTwo)
 
-		bastionPublicIP := fmt.Sprintf("${%s.%s.%s}", defaults.AwsInstance, bastion, defaults.PublicIp)
+		bastionPublicIP := format.Reference(defaults.AwsInstance, bastion, defaults.PublicIp)
 
 		if instance == airgapNodeOne {
 			dependsOn = append(dependsOn, bastionScriptExpression)
@@ -91,19 +92,21 @@ func SetAirgapRKE1(rancherConfig *rancher.Config, terraformConfig *config.Terraf
 }
 
 // getRKE1RegistrationCommands is a helper function that will return the registration commands for the airgap nodes.
-func getRKE1RegistrationCommands(clusterName string) (map[string]string, map[string]string) {
-	commands := make(map[string]string)
-	nodePrivateIPs := make(map[string]string)
+func getRKE1RegistrationCommands(clusterName string) (map[string]hclwrite.Tokens, map[string]hclwrite.Tokens) {
+	commands := make(map[string]hclwrite.Tokens)
+	nodePrivateIPs := make(map[string]hclwrite.Tokens)
 
-	regCommand := fmt.Sprintf("${%s.%s.%s[0].%s}", defaults.Cluster, clusterName, defaults.ClusterRegistrationToken, defaults.NodeCommand)
+	registrationToken := format.Index(format.Reference(defaults.Cluster, clusterName, defaults.ClusterRegistrationToken),
+		hclwrite.TokensForValue(cty.NumberIntVal(0)))
+	nodeCommand := format.Attribute(registrationToken, defaults.NodeCommand)
 
-	etcdRegistrationCommand := fmt.Sprintf(regCommand+" %s", defaults.EtcdRoleFlag)
-	controlPlaneRegistrationCommand := fmt.Sprintf(regCommand+" %s", defaults.ControlPlaneRoleFlag)
-	workerRegistrationCommand := fmt.Sprintf(regCommand+" %s", defaults.WorkerRoleFlag)
+	etcdRegistrationCommand := registrationCommand(nodeCommand, defaults.EtcdRoleFlag)
+	controlPlaneRegistrationCommand := registrationCommand(nodeCommand, defaults.ControlPlaneRoleFlag)
+	workerRegistrationCommand := registrationCommand(nodeCommand, defaults.WorkerRoleFlag)
 
-	airgapNodeOnePrivateIP := fmt.Sprintf("${%s.%s.%s}", defaults.AwsInstance, airgapNodeOne, defaults.PrivateIp)
-	airgapNodeTwoPrivateIP := fmt.Sprintf("${%s.%s.%s}", defaults.AwsInstance, airgapNodeTwo, defaults.PrivateIp)
-	airgapNodeThreePrivateIP := fmt.Sprintf("${%s.%s.%s}", defaults.AwsInstance, airgapNodeThree, defaults.PrivateIp)
+	airgapNodeOnePrivateIP := format.Reference(defaults.AwsInstance, airgapNodeOne, defaults.PrivateIp)
+	airgapNodeTwoPrivateIP := format.Reference(defaults.AwsInstance, airgapNodeTwo, defaults.PrivateIp)
+	airgapNodeThreePrivateIP := format.Reference(defaults.AwsInstance, airgapNodeThree, defaults.PrivateIp)
 
 	commands[airgapNodeOne] = etcdRegistrationCommand
 	commands[airgapNodeTwo] = controlPlaneRegistrationCommand
@@ -1,13 +1,13 @@
 package airgap
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/airgap/nullresource"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/locals"
@@ -66,14 +66,14 @@ func SetAirgapRKE2K3s(rancherConfig *rancher.Config, terraformConfig *config.Ter
 	registrationCommands, nodePrivateIPs := getRKE2K3sRegistrationCommands(terraformConfig)
 
 	for _, instance := range instances {
-		var dependsOn []string
+		var dependsOn []hclwrite.Tokens
 
 		// Depending on the airgapped node, add the specific dependsOn expression.
-		bastionScriptExpression := "[" + defaults.NullResource + `.copy_script_to_bastion` + "]"
-		nodeOneExpression := "[" + defaults.NullResource + `.register_` + airgapNodeOne + "]"
-		nodeTwoExpression := "[" + defaults.NullResource + `.register_` + airgapNodeTwo + "]"
+		bastionScriptExpression := format.Reference(defaults.NullResource, copyScriptToBastion)
+		nodeOneExpression := format.Reference(defaults.NullResource, "register_"+airgapNodeOne)
+		nodeTwoExpression := format.Reference(defaults.NullResource, "register_"+airgapNodeTwo)
 
-		bastionPublicIP := fmt.Sprintf("${%s.%s.%s}", defaults.AwsInstance, bastion, defaults.PublicIp)
+		bastionPublicIP := format.Reference(defaults.AwsInstance, bastion, defaults.PublicIp)
 
 		if instance == airgapNodeOne {
 			dependsOn = append(dependsOn, bastionScriptExpression)
@@ -106,18 +106,20 @@ func SetAirgapRKE2K3s(rancherConfig *rancher.Config, terraformConfig *config.Ter
 }
 
 // getRKE2K3sRegistrationCommands is a helper function that will return the registration commands for the airgap nodes.
-func getRKE2K3sRegistrationCommands(terraformConfig *config.TerraformConfig) (map[string]string, map[string]string) {
-	commands := make(map[string]string)
-	nodePrivateIPs := make(map[string]string)
-
-	etcdRegistrationCommand := fmt.Sprintf("${%s.%s_%s} %s", defaults.Local, terraformConfig.ResourcePrefix, defaults.InsecureNodeCommand, defaults.EtcdRoleFlag)
-	controlPlaneRegistrationCommand := fmt.Sprintf("${%s.%s_%s} %s", defaults.Local, terraformConfig.ResourcePrefix, defaults.InsecureNodeCommand, defaults.ControlPlaneRoleFlag)
-	workerRegistrationCommand := fmt.Sprintf("${%s.%s_%s} %s", defaults.Local, terraformConfig.ResourcePrefix, defaults.InsecureNodeCommand, defaults.WorkerRoleFlag)
-	allRolesRegistrationCommand := fmt.Sprintf("${%s.%s_%s} %s", defaults.Local, terraformConfig.ResourcePrefix, defaults.InsecureNodeCommand, defaults.AllFlags)
-
-	airgapNodeOnePrivateIP := fmt.Sprintf("${%s.%s.%s}", defaults.AwsInstance, airgapNodeOne, defaults.PrivateIp)
-	airgapNodeTwoPrivateIP := fmt.Sprintf("${%s.%s.%s}", defaults.AwsInstance, airgapNodeTwo, defaults.PrivateIp)
-	airgapNodeThreePrivateIP := fmt.Sprintf("${%s.%s.%s}", defaults.AwsInstance, airgapNodeThree, defaults.PrivateIp)
+func getRKE2K3sRegistrationCommands(terraformConfig *config.TerraformConfig) (map[string]hclwrite.Tokens, map[string]hclwrite.Tokens) {
+	commands := make(map[string]hclwrite.Tokens)
+	nodePrivateIPs := make(map[string]hclwrite.Tokens)
+
+	nodeCommand := format.Reference(defaults.Local, terraformConfig.ResourcePrefix+"_"+defaults.InsecureNodeCommand)
+
+	etcdRegistrationCommand := registrationCommand(nodeCommand, defaults.EtcdRoleFlag)
+	controlPlaneRegistrationCommand := registrationCommand(nodeCommand, defaults.ControlPlaneRoleFlag)
+	workerRegistrationCommand := registrationCommand(nodeCommand, defaults.WorkerRoleFlag)
+	allRolesRegistrationCommand := registrationCommand(nodeCommand, defaults.AllFlags)
+
+	airgapNodeOnePrivateIP := format.Reference(defaults.AwsInstance, airgapNodeOne, defaults.PrivateIp)
+	airgapNodeTwoPrivateIP := format.Reference(defaults.AwsInstance, airgapNodeTwo, defaults.PrivateIp)
+	airgapNodeThreePrivateIP := format.Reference(defaults.AwsInstance, airgapNodeThree, defaults.PrivateIp)
 
 	if terraformConfig.Module == modules.AirgapRKE2 {
 		commands[airgapNodeOne] = etcdRegistrationCommand
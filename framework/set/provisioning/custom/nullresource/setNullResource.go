@@ -1,10 +1,10 @@
 package nullresource
 
 import (
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -15,26 +15,22 @@ func SetNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformC
 	nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, defaults.RegisterNodes + "-" + terraformConfig.ResourcePrefix})
 	nullResourceBlockBody := nullResourceBlock.Body()
 
-	countExpression := defaults.Length + `(` + defaults.AwsInstance + `.` + terraformConfig.ResourcePrefix + `)`
-	nullResourceBlockBody.SetAttributeRaw(defaults.Count, hclwrite.TokensForIdentifier(countExpression))
+	count := format.FunctionCall(defaults.Length, format.Reference(defaults.AwsInstance, terraformConfig.ResourcePrefix))
+	nullResourceBlockBody.SetAttributeRaw(defaults.Count, count)
 
 	provisionerBlock := nullResourceBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.RemoteExec})
 	provisionerBlockBody := provisionerBlock.Body()
 
 	if terraformConfig.Module == modules.CustomEC2RKE1 {
-		regCommand := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(`["${` + defaults.Cluster + `.` + terraformConfig.ResourcePrefix + `.` + defaults.ClusterRegistrationToken + `[0].` + defaults.NodeCommand + `} ${` + defaults.Local + `.` + defaults.RoleFlags + `[` + defaults.Count + `.` + defaults.Index + `]}"]`)},
-		}
+		nodeCommand := format.Attribute(format.Index(format.Reference(defaults.Cluster, terraformConfig.ResourcePrefix, defaults.ClusterRegistrationToken),
+			hclwrite.TokensForValue(cty.NumberIntVal(0))), defaults.NodeCommand)
 
-		provisionerBlockBody.SetAttributeRaw(defaults.Inline, regCommand)
+		provisionerBlockBody.SetAttributeRaw(defaults.Inline, registrationCommand(nodeCommand))
 	}
 
 	if terraformConfig.Module == modules.CustomEC2RKE2 || terraformConfig.Module == modules.CustomEC2K3s {
-		regCommand := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(`["${` + defaults.Local + `.` + terraformConfig.ResourcePrefix + "_" + defaults.InsecureNodeCommand + `} ${` + defaults.Local + `.` + defaults.RoleFlags + `[` + defaults.Count + `.` + defaults.Index + `]}"]`)},
-		}
-
-		provisionerBlockBody.SetAttributeRaw(defaults.Inline, regCommand)
+		nodeCommand := format.Reference(defaults.Local, terraformConfig.ResourcePrefix+"_"+defaults.InsecureNodeCommand)
+		provisionerBlockBody.SetAttributeRaw(defaults.Inline, registrationCommand(nodeCommand))
 	}
 
 	connectionBlock := provisionerBlockBody.AppendNewBlock(defaults.Connection, nil)
@@ -43,47 +39,38 @@ func SetNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformC
 	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.Ssh))
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.AWSUser))
 
-	hostExpression := defaults.AwsInstance + `.` + terraformConfig.ResourcePrefix + `[` + defaults.Count + `.` + defaults.Index + `].` + defaults.PublicIp
-	host := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(hostExpression)},
-	}
+	host := format.Attribute(format.Index(format.Reference(defaults.AwsInstance, terraformConfig.ResourcePrefix),
+		format.Reference(defaults.Count, defaults.Index)), defaults.PublicIp)
 
 	connectionBlockBody.SetAttributeRaw(defaults.Host, host)
-
-	keyPathExpression := defaults.File + `("` + terraformConfig.PrivateKeyPath + `")`
-	keyPath := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(keyPathExpression)},
-	}
-
-	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, keyPath)
+	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, format.File(terraformConfig.PrivateKeyPath))
 
 	if terraformConfig.Module == modules.CustomEC2RKE2 || terraformConfig.Module == modules.CustomEC2K3s ||
 		terraformConfig.Module == modules.CustomEC2RKE2Windows {
-		regCommand := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(`["${` + defaults.Local + `.` + terraformConfig.ResourcePrefix + "_" + defaults.InsecureNodeCommand + `} ${` + defaults.Local + `.` + defaults.RoleFlags + `[` + defaults.Count + `.` + defaults.Index + `]}"]`)},
-		}
-
-		provisionerBlockBody.SetAttributeRaw(defaults.Inline, regCommand)
+		nodeCommand := format.Reference(defaults.Local, terraformConfig.ResourcePrefix+"_"+defaults.InsecureNodeCommand)
+		provisionerBlockBody.SetAttributeRaw(defaults.Inline, registrationCommand(nodeCommand))
 	}
 
 	if terraformConfig.Module == modules.CustomEC2RKE1 {
-		clusterExpression := `[` + defaults.Cluster + `.` + terraformConfig.ResourcePrefix + `]`
-		cluster := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(clusterExpression)},
-		}
-
+		cluster := format.ListOfReferences(format.Reference(defaults.Cluster, terraformConfig.ResourcePrefix))
 		nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, cluster)
 	}
 
 	if terraformConfig.Module == modules.CustomEC2RKE2 || terraformConfig.Module == modules.CustomEC2K3s ||
 		terraformConfig.Module == modules.CustomEC2RKE2Windows {
-		clusterV2Expression := `[` + defaults.ClusterV2 + `.` + terraformConfig.ResourcePrefix + `]`
-		clusterV2 := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(clusterV2Expression)},
-		}
-
+		clusterV2 := format.ListOfReferences(format.Reference(defaults.ClusterV2, terraformConfig.ResourcePrefix))
 		nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, clusterV2)
 	}
 
 	return nil
 }
+
+// registrationCommand is a function that will return the inline command running the given node command with the role
+// flags of the current node.
+func registrationCommand(nodeCommand hclwrite.Tokens) hclwrite.Tokens {
+	roleFlags := format.Index(format.Reference(defaults.Local, defaults.RoleFlags), format.Reference(defaults.Count, defaults.Index))
+
+	return hclwrite.TokensForTuple([]hclwrite.Tokens{
+		format.String(format.Interpolate(nodeCommand), format.Literal(" "), format.Interpolate(roleFlags)),
+	})
+}
@@ -1,9 +1,9 @@
 package nullresource
 
 import (
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -14,8 +14,8 @@ func SetWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.Ter
 	nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, defaults.RegisterNodes + "-" + terraformConfig.ResourcePrefix + "-windows"})
 	nullResourceBlockBody := nullResourceBlock.Body()
 
-	countExpression := defaults.Length + `(` + defaults.AwsInstance + `.` + terraformConfig.ResourcePrefix + `-windows)`
-	nullResourceBlockBody.SetAttributeRaw(defaults.Count, hclwrite.TokensForIdentifier(countExpression))
+	count := format.FunctionCall(defaults.Length, format.Reference(defaults.AwsInstance, terraformConfig.ResourcePrefix+"-windows"))
+	nullResourceBlockBody.SetAttributeRaw(defaults.Count, count)
 
 	provisionerBlock := nullResourceBlockBody.AppendNewBlock(defaults.Provisioner, []string{defaults.RemoteExec})
 	provisionerBlockBody := provisionerBlock.Body()
@@ -26,24 +26,18 @@ func SetWindowsNullResource(rootBody *hclwrite.Body, terraformConfig *config.Ter
 	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.Ssh))
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.WindowsAWSUser))
 
-	hostExpression := defaults.AwsInstance + `.` + terraformConfig.ResourcePrefix + `-windows[` + defaults.Count + `.` + defaults.Index + `].` + defaults.PublicIp
-	host := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(hostExpression)},
-	}
+	host := format.Attribute(format.Index(format.Reference(defaults.AwsInstance, terraformConfig.ResourcePrefix+"-windows"),
+		format.Reference(defaults.Count, defaults.Index)), defaults.PublicIp)
 
 	connectionBlockBody.SetAttributeRaw(defaults.Host, host)
 	connectionBlockBody.SetAttributeValue(defaults.TargetPlatform, cty.StringVal(defaults.Windows))
 
-	keyPathExpression := defaults.File + `("` + terraformConfig.WindowsPrivateKeyPath + `")`
-	keyPath := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(keyPathExpression)},
-	}
+	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, format.File(terraformConfig.WindowsPrivateKeyPath))
 
-	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, keyPath)
-
-	regCommand := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(`["powershell.exe ${` + defaults.Local + `.` + terraformConfig.ResourcePrefix + "_" + defaults.InsecureWindowsNodeCommand + `}"]`)},
-	}
+	nodeCommand := format.Reference(defaults.Local, terraformConfig.ResourcePrefix+"_"+defaults.InsecureWindowsNodeCommand)
+	regCommand := hclwrite.TokensForTuple([]hclwrite.Tokens{
+		format.String(format.Literal("powershell.exe "), format.Interpolate(nodeCommand)),
+	})
 
 	provisionerBlockBody.SetAttributeRaw(defaults.Inline, regCommand)
 
@@ -0,0 +1,37 @@
+package rke1
+
+import (
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/generators"
+	"github.com/rancher/tfp-automation/framework/set/outputs"
+)
+
+type customRKE1Generator struct{}
+
+func init() {
+	generators.Register(customRKE1Generator{})
+}
+
+// Modules returns the custom RKE1 modules.
+func (customRKE1Generator) Modules() []string {
+	return []string{modules.CustomEC2RKE1}
+}
+
+// RequiredProviders returns the providers needed to create the custom RKE1 nodes.
+func (customRKE1Generator) RequiredProviders() []string {
+	return []string{defaults.Aws, defaults.Local}
+}
+
+// Generate sets the custom RKE1 configurations in the main.tf file.
+func (customRKE1Generator) Generate(input *generators.Input) error {
+	_, err := SetCustomRKE1(input.RancherConfig, input.TerraformConfig, input.TerratestConfig, input.ConfigMap, input.NewFile,
+		input.RootBody, input.File)
+	if err != nil {
+		return err
+	}
+
+	outputs.SetClusterOutputs(input.RootBody, defaults.Cluster, input.TerraformConfig.ResourcePrefix, input.TerraformConfig.ResourcePrefix)
+
+	return nil
+}
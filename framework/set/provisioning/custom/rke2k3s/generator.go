@@ -0,0 +1,45 @@
+package rke2k3s
+
+import (
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/generators"
+	"github.com/rancher/tfp-automation/framework/set/outputs"
+)
+
+type customRKE2K3sGenerator struct{}
+
+func init() {
+	generators.Register(customRKE2K3sGenerator{})
+}
+
+// Modules returns the custom RKE2/K3s modules.
+func (customRKE2K3sGenerator) Modules() []string {
+	return []string{modules.CustomEC2RKE2, modules.CustomEC2RKE2Windows, modules.CustomEC2K3s}
+}
+
+// RequiredProviders returns the providers needed to create the custom RKE2/K3s nodes.
+func (customRKE2K3sGenerator) RequiredProviders() []string {
+	return []string{defaults.Aws, defaults.Local}
+}
+
+// Generate sets the custom RKE2/K3s configurations, and the Windows nodes if requested, in the main.tf file.
+func (customRKE2K3sGenerator) Generate(input *generators.Input) error {
+	_, err := SetCustomRKE2K3s(input.RancherConfig, input.TerraformConfig, input.TerratestConfig, input.ConfigMap, input.NewFile,
+		input.RootBody, input.File)
+	if err != nil {
+		return err
+	}
+
+	if input.IsWindows {
+		_, err = SetCustomRKE2Windows(input.RancherConfig, input.TerraformConfig, input.TerratestConfig, input.ConfigMap,
+			input.NewFile, input.RootBody, input.File)
+		if err != nil {
+			return err
+		}
+	}
+
+	outputs.SetClusterOutputs(input.RootBody, defaults.ClusterV2, input.TerraformConfig.ResourcePrefix, input.TerraformConfig.ResourcePrefix)
+
+	return nil
+}
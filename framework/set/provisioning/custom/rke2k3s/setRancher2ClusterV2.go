@@ -3,11 +3,10 @@ package rke2k3s
 import (
 	"strings"
 
-	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	v2 "github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke2k3s"
 	"github.com/zclconf/go-cty/cty"
@@ -29,9 +28,7 @@ func SetRancher2ClusterV2(rootBody *hclwrite.Body, terraformConfig *config.Terra
 	rkeConfigBlockBody := rkeConfigBlock.Body()
 
 	if strings.Contains(terraformConfig.Module, "rke2") {
-		machineGlobalConfigValue := hclwrite.TokensForTraversal(hcl.Traversal{
-			hcl.TraverseRoot{Name: "<<EOF\ncni: " + terraformConfig.CNI + "\nEOF"},
-		})
+		machineGlobalConfigValue := format.Heredoc("cni: " + terraformConfig.CNI)
 		rkeConfigBlockBody.SetAttributeRaw(defaults.MachineGlobalConfig, machineGlobalConfigValue)
 	}
 
@@ -50,12 +47,7 @@ func SetRancher2ClusterV2(rootBody *hclwrite.Body, terraformConfig *config.Terra
 	}
 
 	if terraformConfig.Module == modules.CustomEC2RKE2Windows {
-		dependsOnBlock := `[` + defaults.AwsInstance + `.` + terraformConfig.ResourcePrefix + `-windows]`
-
-		server := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnBlock)},
-		}
-
+		server := format.ListOfReferences(format.Reference(defaults.AwsInstance, terraformConfig.ResourcePrefix+"-windows"))
 		rancher2ClusterV2BlockBody.SetAttributeRaw(defaults.DependsOn, server)
 	}
 
@@ -11,7 +11,7 @@ import (
 )
 
 // SetCustomRKE2Windows is a function that will set the custom RKE2 cluster configurations in the main.tf file.
-func SetCustomRKE2Windows(client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
+func SetCustomRKE2Windows(rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, configMap []map[string]any, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File) (*os.File, error) {
 	nullresource.SetWindowsNullResource(rootBody, terraformConfig)
 	rootBody.AppendNewline()
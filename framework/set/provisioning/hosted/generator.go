@@ -0,0 +1,49 @@
+package hosted
+
+import (
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/generators"
+	"github.com/rancher/tfp-automation/framework/set/outputs"
+)
+
+type hostedGenerator struct{}
+
+func init() {
+	generators.Register(hostedGenerator{})
+}
+
+// Modules returns the hosted cluster modules.
+func (hostedGenerator) Modules() []string {
+	return []string{clustertypes.AKS, clustertypes.EKS, clustertypes.GKE}
+}
+
+// RequiredProviders returns the providers needed by the hosted clusters.
+func (hostedGenerator) RequiredProviders() []string {
+	return nil
+}
+
+// Generate sets the AKS, EKS or GKE configurations in the main.tf file.
+func (hostedGenerator) Generate(input *generators.Input) error {
+	var err error
+
+	terraformConfig := input.TerraformConfig
+	terratestConfig := input.TerratestConfig
+
+	switch terraformConfig.Module {
+	case clustertypes.AKS:
+		_, err = SetAKS(terraformConfig, terratestConfig.KubernetesVersion, terratestConfig.Nodepools, input.NewFile, input.RootBody, input.File)
+	case clustertypes.EKS:
+		_, err = SetEKS(terraformConfig, terratestConfig.KubernetesVersion, terratestConfig.Nodepools, input.NewFile, input.RootBody, input.File)
+	case clustertypes.GKE:
+		_, err = SetGKE(terraformConfig, terratestConfig.KubernetesVersion, terratestConfig.Nodepools, input.NewFile, input.RootBody, input.File)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	outputs.SetClusterOutputs(input.RootBody, defaults.Cluster, terraformConfig.ResourcePrefix, terraformConfig.ResourcePrefix)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package imported
+
+import (
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/generators"
+	"github.com/rancher/tfp-automation/framework/set/outputs"
+)
+
+type importedRKE1Generator struct{}
+
+type importedRKE2K3sGenerator struct{}
+
+func init() {
+	generators.Register(importedRKE1Generator{})
+	generators.Register(importedRKE2K3sGenerator{})
+}
+
+// Modules returns the imported RKE1 modules.
+func (importedRKE1Generator) Modules() []string {
+	return []string{modules.ImportEC2RKE1}
+}
+
+// RequiredProviders returns the providers needed to create and import the RKE1 cluster.
+func (importedRKE1Generator) RequiredProviders() []string {
+	return []string{defaults.Aws, defaults.Local, defaults.RKE}
+}
+
+// Generate sets the imported RKE1 configurations in the main.tf file.
+func (importedRKE1Generator) Generate(input *generators.Input) error {
+	_, err := SetImportedRKE1(input.RancherConfig, input.TerraformConfig, input.TerratestConfig, input.NewFile, input.RootBody, input.File)
+	if err != nil {
+		return err
+	}
+
+	outputs.SetClusterOutputs(input.RootBody, defaults.Cluster, input.TerraformConfig.ResourcePrefix, input.TerraformConfig.ResourcePrefix)
+
+	return nil
+}
+
+// Modules returns the imported RKE2/K3s modules.
+func (importedRKE2K3sGenerator) Modules() []string {
+	return []string{modules.ImportEC2RKE2, modules.ImportEC2K3s}
+}
+
+// RequiredProviders returns the providers needed to create and import the RKE2/K3s cluster.
+func (importedRKE2K3sGenerator) RequiredProviders() []string {
+	return []string{defaults.Aws, defaults.Local}
+}
+
+// Generate sets the imported RKE2/K3s configurations in the main.tf file.
+func (importedRKE2K3sGenerator) Generate(input *generators.Input) error {
+	_, err := SetImportedRKE2K3s(input.RancherConfig, input.TerraformConfig, input.TerratestConfig, input.NewFile, input.RootBody, input.File)
+	if err != nil {
+		return err
+	}
+
+	outputs.SetClusterOutputs(input.RootBody, defaults.Cluster, input.TerraformConfig.ResourcePrefix, input.TerraformConfig.ResourcePrefix)
+
+	return nil
+}
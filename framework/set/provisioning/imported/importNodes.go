@@ -5,17 +5,17 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/imported"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // importNodes is a function that will import the nodes to the cluster
-func importNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, nodeOnePublicDNS, kubeConfig, importCommand string) error {
+func importNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, nodeOnePublicDNS, kubeConfig, importCommand hclwrite.Tokens) error {
 	userDir, err := os.UserHomeDir()
 	if err != nil {
 		return err
@@ -35,17 +35,21 @@ func importNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfi
 
 	encodedPEMFile := base64.StdEncoding.EncodeToString([]byte(privateKey))
 
-	kubeConfig = `\"` + kubeConfig + `\"`
-	importCommand = `\"` + importCommand + `\"`
-
-	command := "bash -c '/tmp/import-nodes.sh " + encodedPEMFile + " " + terraformConfig.Standalone.OSUser + " " +
-		terraformConfig.Standalone.OSGroup + " " + nodeOnePublicDNS + " " + importCommand
+	commandParts := []hclwrite.Tokens{
+		format.Literal("bash -c '/tmp/import-nodes.sh " + encodedPEMFile + " " + terraformConfig.Standalone.OSUser + " " +
+			terraformConfig.Standalone.OSGroup + " "),
+		format.Interpolate(nodeOnePublicDNS),
+		format.Literal(` "`),
+		format.Interpolate(importCommand),
+		format.Literal(`"`),
+	}
 
 	if terraformConfig.Module == modules.ImportEC2RKE1 {
-		command += " " + kubeConfig
+		commandParts = append(commandParts, format.Literal(` "`), format.Interpolate(kubeConfig), format.Literal(`"`))
 	}
 
-	command += "'"
+	commandParts = append(commandParts, format.Literal("'"))
+	command := format.String(commandParts...)
 
 	// Need to first create a null resource block to copy the script to the node.
 	copyScriptName := terraformConfig.ResourcePrefix + `_` + copyScript
@@ -56,18 +60,14 @@ func importNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfi
 		cty.StringVal("chmod +x /tmp/import-nodes.sh"),
 	}))
 
-	var dependsOnServer string
+	var server hclwrite.Tokens
 
 	if terraformConfig.Module == modules.ImportEC2K3s || terraformConfig.Module == modules.ImportEC2RKE2 {
 		addServerTwoName := addServer + terraformConfig.ResourcePrefix + `_` + serverTwo
 		addServerThreeName := addServer + terraformConfig.ResourcePrefix + `_` + serverThree
-		dependsOnServer = `[` + defaults.NullResource + `.` + addServerTwoName + `, ` + defaults.NullResource + `.` + addServerThreeName + `]`
+		server = format.ListOfReferences(format.Reference(defaults.NullResource, addServerTwoName), format.Reference(defaults.NullResource, addServerThreeName))
 	} else {
-		dependsOnServer = `[` + defaults.RKECluster + `.` + terraformConfig.ResourcePrefix + `]`
-	}
-
-	server := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnServer)},
+		server = format.ListOfReferences(format.Reference(defaults.RKECluster, terraformConfig.ResourcePrefix))
 	}
 
 	nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, server)
@@ -77,17 +77,9 @@ func importNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfi
 	importClusterName := terraformConfig.ResourcePrefix + `_` + importCluster
 	nullResourceBlockBody, provisionerBlockBody = imported.CreateImportedNullResource(rootBody, terraformConfig, nodeOnePublicDNS, importClusterName)
 
-	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.Tokens{
-		{Type: hclsyntax.TokenOQuote, Bytes: []byte(`["`), SpacesBefore: 1},
-		{Type: hclsyntax.TokenStringLit, Bytes: []byte(command)},
-		{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"]`), SpacesBefore: 1},
-	})
-
-	dependsOnServer = `[` + defaults.NullResource + `.` + copyScriptName + `]`
+	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.TokensForTuple([]hclwrite.Tokens{command}))
 
-	server = hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnServer)},
-	}
+	server = format.ListOfReferences(format.Reference(defaults.NullResource, copyScriptName))
 
 	nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, server)
 
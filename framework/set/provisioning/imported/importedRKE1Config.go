@@ -1,13 +1,12 @@
 package imported
 
 import (
-	"fmt"
 	"os"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/aws"
 	"github.com/sirupsen/logrus"
@@ -26,8 +25,8 @@ func SetImportedRKE1(rancherConfig *rancher.Config, terraformConfig *config.Terr
 	importCommand := getImportCommand(terraformConfig.ResourcePrefix)
 
 	serverOneName := terraformConfig.ResourcePrefix + `_` + serverOne
-	nodeOnePublicDNS := fmt.Sprintf("${%s.%s.public_dns}", defaults.AwsInstance, serverOneName)
-	kubeConfig := fmt.Sprintf("${%s.%s.kube_config_yaml}", defaults.RKECluster, terraformConfig.ResourcePrefix)
+	nodeOnePublicDNS := format.Reference(defaults.AwsInstance, serverOneName, publicDNS)
+	kubeConfig := format.Reference(defaults.RKECluster, terraformConfig.ResourcePrefix, kubeConfigYAML)
 
 	err := importNodes(rootBody, terraformConfig, nodeOnePublicDNS, kubeConfig, importCommand[serverOneName])
 	if err != nil {
@@ -62,38 +61,19 @@ func createRKE1Cluster(rootBody *hclwrite.Body, terraformConfig *config.Terrafor
 		nodesBlock := rkeBlockBody.AppendNewBlock(defaults.Nodes, nil)
 		nodesBlockBody := nodesBlock.Body()
 
-		addressExpression := `"${` + defaults.AwsInstance + "." + instance + ".public_ip" + `}"`
-		values := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(addressExpression)},
-		}
-
-		nodesBlockBody.SetAttributeRaw(address, values)
+		nodesBlockBody.SetAttributeRaw(address, format.Reference(defaults.AwsInstance, instance, defaults.PublicIp))
 		nodesBlockBody.SetAttributeValue(user, cty.StringVal(terraformConfig.Standalone.OSUser))
-
-		rolesExpression := fmt.Sprintf(`["controlplane", "etcd", "worker"]`)
-		values = hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(rolesExpression)},
-		}
-
-		nodesBlockBody.SetAttributeRaw(role, values)
-
-		keyPathExpression := defaults.File + `("` + terraformConfig.PrivateKeyPath + `")`
-		keyPath := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(keyPathExpression)},
-		}
-
-		nodesBlockBody.SetAttributeRaw(sshKey, keyPath)
+		nodesBlockBody.SetAttributeRaw(role, format.ListOfStrings([]string{controlPlane, etcd, worker}))
+		nodesBlockBody.SetAttributeRaw(sshKey, format.File(terraformConfig.PrivateKeyPath))
 	}
 
 	rkeBlockBody.SetAttributeValue(enableCriDockerD, cty.BoolVal(true))
 
-	var dependsOnServer string
-
-	dependsOnServer = `[` + defaults.AwsInstance + `.` + serverOneName + `, ` + defaults.AwsInstance + `.` + serverTwoName + `, ` + defaults.AwsInstance + `.` + serverThreeName + `]`
-
-	server := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnServer)},
-	}
+	server := format.ListOfReferences(
+		format.Reference(defaults.AwsInstance, serverOneName),
+		format.Reference(defaults.AwsInstance, serverTwoName),
+		format.Reference(defaults.AwsInstance, serverThreeName),
+	)
 
 	rkeBlockBody.SetAttributeRaw(defaults.DependsOn, server)
 
@@ -1,24 +1,29 @@
 package imported
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/imported"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/aws"
 	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
 )
 
 const (
 	address          = "address"
 	addServer        = "add_server_"
 	importCluster    = "import_cluster"
+	kubeConfigYAML   = "kube_config_yaml"
+	publicDNS        = "public_dns"
+	controlPlane     = "controlplane"
 	copyScript       = "copy_script"
 	enableCriDockerD = "enable_cri_dockerd"
+	etcd             = "etcd"
 	role             = "role"
 	serverOne        = "server1"
 	serverTwo        = "server2"
@@ -26,6 +31,7 @@ const (
 	token            = "token"
 	sshKey           = "ssh_key"
 	user             = "user"
+	worker           = "worker"
 )
 
 // // SetImportedRKE2K3s is a function that will set the imported RKE2/K3s cluster configurations in the main.tf file.
@@ -44,12 +50,10 @@ func SetImportedRKE2K3s(rancherConfig *rancher.Config, terraformConfig *config.T
 		rootBody.AppendNewline()
 	}
 
-	var nodeOnePublicDNS, nodeOnePrivateIP, nodeTwoPublicDNS, nodeThreePublicDNS string
-
-	nodeOnePrivateIP = fmt.Sprintf("${%s.%s.private_ip}", defaults.AwsInstance, serverOneName)
-	nodeOnePublicDNS = fmt.Sprintf("${%s.%s.public_dns}", defaults.AwsInstance, serverOneName)
-	nodeTwoPublicDNS = fmt.Sprintf("${%s.%s.public_dns}", defaults.AwsInstance, serverTwoName)
-	nodeThreePublicDNS = fmt.Sprintf("${%s.%s.public_dns}", defaults.AwsInstance, serverThreeName)
+	nodeOnePrivateIP := format.Reference(defaults.AwsInstance, serverOneName, defaults.PrivateIp)
+	nodeOnePublicDNS := format.Reference(defaults.AwsInstance, serverOneName, publicDNS)
+	nodeTwoPublicDNS := format.Reference(defaults.AwsInstance, serverTwoName, publicDNS)
+	nodeThreePublicDNS := format.Reference(defaults.AwsInstance, serverThreeName, publicDNS)
 
 	imported.CreateRKE2K3SImportedCluster(rootBody, terraformConfig, nodeOnePublicDNS, nodeOnePrivateIP, nodeTwoPublicDNS, nodeThreePublicDNS)
 
@@ -57,7 +61,7 @@ func SetImportedRKE2K3s(rancherConfig *rancher.Config, terraformConfig *config.T
 
 	importCommand := getImportCommand(terraformConfig.ResourcePrefix)
 
-	err := importNodes(rootBody, terraformConfig, nodeOnePublicDNS, "", importCommand[serverOneName])
+	err := importNodes(rootBody, terraformConfig, nodeOnePublicDNS, nil, importCommand[serverOneName])
 	if err != nil {
 		return nil, err
 	}
@@ -72,9 +76,11 @@ func SetImportedRKE2K3s(rancherConfig *rancher.Config, terraformConfig *config.T
 }
 
 // getImportCommand is a helper function that will return the import command for the cluster
-func getImportCommand(clusterName string) map[string]string {
-	command := make(map[string]string)
-	importCommand := fmt.Sprintf("${%s.%s.%s[0].%s}", defaults.Cluster, clusterName, defaults.ClusterRegistrationToken, defaults.InsecureCommand)
+func getImportCommand(clusterName string) map[string]hclwrite.Tokens {
+	command := make(map[string]hclwrite.Tokens)
+	registrationToken := format.Index(format.Reference(defaults.Cluster, clusterName, defaults.ClusterRegistrationToken),
+		hclwrite.TokensForValue(cty.NumberIntVal(0)))
+	importCommand := format.Attribute(registrationToken, defaults.InsecureCommand)
 
 	serverOneName := clusterName + `_` + serverOne
 	command[serverOneName] = importCommand
@@ -6,13 +6,16 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
+// ClusterDescription is the description of the imported clusters.
+const ClusterDescription = "tfp-automation imported cluster"
+
 // SetImportedCluster is a function that will set the imported rancher2_cluster configurations in the main.tf file.
 func SetImportedCluster(rootBody *hclwrite.Body, clusterName string) error {
 	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, clusterName})
 	clusterBlockBody := clusterBlock.Body()
 
 	clusterBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(clusterName))
-	clusterBlockBody.SetAttributeValue(defaults.Description, cty.StringVal("tfp-automation imported cluster"))
+	clusterBlockBody.SetAttributeValue(defaults.Description, cty.StringVal(ClusterDescription))
 
 	return nil
 }
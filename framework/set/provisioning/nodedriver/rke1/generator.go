@@ -0,0 +1,45 @@
+package rke1
+
+import (
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/generators"
+	"github.com/rancher/tfp-automation/framework/set/outputs"
+)
+
+type rke1Generator struct{}
+
+func init() {
+	generators.Register(rke1Generator{})
+}
+
+// Modules returns the node driver RKE1 modules.
+func (rke1Generator) Modules() []string {
+	return []string{
+		modules.AzureRKE1,
+		modules.EC2RKE1,
+		modules.HarvesterRKE1,
+		modules.LinodeRKE1,
+		modules.VsphereRKE1,
+	}
+}
+
+// RequiredProviders returns the providers needed by the node driver RKE1 clusters.
+func (rke1Generator) RequiredProviders() []string {
+	return nil
+}
+
+// Generate sets the node driver RKE1 configurations in the main.tf file.
+func (rke1Generator) Generate(input *generators.Input) error {
+	terratestConfig := input.TerratestConfig
+
+	_, err := SetRKE1(input.TerraformConfig, terratestConfig.KubernetesVersion, terratestConfig.PSACT, terratestConfig.Nodepools,
+		terratestConfig.SnapshotInput, input.NewFile, input.RootBody, input.File, input.RBACRole)
+	if err != nil {
+		return err
+	}
+
+	outputs.SetClusterOutputs(input.RootBody, defaults.Cluster, input.TerraformConfig.ResourcePrefix, input.TerraformConfig.ResourcePrefix)
+
+	return nil
+}
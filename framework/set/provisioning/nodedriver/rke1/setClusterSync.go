@@ -1,26 +1,20 @@
 package rke1
 
 import (
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
 
-func setClusterSync(rootBody *hclwrite.Body, clusterSyncNodePoolIDs string, clusterName string) error {
+func setClusterSync(rootBody *hclwrite.Body, clusterSyncNodePoolIDs []hclwrite.Tokens, clusterName string) error {
 	clusterSyncBlock := rootBody.AppendNewBlock(defaults.Resource, []string{clusterSync, clusterName})
 	clusterSyncBlockBody := clusterSyncBlock.Body()
 
-	clusterID := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.Cluster + "." + clusterName + ".id")},
-	}
-
+	clusterID := format.Reference(defaults.Cluster, clusterName, defaults.ID)
 	clusterSyncBlockBody.SetAttributeRaw(defaults.RancherClusterID, clusterID)
 
-	nodePoolIDs := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte("[" + clusterSyncNodePoolIDs + "]")},
-	}
-
+	nodePoolIDs := format.ListOfReferences(clusterSyncNodePoolIDs...)
 	clusterSyncBlockBody.SetAttributeRaw(rancherNodePoolIDs, nodePoolIDs)
 	clusterSyncBlockBody.SetAttributeValue(stateConfirm, cty.NumberIntVal(2))
 
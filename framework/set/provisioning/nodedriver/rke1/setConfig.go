@@ -4,10 +4,10 @@ import (
 	"os"
 	"strings"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	v2 "github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke2k3s"
 	aws "github.com/rancher/tfp-automation/framework/set/provisioning/providers/aws"
@@ -87,15 +87,11 @@ func SetRKE1(terraformConfig *config.TerraformConfig, k8sVersion, psact string,
 	clusterBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Cluster, terraformConfig.ResourcePrefix})
 	clusterBlockBody := clusterBlock.Body()
 
-	dependsOnTemp := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte("[" + nodeTemplate + "." + terraformConfig.ResourcePrefix + "]")},
-	}
+	dependsOnTemp := format.ListOfReferences(format.Reference(nodeTemplate, terraformConfig.ResourcePrefix))
 
 	if psact == defaults.RancherBaseline {
-		dependsOnTemp = hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte("[" + nodeTemplate + "." + terraformConfig.ResourcePrefix + "," +
-				defaults.PodSecurityAdmission + "." + terraformConfig.ResourcePrefix + "]")},
-		}
+		dependsOnTemp = format.ListOfReferences(format.Reference(nodeTemplate, terraformConfig.ResourcePrefix),
+			format.Reference(defaults.PodSecurityAdmission, terraformConfig.ResourcePrefix))
 	}
 
 	clusterBlockBody.SetAttributeRaw(defaults.DependsOn, dependsOnTemp)
@@ -136,10 +132,15 @@ func SetRKE1(terraformConfig *config.TerraformConfig, k8sVersion, psact string,
 		rootBody.AppendNewline()
 	}
 
-	clusterSyncNodePoolIDs := ""
+	var clusterSyncNodePoolIDs []hclwrite.Tokens
 
 	for count, pool := range nodePools {
-		setNodePool(nodePools, count, pool, rootBody, clusterSyncNodePoolIDs, terraformConfig)
+		nodePoolID, err := setNodePool(nodePools, count, pool, rootBody, terraformConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		clusterSyncNodePoolIDs = append(clusterSyncNodePoolIDs, nodePoolID)
 	}
 
 	setClusterSync(rootBody, clusterSyncNodePoolIDs, terraformConfig.ResourcePrefix)
@@ -154,14 +155,12 @@ func SetRKE1(terraformConfig *config.TerraformConfig, k8sVersion, psact string,
 
 		rootBody.AppendNewline()
 
-		cluster := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.Cluster + "." + terraformConfig.ResourcePrefix + ".id")},
-		}
+		cluster := format.Reference(defaults.Cluster, terraformConfig.ResourcePrefix, defaults.ID)
 
 		if strings.Contains(string(rbacRole), project) {
-			rbac.AddProjectMember(nil, newFile, rootBody, cluster, rbacRole, user, "", true)
+			rbac.AddProjectMember(newFile, rootBody, cluster, rbacRole, user)
 		} else {
-			rbac.AddClusterRole(nil, newFile, rootBody, cluster, rbacRole, user, "", true)
+			rbac.AddClusterRole(newFile, rootBody, cluster, rbacRole, user)
 		}
 	}
 
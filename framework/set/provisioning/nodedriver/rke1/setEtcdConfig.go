@@ -5,6 +5,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -25,12 +26,12 @@ func setEtcdConfig(servicesBlockBody *hclwrite.Body, terraformConfig *config.Ter
 		s3ConfigBlock := backupConfigBlockBody.AppendNewBlock(s3BackupConfig, nil)
 		s3ConfigBlockBody := s3ConfigBlock.Body()
 
-		s3ConfigBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.AccessKey))
+		s3ConfigBlockBody.SetAttributeRaw(defaults.AccessKey, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.S3BackupAccessKey)))
 		s3ConfigBlockBody.SetAttributeValue(bucketName, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.BucketName))
 		s3ConfigBlockBody.SetAttributeValue(defaults.Endpoint, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.Endpoint))
 		s3ConfigBlockBody.SetAttributeValue(defaults.Folder, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.Folder))
 		s3ConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.Region))
-		s3ConfigBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.SecretKey))
+		s3ConfigBlockBody.SetAttributeRaw(defaults.SecretKey, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.S3BackupSecretKey)))
 	}
 
 	etcdBlockBody.SetAttributeValue(retention, cty.StringVal(terraformConfig.ETCDRKE1.Retention))
@@ -3,44 +3,37 @@ package rke1
 import (
 	"strconv"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/zclconf/go-cty/cty"
 )
 
 func setNodePool(nodePools []config.Nodepool, count int, pool config.Nodepool, rootBody *hclwrite.Body,
-	clusterSyncNodePoolIDs string, terraformConfig *config.TerraformConfig) error {
+	terraformConfig *config.TerraformConfig) (hclwrite.Tokens, error) {
 	poolNum := strconv.Itoa(count)
 
 	_, err := resources.SetResourceNodepoolValidation(terraformConfig, pool, poolNum)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	nodePoolBlock := rootBody.AppendNewBlock(defaults.Resource, []string{rancherNodePool, terraformConfig.ResourcePrefix + defaults.NodePool + poolNum})
+	nodePoolName := terraformConfig.ResourcePrefix + defaults.NodePool + poolNum
+	nodePoolBlock := rootBody.AppendNewBlock(defaults.Resource, []string{rancherNodePool, nodePoolName})
 	nodePoolBlockBody := nodePoolBlock.Body()
 
-	dependsOnCluster := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte("[" + defaults.Cluster + "." + terraformConfig.ResourcePrefix + "]")},
-	}
-
+	dependsOnCluster := format.ListOfReferences(format.Reference(defaults.Cluster, terraformConfig.ResourcePrefix))
 	nodePoolBlockBody.SetAttributeRaw(defaults.DependsOn, dependsOnCluster)
 
-	clusterID := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.Cluster + "." + terraformConfig.ResourcePrefix + ".id")},
-	}
+	clusterID := format.Reference(defaults.Cluster, terraformConfig.ResourcePrefix, defaults.ID)
 
 	nodePoolBlockBody.SetAttributeRaw(defaults.RancherClusterID, clusterID)
 	nodePoolBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal("pool"+poolNum))
 	nodePoolBlockBody.SetAttributeValue(hostnamePrefix, cty.StringVal(terraformConfig.ResourcePrefix+"-pool"+poolNum))
 
-	nodeTempID := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(nodeTemplate + "." + terraformConfig.ResourcePrefix + ".id")},
-	}
-
+	nodeTempID := format.Reference(nodeTemplate, terraformConfig.ResourcePrefix, defaults.ID)
 	nodePoolBlockBody.SetAttributeRaw(nodeTemplateID, nodeTempID)
 	nodePoolBlockBody.SetAttributeValue(defaults.Quantity, cty.NumberIntVal(pool.Quantity))
 	nodePoolBlockBody.SetAttributeValue(controlPlane, cty.BoolVal(pool.Controlplane))
@@ -49,13 +42,5 @@ func setNodePool(nodePools []config.Nodepool, count int, pool config.Nodepool, r
 
 	rootBody.AppendNewline()
 
-	if count != len(nodePools) {
-		clusterSyncNodePoolIDs = clusterSyncNodePoolIDs + rancherNodePool + "." + terraformConfig.ResourcePrefix + poolNum + ".id, "
-	}
-
-	if count == len(nodePools) {
-		clusterSyncNodePoolIDs = clusterSyncNodePoolIDs + rancherNodePool + "." + terraformConfig.ResourcePrefix + poolNum + ".id"
-	}
-
-	return nil
+	return format.Reference(rancherNodePool, nodePoolName, defaults.ID), nil
 }
@@ -3,6 +3,7 @@ package rke1
 import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -12,7 +13,7 @@ func setRKE1PrivateRegistryConfig(registryBlockBody *hclwrite.Body, terraformCon
 
 	if terraformConfig.StandaloneRegistry.Authenticated {
 		registryBlockBody.SetAttributeValue(privateRegistryUsername, cty.StringVal(terraformConfig.PrivateRegistries.Username))
-		registryBlockBody.SetAttributeValue(privateRegistryPassword, cty.StringVal(terraformConfig.PrivateRegistries.Password))
+		registryBlockBody.SetAttributeRaw(privateRegistryPassword, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.RegistryPassword)))
 	}
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -29,6 +30,6 @@ func CreateRegistrySecret(terraformConfig *config.TerraformConfig, rootBody *hcl
 	dataBlock := secretBlockBody.AppendNewBlock(defaults.Data+" =", nil)
 	configBlockBody := dataBlock.Body()
 
-	configBlockBody.SetAttributeValue(password, cty.StringVal(terraformConfig.PrivateRegistries.Password))
+	configBlockBody.SetAttributeRaw(password, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.RegistryPassword)))
 	configBlockBody.SetAttributeValue(username, cty.StringVal(terraformConfig.PrivateRegistries.Username))
 }
@@ -0,0 +1,55 @@
+package rke2k3s
+
+import (
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/generators"
+	"github.com/rancher/tfp-automation/framework/set/outputs"
+)
+
+type rke2K3sGenerator struct{}
+
+func init() {
+	generators.Register(rke2K3sGenerator{})
+}
+
+// Modules returns the node driver RKE2/K3s modules.
+func (rke2K3sGenerator) Modules() []string {
+	return NodeDriverModules()
+}
+
+// NodeDriverModules is a function that will return the node driver RKE2/K3s modules the generator is registered for.
+func NodeDriverModules() []string {
+	return []string{
+		modules.AzureRKE2,
+		modules.AzureK3s,
+		modules.EC2RKE2,
+		modules.EC2K3s,
+		modules.HarvesterRKE2,
+		modules.HarvesterK3s,
+		modules.LinodeRKE2,
+		modules.LinodeK3s,
+		modules.VsphereRKE2,
+		modules.VsphereK3s,
+	}
+}
+
+// RequiredProviders returns the providers needed by the node driver RKE2/K3s clusters.
+func (rke2K3sGenerator) RequiredProviders() []string {
+	return nil
+}
+
+// Generate sets the node driver RKE2/K3s configurations in the main.tf file.
+func (rke2K3sGenerator) Generate(input *generators.Input) error {
+	terratestConfig := input.TerratestConfig
+
+	_, err := SetRKE2K3s(input.TerraformConfig, terratestConfig.KubernetesVersion, terratestConfig.PSACT, terratestConfig.Nodepools,
+		terratestConfig.SnapshotInput, input.NewFile, input.RootBody, input.File, input.RBACRole)
+	if err != nil {
+		return err
+	}
+
+	outputs.SetClusterOutputs(input.RootBody, defaults.ClusterV2, input.TerraformConfig.ResourcePrefix, input.TerraformConfig.ResourcePrefix)
+
+	return nil
+}
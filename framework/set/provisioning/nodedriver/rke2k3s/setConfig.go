@@ -4,12 +4,10 @@ import (
 	"os"
 	"strings"
 
-	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
-	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	aws "github.com/rancher/tfp-automation/framework/set/provisioning/providers/aws"
 	azure "github.com/rancher/tfp-automation/framework/set/provisioning/providers/azure"
@@ -25,6 +23,7 @@ import (
 const (
 	clusterV2       = "rancher2_cluster_v2"
 	machineConfigV2 = "rancher2_machine_config_v2"
+	clusterV1ID     = "cluster_v1_id"
 
 	cloudCredentialName       = "cloud_credential_name"
 	cloudCredentialSecretName = "cloud_credential_secret_name"
@@ -60,7 +59,7 @@ const (
 )
 
 // SetRKE2K3s is a function that will set the RKE2/K3S configurations in the main.tf file.
-func SetRKE2K3s(client *rancher.Client, terraformConfig *config.TerraformConfig, k8sVersion, psact string,
+func SetRKE2K3s(terraformConfig *config.TerraformConfig, k8sVersion, psact string,
 	nodePools []config.Nodepool, snapshots config.Snapshots, newFile *hclwrite.File, rootBody *hclwrite.Body, file *os.File, rbacRole config.Role) (*os.File, error) {
 	switch {
 	case terraformConfig.Module == modules.EC2RKE2 || terraformConfig.Module == modules.EC2K3s:
@@ -87,10 +86,7 @@ func SetRKE2K3s(client *rancher.Client, terraformConfig *config.TerraformConfig,
 	machineConfigBlockBody := machineConfigBlock.Body()
 
 	if psact == defaults.RancherBaseline {
-		dependsOnTemp := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte("[" + defaults.PodSecurityAdmission + "." +
-				terraformConfig.ResourcePrefix + "]")},
-		}
+		dependsOnTemp := format.ListOfReferences(format.Reference(defaults.PodSecurityAdmission, terraformConfig.ResourcePrefix))
 
 		machineConfigBlockBody.SetAttributeRaw(defaults.DependsOn, dependsOnTemp)
 	}
@@ -129,16 +125,12 @@ func SetRKE2K3s(client *rancher.Client, terraformConfig *config.TerraformConfig,
 	rkeConfigBlockBody := rkeConfigBlock.Body()
 
 	if terraformConfig.ChartValues != "" {
-		chartValues := hclwrite.TokensForTraversal(hcl.Traversal{
-			hcl.TraverseRoot{Name: "<<EOF\n" + terraformConfig.ChartValues + "\nEOF"},
-		})
+		chartValues := format.Heredoc(terraformConfig.ChartValues)
 
 		rkeConfigBlockBody.SetAttributeRaw(defaults.ChartValues, chartValues)
 	}
 
-	machineGlobalConfigValue := hclwrite.TokensForTraversal(hcl.Traversal{
-		hcl.TraverseRoot{Name: "<<EOF\ncni: " + terraformConfig.CNI + "\ndisable-kube-proxy: " + terraformConfig.DisableKubeProxy + "\nEOF"},
-	})
+	machineGlobalConfigValue := format.Heredoc("cni: " + terraformConfig.CNI + "\ndisable-kube-proxy: " + terraformConfig.DisableKubeProxy)
 
 	rkeConfigBlockBody.SetAttributeRaw(defaults.MachineGlobalConfig, machineGlobalConfigValue)
 
@@ -188,10 +180,12 @@ func SetRKE2K3s(client *rancher.Client, terraformConfig *config.TerraformConfig,
 
 		rootBody.AppendNewline()
 
+		cluster := format.Reference(clusterV2, terraformConfig.ResourcePrefix, clusterV1ID)
+
 		if strings.Contains(string(rbacRole), project) {
-			rbac.AddProjectMember(client, newFile, rootBody, nil, rbacRole, user, terraformConfig.ResourcePrefix, false)
+			rbac.AddProjectMember(newFile, rootBody, cluster, rbacRole, user)
 		} else {
-			rbac.AddClusterRole(client, newFile, rootBody, nil, rbacRole, user, terraformConfig.ResourcePrefix, false)
+			rbac.AddClusterRole(newFile, rootBody, cluster, rbacRole, user)
 		}
 	}
 
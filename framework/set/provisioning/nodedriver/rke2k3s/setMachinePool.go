@@ -3,9 +3,9 @@ package rke2k3s
 import (
 	"strconv"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/zclconf/go-cty/cty"
@@ -24,9 +24,7 @@ func setMachinePool(terraformConfig *config.TerraformConfig, count int, pool con
 
 	machinePoolsBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal("pool"+poolNum))
 
-	cloudCredSecretName := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + terraformConfig.ResourcePrefix + ".id")},
-	}
+	cloudCredSecretName := format.Reference(defaults.CloudCredential, terraformConfig.ResourcePrefix, defaults.ID)
 
 	machinePoolsBlockBody.SetAttributeRaw(cloudCredentialSecretName, cloudCredSecretName)
 	machinePoolsBlockBody.SetAttributeValue(controlPlaneRole, cty.BoolVal(pool.Controlplane))
@@ -37,15 +35,11 @@ func setMachinePool(terraformConfig *config.TerraformConfig, count int, pool con
 	machineConfigBlock := machinePoolsBlockBody.AppendNewBlock(defaults.MachineConfig, nil)
 	machineConfigBlockBody := machineConfigBlock.Body()
 
-	kind := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(machineConfigV2 + "." + terraformConfig.ResourcePrefix + ".kind")},
-	}
+	kind := format.Reference(machineConfigV2, terraformConfig.ResourcePrefix, defaults.ResourceKind)
 
 	machineConfigBlockBody.SetAttributeRaw(defaults.ResourceKind, kind)
 
-	name := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(machineConfigV2 + "." + terraformConfig.ResourcePrefix + ".name")},
-	}
+	name := format.Reference(machineConfigV2, terraformConfig.ResourcePrefix, defaults.ResourceName)
 
 	machineConfigBlockBody.SetAttributeRaw(defaults.ResourceName, name)
 
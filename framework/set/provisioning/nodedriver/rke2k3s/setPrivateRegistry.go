@@ -1,9 +1,9 @@
 package rke2k3s
 
 import (
-	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -13,9 +13,7 @@ func SetMachineSelectorConfig(rkeConfigBlockBody *hclwrite.Body, terraformConfig
 	machineSelectorBlock := rkeConfigBlockBody.AppendNewBlock(defaults.MachineSelectorConfig, nil)
 	machineSelectorBlockBody := machineSelectorBlock.Body()
 
-	registryValue := hclwrite.TokensForTraversal(hcl.Traversal{
-		hcl.TraverseRoot{Name: "<<EOF\n" + systemDefaultRegistry + ": " + terraformConfig.PrivateRegistries.SystemDefaultRegistry + "\nEOF"},
-	})
+	registryValue := format.Heredoc(systemDefaultRegistry + ": " + terraformConfig.PrivateRegistries.SystemDefaultRegistry)
 
 	machineSelectorBlockBody.SetAttributeRaw(defaults.Config, registryValue)
 
@@ -1,13 +1,12 @@
 package aws
 
 import (
-	"fmt"
-
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/amazon"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/tags"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -24,13 +23,11 @@ func SetAWSRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terraform
 	awsConfigBlockBody.SetAttributeValue(amazon.VolumeType, cty.StringVal(terraformConfig.AWSConfig.AWSVolumeType))
 	awsConfigBlockBody.SetAttributeValue(amazon.RootSize, cty.NumberIntVal(terraformConfig.AWSConfig.AWSRootSize))
 
-	awsSecGroupsExpression := fmt.Sprintf(`["%s"]`, terraformConfig.AWSConfig.AWSSecurityGroupNames[0])
-	awsSecGroupsList := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(awsSecGroupsExpression)},
-	}
+	awsSecGroupsList := format.ListOfStrings(terraformConfig.AWSConfig.AWSSecurityGroupNames[:1])
 	awsConfigBlockBody.SetAttributeRaw(amazon.SecurityGroup, awsSecGroupsList)
 
 	awsConfigBlockBody.SetAttributeValue(amazon.SubnetID, cty.StringVal(terraformConfig.AWSConfig.AWSSubnetID))
 	awsConfigBlockBody.SetAttributeValue(amazon.VPCID, cty.StringVal(terraformConfig.AWSConfig.AWSVpcID))
 	awsConfigBlockBody.SetAttributeValue(amazon.Zone, cty.StringVal(terraformConfig.AWSConfig.AWSZoneLetter))
+	awsConfigBlockBody.SetAttributeValue(amazon.Tags, cty.StringVal(tags.EC2(tags.Get(terraformConfig))))
 }
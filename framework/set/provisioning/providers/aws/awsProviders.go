@@ -1,13 +1,13 @@
 package aws
 
 import (
-	"fmt"
-
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/amazon"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/tags"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -17,8 +17,8 @@ func SetAWSRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig *c
 	awsConfigBlock := nodeTemplateBlockBody.AppendNewBlock(amazon.EC2Config, nil)
 	awsConfigBlockBody := awsConfigBlock.Body()
 
-	awsConfigBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
-	awsConfigBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
+	awsConfigBlockBody.SetAttributeRaw(defaults.AccessKey, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.AWSAccessKey)))
+	awsConfigBlockBody.SetAttributeRaw(defaults.SecretKey, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.AWSSecretKey)))
 	awsConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.AWSConfig.Region))
 
 	awsConfigBlockBody.SetAttributeValue(amazon.AMI, cty.StringVal(terraformConfig.AWSConfig.AMI))
@@ -27,15 +27,13 @@ func SetAWSRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig *c
 	awsConfigBlockBody.SetAttributeValue(amazon.VolumeType, cty.StringVal(terraformConfig.AWSConfig.AWSVolumeType))
 	awsConfigBlockBody.SetAttributeValue(amazon.RootSize, cty.NumberIntVal(terraformConfig.AWSConfig.AWSRootSize))
 
-	awsSecGroupsExpression := fmt.Sprintf(`["%s"]`, terraformConfig.AWSConfig.AWSSecurityGroupNames[0])
-	awsSecGroupsList := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(awsSecGroupsExpression)},
-	}
+	awsSecGroupsList := format.ListOfStrings(terraformConfig.AWSConfig.AWSSecurityGroupNames[:1])
 
 	awsConfigBlockBody.SetAttributeRaw(amazon.SecurityGroup, awsSecGroupsList)
 	awsConfigBlockBody.SetAttributeValue(amazon.SubnetID, cty.StringVal(terraformConfig.AWSConfig.AWSSubnetID))
 	awsConfigBlockBody.SetAttributeValue(amazon.VPCID, cty.StringVal(terraformConfig.AWSConfig.AWSVpcID))
 	awsConfigBlockBody.SetAttributeValue(amazon.Zone, cty.StringVal(terraformConfig.AWSConfig.AWSZoneLetter))
+	awsConfigBlockBody.SetAttributeValue(amazon.Tags, cty.StringVal(tags.EC2(tags.Get(terraformConfig))))
 }
 
 // SetAWSRKE2K3SProvider is a helper function that will set the AWS RKE2/K3S
@@ -49,6 +47,6 @@ func SetAWSRKE2K3SProvider(rootBody *hclwrite.Body, terraformConfig *config.Terr
 	awsCredBlock := cloudCredBlockBody.AppendNewBlock(amazon.EC2CredentialConfig, nil)
 	awsCredBlockBody := awsCredBlock.Body()
 
-	awsCredBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
-	awsCredBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
+	awsCredBlockBody.SetAttributeRaw(defaults.AccessKey, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.AWSAccessKey)))
+	awsCredBlockBody.SetAttributeRaw(defaults.SecretKey, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.AWSSecretKey)))
 }
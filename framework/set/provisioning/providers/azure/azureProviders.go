@@ -5,6 +5,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/azure"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -21,7 +22,7 @@ func SetAzureRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig
 
 	azureConfigBlockBody.SetAttributeValue(azure.AvailabilitySet, cty.StringVal(terraformConfig.AzureConfig.AvailabilitySet))
 	azureConfigBlockBody.SetAttributeValue(azure.ClientID, cty.StringVal(terraformConfig.AzureCredentials.ClientID))
-	azureConfigBlockBody.SetAttributeValue(azure.ClientSecret, cty.StringVal(terraformConfig.AzureCredentials.ClientSecret))
+	azureConfigBlockBody.SetAttributeRaw(azure.ClientSecret, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.AzureClientSecret)))
 	azureConfigBlockBody.SetAttributeValue(azure.SubscriptionID, cty.StringVal(terraformConfig.AzureCredentials.SubscriptionID))
 	azureConfigBlockBody.SetAttributeValue(azure.Environment, cty.StringVal(terraformConfig.AzureCredentials.Environment))
 	azureConfigBlockBody.SetAttributeValue(azure.CustomData, cty.StringVal(terraformConfig.AzureConfig.CustomData))
@@ -53,7 +54,7 @@ func SetAzureRKE2K3SProvider(rootBody *hclwrite.Body, terraformConfig *config.Te
 	azureCredBlockBody := azureCredBlock.Body()
 
 	azureCredBlockBody.SetAttributeValue(azure.ClientID, cty.StringVal(terraformConfig.AzureCredentials.ClientID))
-	azureCredBlockBody.SetAttributeValue(azure.ClientSecret, cty.StringVal(terraformConfig.AzureCredentials.ClientSecret))
+	azureCredBlockBody.SetAttributeRaw(azure.ClientSecret, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.AzureClientSecret)))
 	azureCredBlockBody.SetAttributeValue(azure.SubscriptionID, cty.StringVal(terraformConfig.AzureCredentials.SubscriptionID))
 	azureCredBlockBody.SetAttributeValue(azure.Environment, cty.StringVal(terraformConfig.AzureCredentials.Environment))
 	azureCredBlockBody.SetAttributeValue(azure.TenantID, cty.StringVal(terraformConfig.AzureCredentials.TenantID))
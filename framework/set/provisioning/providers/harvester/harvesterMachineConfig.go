@@ -1,10 +1,10 @@
 package harvester
 
 import (
-	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/harvester"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -17,9 +17,7 @@ func SetHarvesterRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, ter
 	harvesterConfigBlockBody.SetAttributeRaw(harvester.DiskInfo, constructDiskInfo(terraformConfig.HarvesterConfig.ImageName, terraformConfig.HarvesterConfig.DiskSize))
 
 	if terraformConfig.HarvesterConfig.UserData == "" {
-		harvesterConfigBlockBody.SetAttributeRaw(harvester.UserData, hclwrite.TokensForTraversal(hcl.Traversal{
-			hcl.TraverseRoot{Name: "<<EOT\n#cloud-config\npackage_update: true\npackages:\n  - qemu-guest-agent\nruncmd:\n  - - systemctl\n    - enable\n    - '--now'\n    - qemu-guest-agent.service\nEOT"},
-		}))
+		harvesterConfigBlockBody.SetAttributeRaw(harvester.UserData, format.Heredoc(defaultUserData))
 	}
 
 	harvesterConfigBlockBody.SetAttributeValue(harvester.CPUCount, cty.StringVal(terraformConfig.HarvesterConfig.CPUCount))
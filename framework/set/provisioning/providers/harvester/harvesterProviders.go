@@ -1,31 +1,31 @@
 package harvester
 
 import (
-	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/harvester"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/zclconf/go-cty/cty"
 )
 
+const (
+	defaultUserData = "#cloud-config\npackage_update: true\npackages:\n  - qemu-guest-agent\nruncmd:\n  - - systemctl\n    - enable\n    - '--now'\n    - qemu-guest-agent.service"
+)
+
 // SetHarvesterRKE1Provider is a helper function that will set the Harvester RKE1 terraform configurations in the main.tf file.
 func SetHarvesterRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	nodeTemplateBlockBody.SetAttributeValue("engine_install_url", cty.StringVal("https://releases.rancher.com/install-docker/26.1.sh"))
 
-	cloudCredID := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(defaults.CloudCredential + "." + terraformConfig.ResourcePrefix + ".id")},
-	}
+	cloudCredID := format.Reference(defaults.CloudCredential, terraformConfig.ResourcePrefix, defaults.ID)
 	nodeTemplateBlockBody.SetAttributeRaw(defaults.CloudCredentialID, cloudCredID)
 
 	harvesterConfigBlock := nodeTemplateBlockBody.AppendNewBlock(harvester.HarvesterConfig, nil)
 	harvesterConfigBlockBody := harvesterConfigBlock.Body()
 
 	if terraformConfig.HarvesterConfig.UserData == "" {
-		harvesterConfigBlockBody.SetAttributeRaw(harvester.UserData, hclwrite.TokensForTraversal(hcl.Traversal{
-			hcl.TraverseRoot{Name: "<<EOT\n#cloud-config\npackage_update: true\npackages:\n  - qemu-guest-agent\nruncmd:\n  - - systemctl\n    - enable\n    - '--now'\n    - qemu-guest-agent.service\nEOT"},
-		}))
+		harvesterConfigBlockBody.SetAttributeRaw(harvester.UserData, format.Heredoc(defaultUserData))
 	}
 	harvesterConfigBlockBody.SetAttributeRaw(harvester.NetworkInfo, constructNetworkInfo(terraformConfig.HarvesterConfig.NetworkNames))
 	harvesterConfigBlockBody.SetAttributeRaw(harvester.DiskInfo, constructDiskInfo(terraformConfig.HarvesterConfig.ImageName, terraformConfig.HarvesterConfig.DiskSize))
@@ -48,7 +48,7 @@ func SetHarvesterCredentialProvider(rootBody *hclwrite.Body, terraformConfig *co
 
 	harvesterCredBlockBody.SetAttributeValue(harvester.ClusterID, cty.StringVal(terraformConfig.HarvesterCredentials.ClusterID))
 	harvesterCredBlockBody.SetAttributeValue(harvester.ClusterType, cty.StringVal(terraformConfig.HarvesterCredentials.ClusterType))
-	harvesterCredBlockBody.SetAttributeValue(harvester.KubeconfigContent, cty.StringVal(terraformConfig.HarvesterCredentials.KubeconfigContent))
+	harvesterCredBlockBody.SetAttributeRaw(harvester.KubeconfigContent, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.HarvesterKubeconfig)))
 }
 
 func constructNetworkInfo(networkNames []string) hclwrite.Tokens {
@@ -58,9 +58,7 @@ func constructNetworkInfo(networkNames []string) hclwrite.Tokens {
 	}
 	netString = netString[:len(netString)-1]
 
-	return hclwrite.TokensForTraversal(hcl.Traversal{
-		hcl.TraverseRoot{Name: "<<EOF\n{\n\t\"interfaces\": [" + netString + "]\n}" + "\nEOF"},
-	})
+	return format.Heredoc("{\n\t\"interfaces\": [" + netString + "]\n}")
 }
 
 func constructDiskInfo(imageName, diskSize string) hclwrite.Tokens {
@@ -69,7 +67,5 @@ func constructDiskInfo(imageName, diskSize string) hclwrite.Tokens {
 	diskString += "\t\"bootOrder\": 1 \n\t"
 	diskString += "}"
 
-	return hclwrite.TokensForTraversal(hcl.Traversal{
-		hcl.TraverseRoot{Name: "<<EOF\n{\n\t\"disks\": [" + diskString + "]\n}" + "\nEOF"},
-	})
+	return format.Heredoc("{\n\t\"disks\": [" + diskString + "]\n}")
 }
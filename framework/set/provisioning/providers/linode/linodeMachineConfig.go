@@ -5,6 +5,8 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/linode"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/tags"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -16,5 +18,6 @@ func SetLinodeRKE2K3SMachineConfig(machineConfigBlockBody *hclwrite.Body, terraf
 
 	linodeConfigBlockBody.SetAttributeValue(linode.Image, cty.StringVal(terraformConfig.LinodeConfig.LinodeImage))
 	linodeConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.LinodeConfig.Region))
-	linodeConfigBlockBody.SetAttributeValue(linode.RootPass, cty.StringVal(terraformConfig.LinodeConfig.LinodeRootPass))
+	linodeConfigBlockBody.SetAttributeRaw(linode.RootPass, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.LinodeRootPass)))
+	linodeConfigBlockBody.SetAttributeValue(linode.Tags, cty.StringVal(tags.Linode(tags.Get(terraformConfig))))
 }
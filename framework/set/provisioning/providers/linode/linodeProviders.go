@@ -5,6 +5,8 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/resourceblocks/nodeproviders/linode"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/tags"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -14,11 +16,12 @@ func SetLinodeRKE1Provider(nodeTemplateBlockBody *hclwrite.Body, terraformConfig
 	linodeConfigBlock := nodeTemplateBlockBody.AppendNewBlock(linode.LinodeConfig, nil)
 	linodeConfigBlockBody := linodeConfigBlock.Body()
 
-	linodeConfigBlockBody.SetAttributeValue(linode.Token, cty.StringVal(terraformConfig.LinodeCredentials.LinodeToken))
+	linodeConfigBlockBody.SetAttributeRaw(linode.Token, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.LinodeToken)))
 
 	linodeConfigBlockBody.SetAttributeValue(linode.Image, cty.StringVal(terraformConfig.LinodeConfig.LinodeImage))
 	linodeConfigBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.LinodeConfig.Region))
-	linodeConfigBlockBody.SetAttributeValue(linode.RootPass, cty.StringVal(terraformConfig.LinodeConfig.LinodeRootPass))
+	linodeConfigBlockBody.SetAttributeRaw(linode.RootPass, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.LinodeRootPass)))
+	linodeConfigBlockBody.SetAttributeValue(linode.Tags, cty.StringVal(tags.Linode(tags.Get(terraformConfig))))
 }
 
 // SetLinodeRKE2K3SProvider is a helper function that will set the Linode RKE2/K3S
@@ -32,5 +35,5 @@ func SetLinodeRKE2K3SProvider(rootBody *hclwrite.Body, terraformConfig *config.T
 	linodeCredBlock := cloudCredBlockBody.AppendNewBlock(linode.LinodeCredentialConfig, nil)
 	linodeCredBlockBody := linodeCredBlock.Body()
 
-	linodeCredBlockBody.SetAttributeValue(linode.Token, cty.StringVal(terraformConfig.LinodeCredentials.LinodeToken))
+	linodeCredBlockBody.SetAttributeRaw(linode.Token, variables.Reference(variables.Name(terraformConfig.ResourcePrefix, variables.LinodeToken)))
 }
@@ -1,11 +1,9 @@
 package rbac
 
 import (
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
-	"github.com/rancher/shepherd/clients/rancher"
-	"github.com/rancher/shepherd/extensions/clusters"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -13,7 +11,6 @@ import (
 
 const (
 	project = "rancher2_project"
-	cluster = "rancher2_cluster_v2"
 
 	clusterRoleTemplateBinding = "rancher2_cluster_role_template_binding"
 	projectRoleTemplateBinding = "rancher2_project_role_template_binding"
@@ -26,33 +23,16 @@ const (
 	roleTemplateID                 = "role_template_id"
 )
 
-// AddProjectMember is a helper function that will add the RBAC project member to `user` in the main.tf file.
-func AddProjectMember(client *rancher.Client, newFile *hclwrite.File, rootBody *hclwrite.Body,
-	clusterBlockID hclwrite.Tokens, rbacRole config.Role, newUser, clusterName string, isRKE1 bool) (*hclwrite.File, *hclwrite.Body) {
-	projectBlock := rootBody.AppendNewBlock(defaults.Resource, []string{project, project})
-	projectBlockBody := projectBlock.Body()
-
-	projectBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(projectName))
-
-	if isRKE1 {
-		projectBlockBody.SetAttributeRaw(clusterID, clusterBlockID)
-	} else {
-		clusterBlockID, err := clusters.GetClusterIDByName(client, clusterName)
-		if err != nil {
-			return newFile, rootBody
-		}
-
-		projectBlockBody.SetAttributeValue(clusterID, cty.StringVal(clusterBlockID))
-	}
-
-	rootBody.AppendNewline()
+// AddProjectMember is a helper function that will add the RBAC project member to `user` in the main.tf file. The
+// project is created in the cluster the clusterBlockID expression refers to.
+func AddProjectMember(newFile *hclwrite.File, rootBody *hclwrite.Body, clusterBlockID hclwrite.Tokens, rbacRole config.Role,
+	newUser string) (*hclwrite.File, *hclwrite.Body) {
+	SetProject(rootBody, project, projectName, clusterBlockID)
 
 	projectRoleTemplateBindingBlock := rootBody.AppendNewBlock(defaults.Resource, []string{projectRoleTemplateBinding, projectRoleTemplateBinding})
 	projectRoleTemplateBindingBody := projectRoleTemplateBindingBlock.Body()
 
-	projectBlockID := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(project + "." + project + ".id")},
-	}
+	projectBlockID := format.Reference(project, project, defaults.ID)
 
 	projectRoleTemplateBindingBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(projectRoleTemplateBindingName))
 	projectRoleTemplateBindingBody.SetAttributeRaw(projectID, projectBlockID)
@@ -64,22 +44,27 @@ func AddProjectMember(client *rancher.Client, newFile *hclwrite.File, rootBody *
 	return newFile, rootBody
 }
 
-// AddClusterRole is a helper function that will add the RBAC cluster role to non `user` member in the main.tf file.
-func AddClusterRole(client *rancher.Client, newFile *hclwrite.File, rootBody *hclwrite.Body, clusterBlockID hclwrite.Tokens,
-	rbacRole config.Role, newUser, clusterName string, isRKE1 bool) (*hclwrite.File, *hclwrite.Body) {
+// SetProject is a helper function that will set a rancher2_project, labelled with the given resource name, in the main.tf
+// file. The project is created in the cluster the clusterBlockID expression refers to.
+func SetProject(rootBody *hclwrite.Body, resourceName, projectName string, clusterBlockID hclwrite.Tokens) {
+	projectBlock := rootBody.AppendNewBlock(defaults.Resource, []string{project, resourceName})
+	projectBlockBody := projectBlock.Body()
+
+	projectBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(projectName))
+
+	projectBlockBody.SetAttributeRaw(clusterID, clusterBlockID)
+
+	rootBody.AppendNewline()
+}
+
+// AddClusterRole is a helper function that will add the RBAC cluster role to non `user` member in the main.tf file. The
+// role is bound in the cluster the clusterBlockID expression refers to.
+func AddClusterRole(newFile *hclwrite.File, rootBody *hclwrite.Body, clusterBlockID hclwrite.Tokens, rbacRole config.Role,
+	newUser string) (*hclwrite.File, *hclwrite.Body) {
 	clusterRoleTemplateBindingBlock := rootBody.AppendNewBlock(defaults.Resource, []string{clusterRoleTemplateBinding, clusterRoleTemplateBinding})
 	clusterRoleTemplateBindingBlockBody := clusterRoleTemplateBindingBlock.Body()
 
-	if isRKE1 {
-		clusterRoleTemplateBindingBlockBody.SetAttributeRaw(clusterID, clusterBlockID)
-	} else {
-		clusterBlockID, err := clusters.GetClusterIDByName(client, clusterName)
-		if err != nil {
-			return newFile, rootBody
-		}
-
-		clusterRoleTemplateBindingBlockBody.SetAttributeValue(clusterID, cty.StringVal(clusterBlockID))
-	}
+	clusterRoleTemplateBindingBlockBody.SetAttributeRaw(clusterID, clusterBlockID)
 
 	clusterRoleTemplateBindingBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(clusterRoleTemplateBindingName))
 	clusterRoleTemplateBindingBlockBody.SetAttributeValue(roleTemplateID, cty.StringVal(string(rbacRole)))
@@ -1,11 +1,11 @@
 package rbac
 
 import (
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	password "github.com/rancher/shepherd/extensions/users/passwordgenerator"
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -53,9 +53,7 @@ func SetUsers(newFile *hclwrite.File, rootBody *hclwrite.Body, rbacRole config.R
 	globalRoleBindingBlockBody.SetAttributeValue(name, cty.StringVal(testuser))
 	globalRoleBindingBlockBody.SetAttributeValue(globalRoleID, cty.StringVal(user))
 
-	user := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(rancherUser + "." + testuser + ".id")},
-	}
+	user := format.Reference(rancherUser, testuser, defaults.ID)
 
 	globalRoleBindingBlockBody.SetAttributeRaw(userID, user)
 
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"os"
+	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
@@ -23,7 +24,11 @@ const (
 // CreateAWSResources is a helper function that will create the AWS resources needed for the RKE2 cluster.
 func CreateAWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig) (*os.File, error) {
-	sanity.CreateTerraformProviderBlock(tfBlockBody)
+	err := sanity.CreateTerraformProviderBlock(tfBlockBody, terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	rootBody.AppendNewline()
 
 	sanity.CreateAWSProviderBlock(rootBody, terraformConfig)
@@ -79,12 +84,17 @@ func CreateAWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody, root
 	sanity.CreateRoute53InternalRecord(rootBody, terraformConfig)
 	rootBody.AppendNewline()
 
-	_, err := file.Write(newFile.Bytes())
+	_, err = file.Write(newFile.Bytes())
 	if err != nil {
 		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
 		return nil, err
 	}
 
+	err = sanity.WriteAWSProviderVariables(filepath.Dir(file.Name()), newFile.Bytes(), terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return file, err
 }
 
@@ -1,12 +1,11 @@
 package aws
 
 import (
-	"fmt"
-
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/tags"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -20,10 +19,7 @@ func CreateAirgappedAWSInstances(rootBody *hclwrite.Body, terraformConfig *confi
 	configBlockBody.SetAttributeValue(defaults.InstanceType, cty.StringVal(terraformConfig.AWSConfig.AWSInstanceType))
 	configBlockBody.SetAttributeValue(defaults.SubnetId, cty.StringVal(terraformConfig.AWSConfig.AWSSubnetID))
 
-	awsSecGroupsExpression := fmt.Sprintf(`["%s"]`, terraformConfig.AWSConfig.AWSSecurityGroups[0])
-	awsSecGroupsList := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(awsSecGroupsExpression)},
-	}
+	awsSecGroupsList := format.ListOfStrings(terraformConfig.AWSConfig.AWSSecurityGroups[:1])
 
 	configBlockBody.SetAttributeRaw(defaults.VpcSecurityGroupIds, awsSecGroupsList)
 	configBlockBody.SetAttributeValue(defaults.KeyName, cty.StringVal(terraformConfig.AWSConfig.AWSKeyName))
@@ -39,12 +35,10 @@ func CreateAirgappedAWSInstances(rootBody *hclwrite.Body, terraformConfig *confi
 	tagsBlock := configBlockBody.AppendNewBlock(defaults.Tags+" =", nil)
 	tagsBlockBody := tagsBlock.Body()
 
-	expression := fmt.Sprintf(`"%s`, terraformConfig.ResourcePrefix+"-"+hostnamePrefix+`"`)
-	tags := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
-	}
+	nameTag := hclwrite.TokensForValue(cty.StringVal(terraformConfig.ResourcePrefix + "-" + hostnamePrefix))
 
-	tagsBlockBody.SetAttributeRaw(defaults.Name, tags)
+	tagsBlockBody.SetAttributeRaw(defaults.Name, nameTag)
+	tags.SetBlock(tagsBlockBody, tags.Get(terraformConfig))
 
 	configBlockBody.AppendNewline()
 
@@ -54,17 +48,11 @@ func CreateAirgappedAWSInstances(rootBody *hclwrite.Body, terraformConfig *confi
 	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.Ssh))
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.AWSUser))
 
-	hostExpression := defaults.Self + "." + defaults.PrivateIp
-	host := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(hostExpression)},
-	}
+	host := format.Reference(defaults.Self, defaults.PrivateIp)
 
 	connectionBlockBody.SetAttributeRaw(defaults.Host, host)
 
-	keyPathExpression := defaults.File + `("` + terraformConfig.PrivateKeyPath + `")`
-	keyPath := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(keyPathExpression)},
-	}
+	keyPath := format.File(terraformConfig.PrivateKeyPath)
 
 	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, keyPath)
 	connectionBlockBody.SetAttributeValue(defaults.Timeout, cty.StringVal(terraformConfig.AWSConfig.Timeout))
@@ -12,6 +12,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/airgap/rancher"
 	"github.com/rancher/tfp-automation/framework/set/resources/airgap/rke2"
 	registry "github.com/rancher/tfp-automation/framework/set/resources/registries/createRegistry"
+	"github.com/rancher/tfp-automation/framework/set/tags"
 	"github.com/sirupsen/logrus"
 )
 
@@ -34,6 +35,8 @@ const (
 // CreateMainTF is a helper function that will create the main.tf file for creating an Airgapped-Rancher server.
 func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig) (string, string, error) {
+	terraformConfig = tags.WithTestName(terraformConfig, t.Name())
+
 	var file *os.File
 	file = OpenFile(file, keyPath)
 	defer file.Close()
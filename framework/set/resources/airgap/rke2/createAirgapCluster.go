@@ -5,10 +5,10 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/sirupsen/logrus"
@@ -101,10 +101,7 @@ func createAirgappedRKE2Server(rootBody *hclwrite.Body, terraformConfig *config.
 		cty.StringVal(command),
 	}))
 
-	dependsOnServer := `[` + defaults.NullResource + `.` + rke2Bastion + `]`
-	server := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnServer)},
-	}
+	server := format.ListOfReferences(format.Reference(defaults.NullResource, rke2Bastion))
 
 	nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, server)
 }
@@ -135,10 +132,7 @@ func addAirgappedRKE2ServerNodes(rootBody *hclwrite.Body, terraformConfig *confi
 			cty.StringVal(command),
 		}))
 
-		dependsOnServer := `[` + defaults.NullResource + `.` + rke2ServerOne + `]`
-		server := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnServer)},
-		}
+		server := format.ListOfReferences(format.Reference(defaults.NullResource, rke2ServerOne))
 
 		nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, server)
 	}
@@ -4,11 +4,11 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -25,7 +25,7 @@ const (
 
 // CreateRKE2K3SImportedCluster is a helper function that will create the RKE2/K3S cluster to be imported into Rancher.
 func CreateRKE2K3SImportedCluster(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, serverOnePublicDNS, serverOnePrivateIP,
-	serverTwoPublicDNS, serverThreePublicDNS string) {
+	serverTwoPublicDNS, serverThreePublicDNS hclwrite.Tokens) {
 	userDir, err := os.UserHomeDir()
 	if err != nil {
 		return
@@ -58,7 +58,7 @@ func CreateRKE2K3SImportedCluster(rootBody *hclwrite.Body, terraformConfig *conf
 }
 
 // CreateImportedNullResource is a helper function that will create the null_resource for the cluster.
-func CreateImportedNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, publicDNS, resourceName string) (*hclwrite.Body, *hclwrite.Body) {
+func CreateImportedNullResource(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, publicDNS hclwrite.Tokens, resourceName string) (*hclwrite.Body, *hclwrite.Body) {
 	nullResourceBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.NullResource, resourceName})
 	nullResourceBlockBody := nullResourceBlock.Body()
 
@@ -68,32 +68,22 @@ func CreateImportedNullResource(rootBody *hclwrite.Body, terraformConfig *config
 	connectionBlock := provisionerBlockBody.AppendNewBlock(defaults.Connection, nil)
 	connectionBlockBody := connectionBlock.Body()
 
-	hostExpression := `"` + publicDNS + `"`
-
-	newHost := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(hostExpression)},
-	}
-
-	connectionBlockBody.SetAttributeRaw(defaults.Host, newHost)
+	connectionBlockBody.SetAttributeRaw(defaults.Host, publicDNS)
 
 	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.Ssh))
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.AWSUser))
 
-	keyPathExpression := defaults.File + `("` + terraformConfig.PrivateKeyPath + `")`
-	keyPath := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(keyPathExpression)},
-	}
-
-	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, keyPath)
+	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, format.File(terraformConfig.PrivateKeyPath))
 
 	serverOneName := terraformConfig.ResourcePrefix + `_` + serverOne
 	serverTwoName := terraformConfig.ResourcePrefix + `_` + serverTwo
 	serverThreeName := terraformConfig.ResourcePrefix + `_` + serverThree
 
-	dependsOnServer := `[` + defaults.AwsInstance + `.` + serverOneName + `, ` + defaults.AwsInstance + `.` + serverTwoName + `, ` + defaults.AwsInstance + `.` + serverThreeName + `]`
-	server := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnServer)},
-	}
+	server := format.ListOfReferences(
+		format.Reference(defaults.AwsInstance, serverOneName),
+		format.Reference(defaults.AwsInstance, serverTwoName),
+		format.Reference(defaults.AwsInstance, serverThreeName),
+	)
 
 	nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, server)
 
@@ -103,7 +93,7 @@ func CreateImportedNullResource(rootBody *hclwrite.Body, terraformConfig *config
 }
 
 // createImportedRKE2K3SServer is a helper function that will create the server to be imported into Rancher.
-func createImportedRKE2K3SServer(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, serverOnePublicDNS, serverOnePrivateIP,
+func createImportedRKE2K3SServer(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, serverOnePublicDNS, serverOnePrivateIP hclwrite.Tokens,
 	token string, script []byte) {
 	copyScriptName := terraformConfig.ResourcePrefix + copyScript + serverOne
 	_, provisionerBlockBody := CreateImportedNullResource(rootBody, terraformConfig, serverOnePublicDNS, copyScriptName)
@@ -116,8 +106,11 @@ func createImportedRKE2K3SServer(rootBody *hclwrite.Body, terraformConfig *confi
 		version = terraformConfig.Standalone.RKE2Version
 	}
 
-	command := "bash -c '/tmp/init-server.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
-		version + " " + serverOnePrivateIP + " " + token + "'"
+	command := format.String(
+		format.Literal("bash -c '/tmp/init-server.sh "+terraformConfig.Standalone.OSUser+" "+terraformConfig.Standalone.OSGroup+" "+version+" "),
+		format.Interpolate(serverOnePrivateIP),
+		format.Literal(" "+token+"'"),
+	)
 
 	// For imported clusters, need to first put the script on the machine before running it.
 	provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
@@ -128,25 +121,16 @@ func createImportedRKE2K3SServer(rootBody *hclwrite.Body, terraformConfig *confi
 	createClusterName := terraformConfig.ResourcePrefix + `_` + createCluster
 	nullResourceBlockBody, provisionerBlockBody := CreateImportedNullResource(rootBody, terraformConfig, serverOnePublicDNS, createClusterName)
 
-	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.Tokens{
-		{Type: hclsyntax.TokenOQuote, Bytes: []byte(`["`), SpacesBefore: 1},
-		{Type: hclsyntax.TokenStringLit, Bytes: []byte(command)},
-		{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"]`), SpacesBefore: 1},
-	})
-
-	dependsOnServer := `[` + defaults.NullResource + `.` + copyScriptName + `]`
-
-	server := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnServer)},
-	}
+	provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.TokensForTuple([]hclwrite.Tokens{command}))
 
+	server := format.ListOfReferences(format.Reference(defaults.NullResource, copyScriptName))
 	nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, server)
 }
 
 // addImportedServerNodes is a helper function that will add additional server nodes to the initial server.
 func addImportedRKE2K3SServerNodes(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig, serverOnePrivateIP, serverTwoPublicDNS,
-	serverThreePublicDNS, token string, script []byte) {
-	dnsList := []string{serverTwoPublicDNS, serverThreePublicDNS}
+	serverThreePublicDNS hclwrite.Tokens, token string, script []byte) {
+	dnsList := []hclwrite.Tokens{serverTwoPublicDNS, serverThreePublicDNS}
 
 	createClusterName := terraformConfig.ResourcePrefix + `_` + createCluster
 	resourceNames := []string{serverTwo, serverThree}
@@ -162,36 +146,26 @@ func addImportedRKE2K3SServerNodes(rootBody *hclwrite.Body, terraformConfig *con
 			version = terraformConfig.Standalone.RKE2Version
 		}
 
-		command := "bash -c '/tmp/add-servers.sh " + terraformConfig.Standalone.OSUser + " " + terraformConfig.Standalone.OSGroup + " " +
-			version + " " + serverOnePrivateIP + " " + token + "'"
+		command := format.String(
+			format.Literal("bash -c '/tmp/add-servers.sh "+terraformConfig.Standalone.OSUser+" "+terraformConfig.Standalone.OSGroup+" "+version+" "),
+			format.Interpolate(serverOnePrivateIP),
+			format.Literal(" "+token+"'"),
+		)
 
 		provisionerBlockBody.SetAttributeValue(defaults.Inline, cty.ListVal([]cty.Value{
 			cty.StringVal("echo '" + string(script) + "' > /tmp/add-servers.sh"),
 			cty.StringVal("chmod +x /tmp/add-servers.sh"),
 		}))
 
-		dependsOnServer := `[` + defaults.NullResource + `.` + createClusterName + `]`
-		server := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnServer)},
-		}
-
+		server := format.ListOfReferences(format.Reference(defaults.NullResource, createClusterName))
 		nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, server)
 
 		nullResourceBlockBody, provisionerBlockBody = CreateImportedNullResource(rootBody, terraformConfig, dns, addServer+"_"+resourceName)
 
-		provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.Tokens{
-			{Type: hclsyntax.TokenOQuote, Bytes: []byte(`["`), SpacesBefore: 1},
-			{Type: hclsyntax.TokenStringLit, Bytes: []byte(command)},
-			{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"]`), SpacesBefore: 1},
-		})
+		provisionerBlockBody.SetAttributeRaw(defaults.Inline, hclwrite.TokensForTuple([]hclwrite.Tokens{command}))
 
 		importClusterName := terraformConfig.ResourcePrefix + `_` + resourceNames[i]
-		dependsOnServer = `[` + defaults.NullResource + `.` + importClusterName + `]`
-
-		server = hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnServer)},
-		}
-
+		server = format.ListOfReferences(format.Reference(defaults.NullResource, importClusterName))
 		nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, server)
 	}
 }
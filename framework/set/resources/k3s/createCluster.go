@@ -4,10 +4,10 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/sirupsen/logrus"
@@ -90,10 +90,7 @@ func AddK3SServerNodes(rootBody *hclwrite.Body, terraformConfig *config.Terrafor
 			cty.StringVal(command),
 		}))
 
-		dependsOnServer := `[` + defaults.NullResource + `.` + k3sServerOne + `]`
-		server := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnServer)},
-		}
+		server := format.ListOfReferences(format.Reference(defaults.NullResource, k3sServerOne))
 
 		nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, server)
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/proxy/squid"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/aws"
+	"github.com/rancher/tfp-automation/framework/set/tags"
 	"github.com/sirupsen/logrus"
 )
 
@@ -33,6 +34,8 @@ const (
 // CreateMainTF is a helper function that will create the main.tf file for creating a Rancher server behind a proxy.
 func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, terraformConfig *config.TerraformConfig,
 	terratest *config.TerratestConfig) (string, string, error) {
+	terraformConfig = tags.WithTestName(terraformConfig, t.Name())
+
 	var file *os.File
 	file = sanity.OpenFile(file, keyPath)
 	defer file.Close()
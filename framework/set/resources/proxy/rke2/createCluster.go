@@ -4,10 +4,10 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	namegen "github.com/rancher/shepherd/pkg/namegenerator"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	sanity "github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/sirupsen/logrus"
@@ -92,10 +92,7 @@ func addRKE2ServerNodes(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 			cty.StringVal(command),
 		}))
 
-		dependsOnServer := `[` + defaults.NullResource + `.` + rke2ServerOne + `]`
-		server := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(dependsOnServer)},
-		}
+		server := format.ListOfReferences(format.Reference(defaults.NullResource, rke2ServerOne))
 
 		nullResourceBlockBody.SetAttributeRaw(defaults.DependsOn, server)
 	}
@@ -1,10 +1,8 @@
 package rancher2
 
 import (
-	"strings"
-
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -22,41 +20,49 @@ const (
 	warn           = "warn"
 	warnVersion    = "warn_version"
 
-	baselineDescription = "This is a custom baseline Pod Security Admission Configuration Template." +
+	// BaselineDescription is the description of the baseline PSACT.
+	BaselineDescription = "This is a custom baseline Pod Security Admission Configuration Template." +
 		"It defines a minimally restrictive policy which prevents known privilege escalations. " +
 		"This policy contains namespace level exemptions for Rancher components."
 )
 
-// SetCustomPSACT is a function that will set the Custom PSACT configurations in the main.tf file.
+// BaselineExemptionNamespaces are the namespaces exempted from the baseline PSACT.
+var BaselineExemptionNamespaces = []string{
+	"ingress-nginx",
+	"kube-system",
+	"cattle-system",
+	"cattle-epinio-system",
+	"cattle-fleet-system",
+	"longhorn-system",
+	"cattle-neuvector-system",
+	"cattle-monitoring-system",
+	"rancher-alerting-drivers",
+	"cis-operator-system",
+	"cattle-csp-adapter-system",
+	"cattle-externalip-system",
+	"cattle-gatekeeper-system",
+	"istio-system",
+	"cattle-istio-system",
+	"cattle-logging-system",
+	"cattle-windows-gmsa-system",
+	"cattle-sriov-system",
+	"cattle-ui-plugin-system",
+	"tigera-operator",
+}
+
+// SetBaselinePSACT is a function that will set the Custom PSACT configurations in the main.tf file.
 func SetBaselinePSACT(newFile *hclwrite.File, rootBody *hclwrite.Body, clusterName string) (*hclwrite.File, *hclwrite.Body) {
-	exemptionsNamespaces := []string{
-		"ingress-nginx",
-		"kube-system",
-		"cattle-system",
-		"cattle-epinio-system",
-		"cattle-fleet-system",
-		"longhorn-system",
-		"cattle-neuvector-system",
-		"cattle-monitoring-system",
-		"rancher-alerting-drivers",
-		"cis-operator-system",
-		"cattle-csp-adapter-system",
-		"cattle-externalip-system",
-		"cattle-gatekeeper-system",
-		"istio-system",
-		"cattle-istio-system",
-		"cattle-logging-system",
-		"cattle-windows-gmsa-system",
-		"cattle-sriov-system",
-		"cattle-ui-plugin-system",
-		"tigera-operator",
-	}
+	return SetPSACT(newFile, rootBody, clusterName, defaults.RancherBaseline)
+}
 
-	psactBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.PodSecurityAdmission, clusterName})
+// SetPSACT is a function that will set a baseline PSACT with the given name, labelled with the given resource name, in
+// the main.tf file.
+func SetPSACT(newFile *hclwrite.File, rootBody *hclwrite.Body, resourceName, psactName string) (*hclwrite.File, *hclwrite.Body) {
+	psactBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.PodSecurityAdmission, resourceName})
 	psactBlockBody := psactBlock.Body()
 
-	psactBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(defaults.RancherBaseline))
-	psactBlockBody.SetAttributeValue(description, cty.StringVal(baselineDescription))
+	psactBlockBody.SetAttributeValue(defaults.ResourceName, cty.StringVal(psactName))
+	psactBlockBody.SetAttributeValue(description, cty.StringVal(BaselineDescription))
 
 	defaultsBlock := psactBlockBody.AppendNewBlock(defaults.Defaults, nil)
 	defaultsBlockBody := defaultsBlock.Body()
@@ -71,10 +77,7 @@ func SetBaselinePSACT(newFile *hclwrite.File, rootBody *hclwrite.Body, clusterNa
 	exemptionsBlock := psactBlockBody.AppendNewBlock(exemptions, nil)
 	exemptionsBlockBody := exemptionsBlock.Body()
 
-	namespacesStr := "\"" + strings.Join(exemptionsNamespaces, "\", \"") + "\""
-	namespaces := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte("[" + namespacesStr + "]")},
-	}
+	namespaces := format.ListOfStrings(BaselineExemptionNamespaces)
 
 	exemptionsBlockBody.SetAttributeRaw(namespace, namespaces)
 
@@ -1,123 +1,158 @@
 package rancher2
 
 import (
-	"os"
-	"strings"
+	"errors"
+	"slices"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/binaries"
 	"github.com/rancher/tfp-automation/defaults/configs"
-	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework/backend"
+	"github.com/rancher/tfp-automation/framework/format"
+	"github.com/rancher/tfp-automation/framework/providers"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/tfp-automation/framework/set/generators"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/zclconf/go-cty/cty"
 )
 
 const (
-	apiURL              = "api_url"
-	globalRoleBinding   = "rancher2_global_role_binding"
-	globalRoleID        = "global_role_id"
-	insecure            = "insecure"
-	name                = "name"
-	provider            = "provider"
-	rancher2            = "rancher2"
-	rancherRKE          = "rancher/rke"
-	rancherSource       = "source"
-	rancherUser         = "rancher2_user"
-	rc                  = "-rc"
-	requiredProviders   = "required_providers"
-	terraform           = "terraform"
-	testPassword        = "password"
-	tokenKey            = "token_key"
-	version             = "version"
-	user                = "user"
-	userID              = "user_id"
-	username            = "username"
-	providerEnvVar      = "RANCHER2_PROVIDER_VERSION"
-	awsProviderEnvVar   = "AWS_PROVIDER_VERSION"
-	localProviderEnvVar = "LOCALS_PROVIDER_VERSION"
-	rkeEnvVar           = "RKE_PROVIDER_VERSION"
+	apiURL            = "api_url"
+	globalRoleBinding = "rancher2_global_role_binding"
+	globalRoleID      = "global_role_id"
+	insecure          = "insecure"
+	name              = "name"
+	provider          = "provider"
+	rancher2          = "rancher2"
+	rancherUser       = "rancher2_user"
+	requiredProviders = "required_providers"
+	terraform         = "terraform"
+	testPassword      = "password"
+	tokenKey          = "token_key"
+	user              = "user"
+	userID            = "user_id"
+	username          = "username"
 )
 
 // SetProvidersAndUsersTF is a helper function that will set the general Terraform configurations in the main.tf file.
-func SetProvidersAndUsersTF(testUser, testPassword string, authProvider bool, configMap []map[string]any) (*hclwrite.File, *hclwrite.Body) {
+func SetProvidersAndUsersTF(testUser, testPassword string, authProvider bool, configMap []map[string]any) (*hclwrite.File, *hclwrite.Body, error) {
 	newFile := hclwrite.NewEmptyFile()
 	rootBody := newFile.Body()
 
-	createRequiredProviders(rootBody, configMap)
+	err := setProviders(rootBody, configMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	setUsers(rootBody, testUser, testPassword, authProvider)
+
+	return newFile, rootBody, nil
+}
+
+// SetProvidersTF is a helper function that will set the required_providers block, the backend block and the provider
+// blocks in the providers.tf file.
+func SetProvidersTF(configMap []map[string]any) (*hclwrite.File, error) {
+	newFile := hclwrite.NewEmptyFile()
+
+	err := setProviders(newFile.Body(), configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFile, nil
+}
+
+// SetUsersTF is a helper function that will set the test user and its global role binding in the users.tf file.
+func SetUsersTF(testUser, testPassword string, authProvider bool) *hclwrite.File {
+	newFile := hclwrite.NewEmptyFile()
+
+	setUsers(newFile.Body(), testUser, testPassword, authProvider)
+
+	return newFile
+}
+
+// setProviders sets the required_providers block, the backend block of the first config and the provider blocks for
+// the given configs.
+func setProviders(rootBody *hclwrite.Body, configMap []map[string]any) error {
+	required, err := getRequiredProviders(configMap)
+	if err != nil {
+		return err
+	}
+
+	terraformConfig := new(config.TerraformConfig)
+	if len(configMap) > 0 {
+		operations.LoadObjectFromMap(config.TerraformConfigurationFileKey, configMap[0], terraformConfig)
+	}
+
+	err = createRequiredProviders(rootBody, required, terraformConfig.Backend)
+	if err != nil {
+		return err
+	}
 
 	rootBody.AppendNewline()
 
-	createProvider(rootBody, configMap)
+	createProvider(rootBody, required, configMap)
 
+	return nil
+}
+
+// setUsers sets the test user and, unless the user is set up by an auth provider, its global role binding.
+func setUsers(rootBody *hclwrite.Body, testUser, testPassword string, authProvider bool) {
 	createUser(rootBody, testUser, testPassword)
 
 	if !authProvider {
 		createGlobalRoleBinding(rootBody, testUser, userID)
 	}
-
-	return newFile, rootBody
 }
 
-// createRequiredProviders creates the required_providers block.
-func createRequiredProviders(rootBody *hclwrite.Body, configMap []map[string]any) {
+// createRequiredProviders creates the required_providers block and, when a remote state backend is set, the backend
+// block.
+func createRequiredProviders(rootBody *hclwrite.Body, required []providers.Provider, stateBackend *config.Backend) error {
 	tfBlock := rootBody.AppendNewBlock(terraform, nil)
 	tfBlockBody := tfBlock.Body()
 
 	reqProvsBlock := tfBlockBody.AppendNewBlock(requiredProviders, nil)
 	reqProvsBlockBody := reqProvsBlock.Body()
 
-	source, rancherProviderVersion, awsProviderVersion, localProviderVersion, rkeProviderVersion := getRequiredProviderVersions(configMap)
-
-	if rancherProviderVersion != "" {
-		reqProvsBlockBody.SetAttributeValue(rancher2, cty.ObjectVal(map[string]cty.Value{
-			rancherSource: cty.StringVal(source),
-			version:       cty.StringVal(rancherProviderVersion),
-		}))
+	binary, err := binaries.Binary()
+	if err != nil {
+		return err
 	}
 
-	if awsProviderVersion != "" {
-		reqProvsBlockBody.SetAttributeValue(defaults.Aws, cty.ObjectVal(map[string]cty.Value{
-			defaults.Source:  cty.StringVal(defaults.AwsSource),
-			defaults.Version: cty.StringVal(awsProviderVersion),
+	for _, requiredProvider := range required {
+		reqProvsBlockBody.SetAttributeValue(requiredProvider.Name, cty.ObjectVal(map[string]cty.Value{
+			defaults.Source:  cty.StringVal(requiredProvider.RequiredSource(binary)),
+			defaults.Version: cty.StringVal(requiredProvider.Version),
 		}))
 	}
 
-	if localProviderVersion != "" {
-		reqProvsBlockBody.SetAttributeValue(defaults.Local, cty.ObjectVal(map[string]cty.Value{
-			defaults.Source:  cty.StringVal(defaults.LocalSource),
-			defaults.Version: cty.StringVal(localProviderVersion),
-		}))
-	}
+	backend.SetBlock(tfBlockBody, stateBackend)
 
-	if rkeProviderVersion != "" {
-		reqProvsBlockBody.SetAttributeValue(defaults.RKE, cty.ObjectVal(map[string]cty.Value{
-			defaults.Source:  cty.StringVal(rancherRKE),
-			defaults.Version: cty.StringVal(rkeProviderVersion),
-		}))
-	}
+	return nil
 }
 
 // createProvider creates a provider block for the given rancher config.
-func createProvider(rootBody *hclwrite.Body, configMap []map[string]any) {
-	_, _, awsProviderVersion, _, _ := getRequiredProviderVersions(configMap)
-
+func createProvider(rootBody *hclwrite.Body, required []providers.Provider, configMap []map[string]any) {
 	terraformConfig := new(config.TerraformConfig)
 	operations.LoadObjectFromMap(config.TerraformConfigurationFileKey, configMap[0], terraformConfig)
 
 	rancherConfig := new(rancher.Config)
 	operations.LoadObjectFromMap(configs.Rancher, configMap[0], rancherConfig)
 
-	if awsProviderVersion != "" {
+	requiresAWS := slices.ContainsFunc(required, func(requiredProvider providers.Provider) bool {
+		return requiredProvider.Name == defaults.Aws
+	})
+
+	if requiresAWS {
 		awsProvBlock := rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Aws})
 		awsProvBlockBody := awsProvBlock.Body()
 
 		awsProvBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.AWSConfig.Region))
-		awsProvBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
-		awsProvBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
+		awsProvBlockBody.SetAttributeRaw(defaults.AccessKey, variables.Reference(variables.AWSAccessKey))
+		awsProvBlockBody.SetAttributeRaw(defaults.SecretKey, variables.Reference(variables.AWSSecretKey))
 
 		rootBody.AppendNewline()
 		rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Local})
@@ -128,7 +163,7 @@ func createProvider(rootBody *hclwrite.Body, configMap []map[string]any) {
 	rancher2ProvBlockBody := rancher2ProvBlock.Body()
 
 	rancher2ProvBlockBody.SetAttributeValue(apiURL, cty.StringVal("https://"+rancherConfig.Host))
-	rancher2ProvBlockBody.SetAttributeValue(tokenKey, cty.StringVal(rancherConfig.AdminToken))
+	rancher2ProvBlockBody.SetAttributeRaw(tokenKey, variables.Reference(variables.AdminToken))
 	rancher2ProvBlockBody.SetAttributeValue(insecure, cty.BoolVal(*rancherConfig.Insecure))
 
 	rootBody.AppendNewline()
@@ -155,50 +190,45 @@ func createGlobalRoleBinding(rootBody *hclwrite.Body, testUser string, userID st
 	globalRoleBindingBlockBody.SetAttributeValue(name, cty.StringVal(testUser))
 	globalRoleBindingBlockBody.SetAttributeValue(globalRoleID, cty.StringVal(user))
 
-	standardUser := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(rancherUser + "." + rancherUser + ".id")},
-	}
+	standardUser := format.Reference(rancherUser, rancherUser, defaults.ID)
 
 	globalRoleBindingBlockBody.SetAttributeRaw(userID, standardUser)
 }
 
-// Determines the required providers from the list of configs.
-func getRequiredProviderVersions(configMap []map[string]any) (source, rancherProviderVersion, rkeProviderVersion, localProviderVersion, awsProviderVersion string) {
-	for _, cattleConfig := range configMap {
-		tfConfig := new(config.TerraformConfig)
-		operations.LoadObjectFromMap(config.TerraformConfigurationFileKey, cattleConfig, tfConfig)
-		module := tfConfig.Module
+// getRequiredProviders returns the rancher2 provider and every other provider the modules of the given configs need,
+// with the sources and versions set by the providers section of the first config.
+func getRequiredProviders(configMap []map[string]any) ([]providers.Provider, error) {
+	terraformConfig := new(config.TerraformConfig)
+	if len(configMap) > 0 {
+		operations.LoadObjectFromMap(config.TerraformConfigurationFileKey, configMap[0], terraformConfig)
+	}
 
-		rancherProviderVersion = os.Getenv(providerEnvVar)
-		if rancherProviderVersion == "" {
-			logrus.Fatalf("Expected env var not set %s", providerEnvVar)
-		}
+	names := []string{defaults.Rancher2}
 
-		source = "rancher/rancher2"
-		if strings.Contains(rancherProviderVersion, rc) {
-			source = "terraform.local/local/rancher2"
-		}
+	for _, name := range []string{defaults.Aws, defaults.Local, defaults.RKE} {
+		for _, cattleConfig := range configMap {
+			tfConfig := new(config.TerraformConfig)
+			operations.LoadObjectFromMap(config.TerraformConfigurationFileKey, cattleConfig, tfConfig)
 
-		if module == modules.ImportEC2RKE1 {
-			rkeProviderVersion = os.Getenv(rkeEnvVar)
-			if rkeProviderVersion == "" {
-				logrus.Fatalf("Expected env var not set %s", rkeEnvVar)
+			if generators.RequiresProvider(tfConfig.Module, name) {
+				names = append(names, name)
+				break
 			}
 		}
+	}
 
-		if strings.Contains(module, defaults.Custom) || strings.Contains(module, defaults.Import) || strings.Contains(module, defaults.Airgap) {
-			{
-				awsProviderVersion = os.Getenv(awsProviderEnvVar)
-				if awsProviderVersion == "" {
-					logrus.Fatalf("Expected env var not set %s", awsProviderEnvVar)
-				}
-
-				localProviderVersion = os.Getenv(localProviderEnvVar)
-				if localProviderVersion == "" {
-					logrus.Fatalf("Expected env var not set %s", localProviderEnvVar)
-				}
-			}
+	var errs []error
+	required := []providers.Provider{}
+
+	for _, name := range names {
+		requiredProvider, err := providers.Get(name, terraformConfig)
+		if err != nil {
+			errs = append(errs, err)
+			continue
 		}
+
+		required = append(required, requiredProvider)
 	}
-	return source, rancherProviderVersion, awsProviderVersion, localProviderVersion, rkeProviderVersion
+
+	return required, errors.Join(errs...)
 }
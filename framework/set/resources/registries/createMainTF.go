@@ -13,6 +13,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/registries/rke2"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/aws"
+	"github.com/rancher/tfp-automation/framework/set/tags"
 	"github.com/sirupsen/logrus"
 )
 
@@ -39,6 +40,8 @@ const (
 // CreateMainTF is a helper function that will create the main.tf file for creating an Airgapped-Rancher server.
 func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, terraformConfig *config.TerraformConfig,
 	terratest *config.TerratestConfig) (string, string, string, error) {
+	terraformConfig = tags.WithTestName(terraformConfig, t.Name())
+
 	var file *os.File
 	file = sanity.OpenFile(file, keyPath)
 	defer file.Close()
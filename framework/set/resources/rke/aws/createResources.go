@@ -2,6 +2,7 @@ package aws
 
 import (
 	"os"
+	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
@@ -10,10 +11,7 @@ import (
 )
 
 const (
-	awsProviderEnvVar = "AWS_PROVIDER_VERSION"
-	rc                = "-rc"
 	requiredProviders = "required_providers"
-	rkeProviderEnvVar = "RKE_PROVIDER_VERSION"
 	rkeServerOne      = "rke_server1"
 	rkeServerTwo      = "rke_server2"
 	rkeServerThree    = "rke_server3"
@@ -23,7 +21,11 @@ const (
 // CreateAWSResources is a helper function that will create the AWS resources needed for the RKE1 cluster.
 func CreateAWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody, rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig) (*os.File, error) {
-	createTerraformProviderBlock(tfBlockBody)
+	err := createTerraformProviderBlock(tfBlockBody, terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	rootBody.AppendNewline()
 
 	aws.CreateAWSProviderBlock(rootBody, terraformConfig)
@@ -38,11 +40,16 @@ func CreateAWSResources(file *os.File, newFile *hclwrite.File, tfBlockBody, root
 		rootBody.AppendNewline()
 	}
 
-	_, err := file.Write(newFile.Bytes())
+	_, err = file.Write(newFile.Bytes())
 	if err != nil {
 		logrus.Infof("Failed to write configurations to main.tf file. Error: %v", err)
 		return nil, err
 	}
 
+	err = aws.WriteAWSProviderVariables(filepath.Dir(file.Name()), newFile.Bytes(), terraformConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return file, err
 }
@@ -1,44 +1,45 @@
 package aws
 
 import (
-	"os"
-	"strings"
-
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/binaries"
+	"github.com/rancher/tfp-automation/framework/providers"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
-	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 )
 
-// createTerraformProviderBlock will up the terraform block with the required aws provider.
-func createTerraformProviderBlock(tfBlockBody *hclwrite.Body) {
-	awsProviderVersion := os.Getenv(awsProviderEnvVar)
-	if awsProviderVersion == "" {
-		logrus.Fatalf("Expected env var not set %s", awsProviderEnvVar)
+// createTerraformProviderBlock will up the terraform block with the required aws and rke providers.
+func createTerraformProviderBlock(tfBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	awsProvider, err := providers.Get(defaults.Aws, terraformConfig)
+	if err != nil {
+		return err
+	}
+
+	rkeProvider, err := providers.Get(defaults.RKE, terraformConfig)
+	if err != nil {
+		return err
 	}
 
-	rkeProviderVersion := os.Getenv(rkeProviderEnvVar)
-	if rkeProviderVersion == "" {
-		logrus.Fatalf("Expected env var not set %s", rkeProviderEnvVar)
+	binary, err := binaries.Binary()
+	if err != nil {
+		return err
 	}
 
 	reqProvsBlock := tfBlockBody.AppendNewBlock(requiredProviders, nil)
 	reqProvsBlockBody := reqProvsBlock.Body()
 
 	reqProvsBlockBody.SetAttributeValue(defaults.Aws, cty.ObjectVal(map[string]cty.Value{
-		defaults.Source:  cty.StringVal(defaults.AwsSource),
-		defaults.Version: cty.StringVal(awsProviderVersion),
+		defaults.Source:  cty.StringVal(awsProvider.RequiredSource(binary)),
+		defaults.Version: cty.StringVal(awsProvider.Version),
 	}))
 
-	source := "rancher/rke"
-	if strings.Contains(rkeProviderVersion, rc) {
-		source = "terraform.local/local/rke"
-	}
-
 	reqProvsBlockBody.SetAttributeValue(defaults.RKE, cty.ObjectVal(map[string]cty.Value{
-		defaults.Source:  cty.StringVal(source),
-		defaults.Version: cty.StringVal(rkeProviderVersion),
+		defaults.Source:  cty.StringVal(rkeProvider.RequiredSource(binary)),
+		defaults.Version: cty.StringVal(rkeProvider.Version),
 	}))
+
+	return nil
 }
 
 // createRKEProviderBlock will set up the RKE1 provider block.
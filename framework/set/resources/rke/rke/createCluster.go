@@ -1,12 +1,11 @@
 package rke
 
 import (
-	"fmt"
 	"os"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
@@ -15,13 +14,16 @@ import (
 const (
 	address          = "address"
 	cluster          = "cluster"
+	controlPlane     = "controlplane"
 	enableCriDockerD = "enable_cri_dockerd"
+	etcd             = "etcd"
 	rkeServerOne     = "rke_server1"
 	rkeServerTwo     = "rke_server2"
 	rkeServerThree   = "rke_server3"
 	role             = "role"
 	sshKey           = "ssh_key"
 	user             = "user"
+	worker           = "worker"
 )
 
 // CreateRKECluster is a helper function that will create the RKE2 cluster.
@@ -34,25 +36,16 @@ func CreateRKECluster(file *os.File, newFile *hclwrite.File, rootBody *hclwrite.
 		nodesBlock := rkeBlockBody.AppendNewBlock(defaults.Nodes, nil)
 		nodesBlockBody := nodesBlock.Body()
 
-		addressExpression := defaults.AwsInstance + "." + instance + ".public_ip"
-		values := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(addressExpression)},
-		}
+		values := format.Reference(defaults.AwsInstance, instance, defaults.PublicIp)
 
 		nodesBlockBody.SetAttributeRaw(address, values)
 		nodesBlockBody.SetAttributeValue(user, cty.StringVal(terraformConfig.Standalone.OSUser))
 
-		rolesExpression := fmt.Sprintf(`["controlplane", "etcd", "worker"]`)
-		values = hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(rolesExpression)},
-		}
+		values = format.ListOfStrings([]string{controlPlane, etcd, worker})
 
 		nodesBlockBody.SetAttributeRaw(role, values)
 
-		keyPathExpression := defaults.File + `("` + terraformConfig.PrivateKeyPath + `")`
-		keyPath := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(keyPathExpression)},
-		}
+		keyPath := format.File(terraformConfig.PrivateKeyPath)
 
 		nodesBlockBody.SetAttributeRaw(sshKey, keyPath)
 	}
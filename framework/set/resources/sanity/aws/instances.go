@@ -1,13 +1,13 @@
 package aws
 
 import (
-	"fmt"
 	"strings"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/tags"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -25,11 +25,7 @@ func CreateAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 	configBlockBody.SetAttributeValue(defaults.InstanceType, cty.StringVal(terraformConfig.AWSConfig.AWSInstanceType))
 	configBlockBody.SetAttributeValue(defaults.SubnetId, cty.StringVal(terraformConfig.AWSConfig.AWSSubnetID))
 
-	awsSecGroupsExpression := fmt.Sprintf(`["%s"]`, terraformConfig.AWSConfig.AWSSecurityGroups[0])
-
-	awsSecGroupsList := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(awsSecGroupsExpression)},
-	}
+	awsSecGroupsList := format.ListOfStrings(terraformConfig.AWSConfig.AWSSecurityGroups[:1])
 
 	configBlockBody.SetAttributeRaw(defaults.VpcSecurityGroupIds, awsSecGroupsList)
 	configBlockBody.SetAttributeValue(defaults.KeyName, cty.StringVal(terraformConfig.AWSConfig.AWSKeyName))
@@ -51,21 +47,17 @@ func CreateAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 	tagsBlockBody := tagsBlock.Body()
 
 	if strings.Contains(terraformConfig.Module, "custom") {
-		expression := fmt.Sprintf(`"%s-${`+defaults.Count+`.`+defaults.Index+`}"`, terraformConfig.ResourcePrefix+"-"+hostnamePrefix)
-		tags := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
-		}
+		nameTag := format.String(format.Literal(terraformConfig.ResourcePrefix+"-"+hostnamePrefix+"-"), format.Interpolate(format.Reference(defaults.Count, defaults.Index)))
 
-		tagsBlockBody.SetAttributeRaw(defaults.Name, tags)
+		tagsBlockBody.SetAttributeRaw(defaults.Name, nameTag)
 	} else {
-		expression := fmt.Sprintf(`"%s`, terraformConfig.ResourcePrefix+"-"+hostnamePrefix+`"`)
-		tags := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
-		}
+		nameTag := hclwrite.TokensForValue(cty.StringVal(terraformConfig.ResourcePrefix + "-" + hostnamePrefix))
 
-		tagsBlockBody.SetAttributeRaw(defaults.Name, tags)
+		tagsBlockBody.SetAttributeRaw(defaults.Name, nameTag)
 	}
 
+	tags.SetBlock(tagsBlockBody, tags.Get(terraformConfig))
+
 	configBlockBody.AppendNewline()
 
 	connectionBlock := configBlockBody.AppendNewBlock(defaults.Connection, nil)
@@ -74,17 +66,11 @@ func CreateAWSInstances(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 	connectionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(defaults.Ssh))
 	connectionBlockBody.SetAttributeValue(defaults.User, cty.StringVal(terraformConfig.AWSConfig.AWSUser))
 
-	hostExpression := defaults.Self + "." + defaults.PublicIp
-	host := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(hostExpression)},
-	}
+	host := format.Reference(defaults.Self, defaults.PublicIp)
 
 	connectionBlockBody.SetAttributeRaw(defaults.Host, host)
 
-	keyPathExpression := defaults.File + `("` + terraformConfig.PrivateKeyPath + `")`
-	keyPath := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(keyPathExpression)},
-	}
+	keyPath := format.File(terraformConfig.PrivateKeyPath)
 
 	connectionBlockBody.SetAttributeRaw(defaults.PrivateKey, keyPath)
 	connectionBlockBody.SetAttributeValue(defaults.Timeout, cty.StringVal(terraformConfig.AWSConfig.Timeout))
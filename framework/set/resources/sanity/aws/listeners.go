@@ -3,13 +3,14 @@ package aws
 import (
 	"strconv"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
 
 const (
+	arn             = "arn"
 	loadBalancerARN = "load_balancer_arn"
 	forward         = "forward"
 	targetGroupARN  = "target_group_arn"
@@ -20,10 +21,7 @@ func CreateLoadBalancerListeners(rootBody *hclwrite.Body, port int64) {
 	listenersGroupBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.LoadBalancerListener, defaults.LoadBalancerListener + "_" + strconv.FormatInt(port, 10)})
 	listenersGroupBlockBody := listenersGroupBlock.Body()
 
-	loadBalancerExpression := defaults.LoadBalancer + "." + defaults.LoadBalancer + ".arn"
-	values := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(loadBalancerExpression)},
-	}
+	values := format.Reference(defaults.LoadBalancer, defaults.LoadBalancer, arn)
 
 	listenersGroupBlockBody.SetAttributeRaw(loadBalancerARN, values)
 	listenersGroupBlockBody.SetAttributeValue(defaults.Port, cty.NumberIntVal(port))
@@ -34,10 +32,7 @@ func CreateLoadBalancerListeners(rootBody *hclwrite.Body, port int64) {
 
 	defaultActionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(forward))
 
-	targetGroupExpression := defaults.LoadBalancerTargetGroup + "." + defaults.TargetGroupPrefix + strconv.FormatInt(port, 10) + ".arn"
-	values = hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(targetGroupExpression)},
-	}
+	values = format.Reference(defaults.LoadBalancerTargetGroup, defaults.TargetGroupPrefix+strconv.FormatInt(port, 10), arn)
 
 	defaultActionBlockBody.SetAttributeRaw(targetGroupARN, values)
 }
@@ -47,10 +42,7 @@ func CreateInternalLoadBalancerListeners(rootBody *hclwrite.Body, port int64) {
 	listenersGroupBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.LoadBalancerListener, defaults.LoadBalancerInternalListerner + "_" + strconv.FormatInt(port, 10)})
 	listenersGroupBlockBody := listenersGroupBlock.Body()
 
-	loadBalancerExpression := defaults.LoadBalancer + "." + defaults.InternalLoadBalancer + ".arn"
-	values := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(loadBalancerExpression)},
-	}
+	values := format.Reference(defaults.LoadBalancer, defaults.InternalLoadBalancer, arn)
 
 	listenersGroupBlockBody.SetAttributeRaw(loadBalancerARN, values)
 	listenersGroupBlockBody.SetAttributeValue(defaults.Port, cty.NumberIntVal(port))
@@ -61,10 +53,7 @@ func CreateInternalLoadBalancerListeners(rootBody *hclwrite.Body, port int64) {
 
 	defaultActionBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(forward))
 
-	targetGroupExpression := defaults.LoadBalancerTargetGroup + "." + defaults.TargetGroupInternalPrefix + strconv.FormatInt(port, 10) + ".arn"
-	values = hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(targetGroupExpression)},
-	}
+	values = format.Reference(defaults.LoadBalancerTargetGroup, defaults.TargetGroupInternalPrefix+strconv.FormatInt(port, 10), arn)
 
 	defaultActionBlockBody.SetAttributeRaw(targetGroupARN, values)
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/tags"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -25,6 +26,11 @@ func CreateLoadBalancer(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 	subnetList := format.ListOfStrings([]string{terraformConfig.AWSConfig.AWSSubnetID})
 	loadBalancerGroupBodyBlockBody.SetAttributeRaw(defaults.Subnets, subnetList)
 	loadBalancerGroupBodyBlockBody.SetAttributeValue(name, cty.StringVal(terraformConfig.ResourcePrefix))
+
+	loadBalancerGroupBodyBlockBody.AppendNewline()
+
+	tagsBlock := loadBalancerGroupBodyBlockBody.AppendNewBlock(defaults.Tags+" =", nil)
+	tags.SetBlock(tagsBlock.Body(), tags.Get(terraformConfig))
 }
 
 // CreateInternalLoadBalancer is a function that will set the internal load balancer configurations in the main.tf file.
@@ -38,4 +44,9 @@ func CreateInternalLoadBalancer(rootBody *hclwrite.Body, terraformConfig *config
 	subnetList := format.ListOfStrings([]string{terraformConfig.AWSConfig.AWSSubnetID})
 	loadBalancerGroupBodyBlockBody.SetAttributeRaw(defaults.Subnets, subnetList)
 	loadBalancerGroupBodyBlockBody.SetAttributeValue(name, cty.StringVal(terraformConfig.ResourcePrefix+"-"+internal))
+
+	loadBalancerGroupBodyBlockBody.AppendNewline()
+
+	tagsBlock := loadBalancerGroupBodyBlockBody.AppendNewBlock(defaults.Tags+" =", nil)
+	tags.SetBlock(tagsBlock.Body(), tags.Get(terraformConfig))
 }
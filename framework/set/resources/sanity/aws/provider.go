@@ -1,12 +1,15 @@
 package aws
 
 import (
-	"os"
+	"sort"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/binaries"
+	"github.com/rancher/tfp-automation/framework/format"
+	"github.com/rancher/tfp-automation/framework/providers"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -22,26 +25,43 @@ const (
 )
 
 // CreateTerraformProviderBlock will up the terraform block with the required aws provider.
-func CreateTerraformProviderBlock(tfBlockBody *hclwrite.Body) {
-	awsProviderVersion := os.Getenv("AWS_PROVIDER_VERSION")
+func CreateTerraformProviderBlock(tfBlockBody *hclwrite.Body, terraformConfig *config.TerraformConfig) error {
+	awsProvider, err := providers.Get(defaults.Aws, terraformConfig)
+	if err != nil {
+		return err
+	}
+
+	binary, err := binaries.Binary()
+	if err != nil {
+		return err
+	}
 
 	reqProvsBlock := tfBlockBody.AppendNewBlock(requiredProviders, nil)
 	reqProvsBlockBody := reqProvsBlock.Body()
 
 	reqProvsBlockBody.SetAttributeValue(defaults.Aws, cty.ObjectVal(map[string]cty.Value{
-		defaults.Source:  cty.StringVal(defaults.AwsSource),
-		defaults.Version: cty.StringVal(awsProviderVersion),
+		defaults.Source:  cty.StringVal(awsProvider.RequiredSource(binary)),
+		defaults.Version: cty.StringVal(awsProvider.Version),
 	}))
+
+	return nil
 }
 
-// CreateAWSProviderBlock will set up the aws provider block.
+// CreateAWSProviderBlock will set up the aws provider block. The credentials are referenced as input variables, written
+// by WriteAWSProviderVariables.
 func CreateAWSProviderBlock(rootBody *hclwrite.Body, terraformConfig *config.TerraformConfig) {
 	awsProvBlock := rootBody.AppendNewBlock(defaults.Provider, []string{defaults.Aws})
 	awsProvBlockBody := awsProvBlock.Body()
 
 	awsProvBlockBody.SetAttributeValue(defaults.Region, cty.StringVal(terraformConfig.AWSConfig.Region))
-	awsProvBlockBody.SetAttributeValue(defaults.AccessKey, cty.StringVal(terraformConfig.AWSCredentials.AWSAccessKey))
-	awsProvBlockBody.SetAttributeValue(defaults.SecretKey, cty.StringVal(terraformConfig.AWSCredentials.AWSSecretKey))
+	awsProvBlockBody.SetAttributeRaw(defaults.AccessKey, variables.Reference(variables.AWSAccessKey))
+	awsProvBlockBody.SetAttributeRaw(defaults.SecretKey, variables.Reference(variables.AWSSecretKey))
+}
+
+// WriteAWSProviderVariables will write the variables.tf and terraform.tfvars.json files holding the AWS credentials
+// referenced by the given main.tf contents to the given directory.
+func WriteAWSProviderVariables(keyPath string, mainTF []byte, terraformConfig *config.TerraformConfig) error {
+	return variables.WriteFiles(keyPath, mainTF, variables.AWSProviderValues(terraformConfig))
 }
 
 // CreateLocalBlock will set up the local block. Returns the local block.
@@ -49,30 +69,33 @@ func CreateLocalBlock(rootBody *hclwrite.Body, terraformConfig *config.Terraform
 	localBlock := rootBody.AppendNewBlock(locals, nil)
 	localBlockBody := localBlock.Body()
 
-	var instanceIds map[string]interface{}
+	var instanceIds map[string]hclwrite.Tokens
 	if terraformConfig.Standalone.RKE2Version != "" {
-		instanceIds = map[string]interface{}{
-			rke2ServerOne:   defaults.AwsInstance + "." + rke2ServerOne + ".id",
-			rke2ServerTwo:   defaults.AwsInstance + "." + rke2ServerTwo + ".id",
-			rke2ServerThree: defaults.AwsInstance + "." + rke2ServerThree + ".id",
+		instanceIds = map[string]hclwrite.Tokens{
+			rke2ServerOne:   format.Reference(defaults.AwsInstance, rke2ServerOne, defaults.ID),
+			rke2ServerTwo:   format.Reference(defaults.AwsInstance, rke2ServerTwo, defaults.ID),
+			rke2ServerThree: format.Reference(defaults.AwsInstance, rke2ServerThree, defaults.ID),
 		}
 	} else if terraformConfig.Standalone.K3SVersion != "" {
-		instanceIds = map[string]interface{}{
-			k3sServerOne:   defaults.AwsInstance + "." + k3sServerOne + ".id",
-			k3sServerTwo:   defaults.AwsInstance + "." + k3sServerTwo + ".id",
-			k3sServerThree: defaults.AwsInstance + "." + k3sServerThree + ".id",
+		instanceIds = map[string]hclwrite.Tokens{
+			k3sServerOne:   format.Reference(defaults.AwsInstance, k3sServerOne, defaults.ID),
+			k3sServerTwo:   format.Reference(defaults.AwsInstance, k3sServerTwo, defaults.ID),
+			k3sServerThree: format.Reference(defaults.AwsInstance, k3sServerThree, defaults.ID),
 		}
 	}
 
 	instanceIdsBlock := localBlockBody.AppendNewBlock(rke2InstanceIDs+" =", nil)
 	instanceIdsBlockBody := instanceIdsBlock.Body()
 
-	for key, value := range instanceIds {
-		expression := value.(string)
-		instanceValues := hclwrite.Tokens{
-			{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)},
-		}
+	keys := make([]string, 0, len(instanceIds))
+	for key := range instanceIds {
+		keys = append(keys, key)
+	}
+
+	// Sort the keys so that the generated file does not change from one run to the next.
+	sort.Strings(keys)
 
-		instanceIdsBlockBody.SetAttributeRaw(key, instanceValues)
+	for _, key := range keys {
+		instanceIdsBlockBody.SetAttributeRaw(key, instanceIds[key])
 	}
 }
@@ -1,9 +1,9 @@
 package aws
 
 import (
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -23,20 +23,14 @@ func CreateRoute53Record(rootBody *hclwrite.Body, terraformConfig *config.Terraf
 	routeRecordBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Route53Record, defaults.Route53Record})
 	routeRecordBlockBody := routeRecordBlock.Body()
 
-	zoneIDExpression := defaults.Data + "." + defaults.Route53Zone + "." + selected + "." + zoneID
-	values := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(zoneIDExpression)},
-	}
+	values := format.Reference(defaults.Data, defaults.Route53Zone, selected, zoneID)
 
 	routeRecordBlockBody.SetAttributeRaw(zoneID, values)
 	routeRecordBlockBody.SetAttributeValue(name, cty.StringVal(terraformConfig.ResourcePrefix))
 	routeRecordBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(CNAME))
 	routeRecordBlockBody.SetAttributeValue(ttl, cty.NumberIntVal(300))
 
-	loadBalancerExpression := "[" + defaults.LoadBalancer + "." + defaults.LoadBalancer + "." + dnsName + "]"
-	values = hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(loadBalancerExpression)},
-	}
+	values = format.ListOfReferences(format.Reference(defaults.LoadBalancer, defaults.LoadBalancer, dnsName))
 
 	routeRecordBlockBody.SetAttributeRaw(records, values)
 
@@ -54,20 +48,14 @@ func CreateRoute53InternalRecord(rootBody *hclwrite.Body, terraformConfig *confi
 	routeRecordBlock := rootBody.AppendNewBlock(defaults.Resource, []string{defaults.Route53Record, defaults.Route53InternalRecord})
 	routeRecordBlockBody := routeRecordBlock.Body()
 
-	zoneIDExpression := defaults.Data + "." + defaults.Route53Zone + "." + selected + "." + zoneID
-	values := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(zoneIDExpression)},
-	}
+	values := format.Reference(defaults.Data, defaults.Route53Zone, selected, zoneID)
 
 	routeRecordBlockBody.SetAttributeRaw(zoneID, values)
 	routeRecordBlockBody.SetAttributeValue(name, cty.StringVal(terraformConfig.ResourcePrefix+"-internal"))
 	routeRecordBlockBody.SetAttributeValue(defaults.Type, cty.StringVal(CNAME))
 	routeRecordBlockBody.SetAttributeValue(ttl, cty.NumberIntVal(300))
 
-	loadBalancerExpression := "[" + defaults.LoadBalancer + "." + defaults.InternalLoadBalancer + "." + dnsName + "]"
-	values = hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(loadBalancerExpression)},
-	}
+	values = format.ListOfReferences(format.Reference(defaults.LoadBalancer, defaults.InternalLoadBalancer, dnsName))
 
 	routeRecordBlockBody.SetAttributeRaw(records, values)
 }
@@ -3,14 +3,14 @@ package aws
 import (
 	"fmt"
 
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/framework/format"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
 	"github.com/zclconf/go-cty/cty"
 )
 
 const (
-	eachValue       = "each.value"
+	each            = "each"
 	forEach         = "for_each"
 	rke2InstanceIDs = "rke2_instance_ids"
 )
@@ -20,24 +20,15 @@ func CreateTargetGroupAttachments(rootBody *hclwrite.Body, lbTargetGroupAttachme
 	targetGroupBlock := rootBody.AppendNewBlock(defaults.Resource, []string{lbTargetGroupAttachment, targetGroupAttachmentServer})
 	targetGroupBlockBody := targetGroupBlock.Body()
 
-	instanceValueExpression := defaults.Local + "." + rke2InstanceIDs
-	values := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(instanceValueExpression)},
-	}
+	values := format.Reference(defaults.Local, rke2InstanceIDs)
 
 	targetGroupBlockBody.SetAttributeRaw(forEach, values)
 
-	targetGroupExpression := defaults.LoadBalancerTargetGroup + "." + defaults.TargetGroupPrefix + fmt.Sprint(port) + ".arn"
-	values = hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(targetGroupExpression)},
-	}
+	values = format.Reference(defaults.LoadBalancerTargetGroup, defaults.TargetGroupPrefix+fmt.Sprint(port), arn)
 
 	targetGroupBlockBody.SetAttributeRaw(defaults.TargetGroupARN, values)
 
-	targetIDExpression := eachValue
-	values = hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(targetIDExpression)},
-	}
+	values = format.Reference(each, defaults.Value)
 
 	targetGroupBlockBody.SetAttributeRaw(defaults.TargetID, values)
 	targetGroupBlockBody.SetAttributeValue(defaults.Port, cty.NumberIntVal(port))
@@ -48,24 +39,15 @@ func CreateInternalTargetGroupAttachments(rootBody *hclwrite.Body, lbTargetGroup
 	targetGroupBlock := rootBody.AppendNewBlock(defaults.Resource, []string{lbTargetGroupAttachment, targetGroupAttachmentServer})
 	targetGroupBlockBody := targetGroupBlock.Body()
 
-	instanceValueExpression := defaults.Local + "." + rke2InstanceIDs
-	values := hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(instanceValueExpression)},
-	}
+	values := format.Reference(defaults.Local, rke2InstanceIDs)
 
 	targetGroupBlockBody.SetAttributeRaw(forEach, values)
 
-	targetGroupExpression := defaults.LoadBalancerTargetGroup + "." + defaults.TargetGroupInternalPrefix + fmt.Sprint(port) + ".arn"
-	values = hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(targetGroupExpression)},
-	}
+	values = format.Reference(defaults.LoadBalancerTargetGroup, defaults.TargetGroupInternalPrefix+fmt.Sprint(port), arn)
 
 	targetGroupBlockBody.SetAttributeRaw(defaults.TargetGroupARN, values)
 
-	targetIDExpression := eachValue
-	values = hclwrite.Tokens{
-		{Type: hclsyntax.TokenIdent, Bytes: []byte(targetIDExpression)},
-	}
+	values = format.Reference(each, defaults.Value)
 
 	targetGroupBlockBody.SetAttributeRaw(defaults.TargetID, values)
 	targetGroupBlockBody.SetAttributeValue(defaults.Port, cty.NumberIntVal(port))
@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/tags"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -44,6 +45,11 @@ func CreateTargetGroups(rootBody *hclwrite.Body, terraformConfig *config.Terrafo
 	healthCheckGroupBlockBody.SetAttributeValue(healthyThreshold, cty.NumberIntVal(3))
 	healthCheckGroupBlockBody.SetAttributeValue(unhealthyThreshold, cty.NumberIntVal(3))
 	healthCheckGroupBlockBody.SetAttributeValue(matcher, cty.StringVal("200-399"))
+
+	targetGroupBlockBody.AppendNewline()
+
+	tagsBlock := targetGroupBlockBody.AppendNewBlock(defaults.Tags+" =", nil)
+	tags.SetBlock(tagsBlock.Body(), tags.Get(terraformConfig))
 }
 
 // CreateInternalTargetGroups is a function that will set the internal target group configurations in the main.tf file.
@@ -67,4 +73,9 @@ func CreateInternalTargetGroups(rootBody *hclwrite.Body, terraformConfig *config
 	healthCheckGroupBlockBody.SetAttributeValue(healthyThreshold, cty.NumberIntVal(3))
 	healthCheckGroupBlockBody.SetAttributeValue(unhealthyThreshold, cty.NumberIntVal(3))
 	healthCheckGroupBlockBody.SetAttributeValue(matcher, cty.StringVal("200-399"))
+
+	targetGroupBlockBody.AppendNewline()
+
+	tagsBlock := targetGroupBlockBody.AppendNewBlock(defaults.Tags+" =", nil)
+	tags.SetBlock(tagsBlock.Body(), tags.Get(terraformConfig))
 }
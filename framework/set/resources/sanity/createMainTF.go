@@ -11,6 +11,7 @@ import (
 	"github.com/rancher/tfp-automation/framework/set/resources/rke2"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/aws"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/rancher"
+	"github.com/rancher/tfp-automation/framework/set/tags"
 	"github.com/sirupsen/logrus"
 )
 
@@ -28,6 +29,8 @@ const (
 // CreateMainTF is a helper function that will create the main.tf file for creating a Rancher server.
 func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig) error {
+	terraformConfig = tags.WithTestName(terraformConfig, t.Name())
+
 	var file *os.File
 	file = OpenFile(file, keyPath)
 	defer file.Close()
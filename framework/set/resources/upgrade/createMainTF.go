@@ -12,6 +12,7 @@ import (
 	registry "github.com/rancher/tfp-automation/framework/set/resources/registries/createRegistry"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity"
 	"github.com/rancher/tfp-automation/framework/set/resources/sanity/aws"
+	"github.com/rancher/tfp-automation/framework/set/tags"
 	"github.com/sirupsen/logrus"
 )
 
@@ -23,6 +24,8 @@ const (
 // CreateMainTF is a helper function that will create the main.tf file for creating a Rancher server behind a proxy.
 func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath string, terraformConfig *config.TerraformConfig,
 	terratest *config.TerratestConfig, serverNode, proxyNode, bastionNode, registryNode string) error {
+	terraformConfig = tags.WithTestName(terraformConfig, t.Name())
+
 	var file *os.File
 	file = sanity.OpenFile(file, keyPath)
 	defer file.Close()
@@ -33,12 +36,21 @@ func CreateMainTF(t *testing.T, terraformOptions *terraform.Options, keyPath str
 	tfBlock := rootBody.AppendNewBlock(terraformConst, nil)
 	tfBlockBody := tfBlock.Body()
 
-	aws.CreateTerraformProviderBlock(tfBlockBody)
+	err := aws.CreateTerraformProviderBlock(tfBlockBody, terraformConfig)
+	if err != nil {
+		return err
+	}
+
 	rootBody.AppendNewline()
 
 	aws.CreateAWSProviderBlock(rootBody, terraformConfig)
 	rootBody.AppendNewline()
 
+	err = aws.WriteAWSProviderVariables(keyPath, newFile.Bytes(), terraformConfig)
+	if err != nil {
+		return err
+	}
+
 	file = sanity.OpenFile(file, keyPath)
 	switch {
 	case terraformConfig.Standalone.UpgradeAirgapRancher:
@@ -8,29 +8,34 @@ import (
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/authproviders"
 	"github.com/rancher/tfp-automation/defaults/configs"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/cleanup"
 	"github.com/rancher/tfp-automation/framework/set/authproviders/ad"
 	"github.com/rancher/tfp-automation/framework/set/authproviders/azureAD"
 	"github.com/rancher/tfp-automation/framework/set/authproviders/github"
 	"github.com/rancher/tfp-automation/framework/set/authproviders/ldap"
 	"github.com/rancher/tfp-automation/framework/set/authproviders/okta"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 
 	"github.com/sirupsen/logrus"
 )
 
-// AuthConfig is a function that will set the main.tf file based on the auth provider.
-func AuthConfig(terraformConfig *config.TerraformConfig, testUser, testPassword string) error {
+// AuthConfig is a function that will set the main.tf file in the given working directory based on the auth provider.
+func AuthConfig(terraformConfig *config.TerraformConfig, keyPath, testUser, testPassword string) error {
 	rancherConfig := new(rancher.Config)
 	framework.LoadConfig(configs.Rancher, rancherConfig)
 
 	authProvider := terraformConfig.AuthProvider
 
+	// The files ConfigTF writes would declare the providers and the test user a second time.
+	err := cleanup.RemoveGeneratedFiles(keyPath)
+	if err != nil {
+		return err
+	}
+
 	var file *os.File
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
 
-	file, err := os.Create(keyPath + configs.MainTF)
+	file, err = os.Create(keyPath + configs.MainTF)
 	if err != nil {
 		logrus.Infof("Failed to reset/overwrite main.tf file. Error: %v", err)
 		return err
@@ -38,28 +43,39 @@ func AuthConfig(terraformConfig *config.TerraformConfig, testUser, testPassword
 
 	defer file.Close()
 
-	newFile, rootBody := resources.SetProvidersAndUsersTF(testUser, testPassword, true, nil)
+	newFile, rootBody, err := resources.SetProvidersAndUsersTF(testUser, testPassword, true, nil)
+	if err != nil {
+		return err
+	}
 
 	rootBody.AppendNewline()
 
 	switch {
 	case authProvider == authproviders.AD:
 		err = ad.SetAD(terraformConfig, newFile, rootBody, file)
-		return err
 	case authProvider == authproviders.AzureAD:
 		err = azureAD.SetAzureAD(rancherConfig, terraformConfig, newFile, rootBody, file)
-		return err
 	case authProvider == authproviders.GitHub:
 		err = github.SetGithub(terraformConfig, newFile, rootBody, file)
-		return err
 	case authProvider == authproviders.Okta:
 		err = okta.SetOkta(rancherConfig, terraformConfig, newFile, rootBody, file)
-		return err
 	case authProvider == authproviders.OpenLDAP:
 		err = ldap.SetOpenLDAP(terraformConfig, newFile, rootBody, file)
-		return err
 	default:
 		logrus.Errorf("Unsupported auth provider: %v", authProvider)
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	values := variables.ProviderValues(rancherConfig, terraformConfig)
+	values.Merge(variables.ClusterValues(terraformConfig))
+
+	err = variables.WriteFiles(keyPath, newFile.Bytes(), values)
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -1,157 +1,286 @@
 package set
 
 import (
+	"bytes"
+	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
-	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/rancher/tfp-automation/config"
 	configuration "github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/defaults/configs"
-	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/defaults/modules"
-	"github.com/rancher/tfp-automation/framework/set/defaults"
-	"github.com/rancher/tfp-automation/framework/set/provisioning/airgap"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/redact"
+	"github.com/rancher/tfp-automation/framework/set/document"
+	"github.com/rancher/tfp-automation/framework/set/generators"
 	"github.com/rancher/tfp-automation/framework/set/provisioning/custom/locals"
-	custom "github.com/rancher/tfp-automation/framework/set/provisioning/custom/rke1"
-	customV2 "github.com/rancher/tfp-automation/framework/set/provisioning/custom/rke2k3s"
-	"github.com/rancher/tfp-automation/framework/set/provisioning/hosted"
-	"github.com/rancher/tfp-automation/framework/set/provisioning/imported"
-	nodedriver "github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke1"
-	nodedriverV2 "github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke2k3s"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/tags"
+	"github.com/rancher/tfp-automation/framework/set/variables"
 	"github.com/sirupsen/logrus"
+
+	// The generator packages register their modules with the generators registry when they are imported.
+	_ "github.com/rancher/tfp-automation/framework/set/provisioning/airgap"
+	_ "github.com/rancher/tfp-automation/framework/set/provisioning/custom/rke1"
+	_ "github.com/rancher/tfp-automation/framework/set/provisioning/custom/rke2k3s"
+	_ "github.com/rancher/tfp-automation/framework/set/provisioning/hosted"
+	_ "github.com/rancher/tfp-automation/framework/set/provisioning/imported"
+	_ "github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke1"
+	_ "github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke2k3s"
 )
 
-// ConfigTF is a function that will set the main.tf file based on the module type.
-func ConfigTF(client *rancher.Client, testUser, testPassword string, rbacRole configuration.Role, configMap []map[string]any,
+// reservedFiles are the files a cluster's resourcePrefix must not be named after, as ConfigTF, the variables and the
+// standalone modules write them in the same working directory.
+var reservedFiles = []string{configs.LocalsTF, configs.MainTF, configs.ProvidersTF, configs.UsersTF, configs.OutputsTF,
+	configs.VariablesTF}
+
+// ConfigTF is a function that will set the providers.tf and users.tf files, one <resourcePrefix>.tf file per cluster
+// and, for custom clusters, the locals.tf file in the given working directory based on the module type. The files
+// written by a previous run are removed first, so that clusters no longer in the configMap are not kept around. When
+// the outputFormat of the first config is json, every file, variables.tf included, is written as .tf.json instead.
+// When a tags TTL is set, its expiry is stamped into the configMap, so that later calls for the same run keep it. The
+// given test name is set in the tags of every cluster that does not configure one.
+func ConfigTF(keyPath, testName, testUser, testPassword string, rbacRole configuration.Role, configMap []map[string]any,
 	isWindows bool) ([]string, error) {
-	var file *os.File
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
+	err := resetWorkingDirectory(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	format := outputFormat(configMap)
 
-	file, err := os.Create(keyPath + configs.MainTF)
+	providersFile, err := resources.SetProvidersTF(configMap)
 	if err != nil {
-		logrus.Infof("Failed to reset/overwrite main.tf file. Error: %v", err)
 		return nil, err
 	}
 
-	defer file.Close()
-	newFile, rootBody := resources.SetProvidersAndUsersTF(testUser, testPassword, false, configMap)
+	err = writeTF(keyPath+configs.ProvidersTF, providersFile, format)
+	if err != nil {
+		return nil, err
+	}
+
+	usersFile := resources.SetUsersTF(testUser, testPassword, false)
+
+	err = writeTF(keyPath+configs.UsersTF, usersFile, format)
+	if err != nil {
+		return nil, err
+	}
 
-	rootBody.AppendNewline()
+	generated := [][]byte{providersFile.Bytes(), usersFile.Bytes()}
 
 	clusterNames := []string{}
 	customClusterNames := []string{}
 	containsCustomModule := false
+	values := variables.Values{}
 
 	for i, cattleConfig := range configMap {
+		err = tags.SetExpiry(cattleConfig, time.Now())
+		if err != nil {
+			return clusterNames, err
+		}
+
 		rancherConfig, terraform, terratest := config.LoadTFPConfigs(cattleConfig)
+		redact.Register(rancherConfig, terraform)
 
-		kubernetesVersion := terratest.KubernetesVersion
-		nodePools := terratest.Nodepools
-		psact := terratest.PSACT
-		snapshotInput := terratest.SnapshotInput
+		terraform = tags.WithTestName(terraform, testName)
 
 		module := terraform.Module
 
+		if i == 0 {
+			values.Merge(variables.ProviderValues(rancherConfig, terraform))
+		}
+
+		values.Merge(variables.ClusterValues(terraform))
+
 		if strings.Contains(module, clustertypes.CUSTOM) {
 			containsCustomModule = true
 		}
 
+		if slices.Contains(clusterNames, terraform.ResourcePrefix) {
+			return clusterNames, fmt.Errorf("resourcePrefix %s is used by more than one cluster, every cluster in the same "+
+				"module needs its own resourcePrefix", terraform.ResourcePrefix)
+		}
+
+		clusterPath := "/" + terraform.ResourcePrefix + configs.TFExtension
+		if slices.Contains(reservedFiles, clusterPath) {
+			return clusterNames, fmt.Errorf("resourcePrefix %s is reserved, as the %s file is not written per cluster",
+				terraform.ResourcePrefix, strings.TrimPrefix(clusterPath, "/"))
+		}
+
 		clusterNames = append(clusterNames, terraform.ResourcePrefix)
 
 		if module == modules.CustomEC2RKE2 || module == modules.CustomEC2K3s || module == modules.CustomEC2RKE2Windows {
 			customClusterNames = append(customClusterNames, terraform.ResourcePrefix)
 		}
 
-		switch {
-		case module == clustertypes.AKS:
-			file, err = hosted.SetAKS(terraform, kubernetesVersion, nodePools, newFile, rootBody, file)
-			if err != nil {
-				return clusterNames, err
-			}
-		case module == clustertypes.EKS:
-			file, err = hosted.SetEKS(terraform, kubernetesVersion, nodePools, newFile, rootBody, file)
-			if err != nil {
-				return clusterNames, err
-			}
-		case module == clustertypes.GKE:
-			file, err = hosted.SetGKE(terraform, kubernetesVersion, nodePools, newFile, rootBody, file)
-			if err != nil {
-				return clusterNames, err
-			}
-		case strings.Contains(module, clustertypes.RKE1) && !strings.Contains(module, defaults.Custom) && !strings.Contains(module, defaults.Import) && !strings.Contains(module, defaults.Airgap):
-			file, err = nodedriver.SetRKE1(terraform, kubernetesVersion, psact, nodePools, snapshotInput, newFile, rootBody, file, rbacRole)
-			if err != nil {
-				return clusterNames, err
-			}
-		case (strings.Contains(module, clustertypes.RKE2) || strings.Contains(module, clustertypes.K3S)) && !strings.Contains(module, defaults.Custom) && !strings.Contains(module, defaults.Import) && !strings.Contains(module, defaults.Airgap):
-			file, err = nodedriverV2.SetRKE2K3s(client, terraform, kubernetesVersion, psact, nodePools, snapshotInput, newFile, rootBody, file, rbacRole)
-			if err != nil {
-				return clusterNames, err
-			}
-		case module == modules.CustomEC2RKE1:
-			file, err = custom.SetCustomRKE1(rancherConfig, terraform, terratest, configMap, newFile, rootBody, file)
-			if err != nil {
-				return clusterNames, err
-			}
-		case module == modules.CustomEC2RKE2 || module == modules.CustomEC2K3s || module == modules.CustomEC2RKE2Windows:
-			file, err = customV2.SetCustomRKE2K3s(rancherConfig, terraform, terratest, configMap, newFile, rootBody, file)
-			if err != nil {
-				return clusterNames, err
-			}
+		clusterFile, err := configCluster(keyPath+clusterPath, format, &generators.Input{
+			RancherConfig:   rancherConfig,
+			TerraformConfig: terraform,
+			TerratestConfig: terratest,
+			ConfigMap:       configMap,
+			RBACRole:        rbacRole,
+			IsWindows:       isWindows,
+		})
+		if err != nil {
+			return clusterNames, err
+		}
 
-			if isWindows {
-				file, err = customV2.SetCustomRKE2Windows(client, rancherConfig, terraform, terratest, configMap, newFile, rootBody, file)
-				if err != nil {
-					return clusterNames, err
-				}
-			}
-		case module == modules.AirgapRKE1:
-			_, err = airgap.SetAirgapRKE1(rancherConfig, terraform, terratest, configMap, newFile, rootBody, file)
-			if err != nil {
-				return clusterNames, err
-			}
-		case module == modules.AirgapRKE2 || module == modules.AirgapK3S:
-			_, err = airgap.SetAirgapRKE2K3s(rancherConfig, terraform, terratest, configMap, newFile, rootBody, file)
-			if err != nil {
-				return clusterNames, err
-			}
-		case module == modules.ImportEC2RKE1:
-			_, err = imported.SetImportedRKE1(rancherConfig, terraform, terratest, newFile, rootBody, file)
+		generated = append(generated, clusterFile.Bytes())
+
+		if i == len(configMap)-1 && containsCustomModule {
+			localsFile := hclwrite.NewEmptyFile()
+
+			_, err = locals.SetLocals(localsFile.Body(), terraform, configMap, localsFile, nil, customClusterNames)
 			if err != nil {
 				return clusterNames, err
 			}
-		case module == modules.ImportEC2RKE2 || module == modules.ImportEC2K3s:
-			_, err = imported.SetImportedRKE2K3s(rancherConfig, terraform, terratest, newFile, rootBody, file)
+
+			err = writeTF(keyPath+configs.LocalsTF, localsFile, format)
 			if err != nil {
 				return clusterNames, err
 			}
-		default:
-			logrus.Errorf("Unsupported module: %v", module)
+
+			generated = append(generated, localsFile.Bytes())
 		}
+	}
 
-		if i == len(configMap)-1 && containsCustomModule {
-			file, err = locals.SetLocals(rootBody, terraform, configMap, newFile, file, customClusterNames)
-			rootBody.AppendNewline()
+	err = variables.WriteFiles(keyPath, bytes.Join(generated, []byte("\n")), values)
+	if err != nil {
+		return clusterNames, err
+	}
+
+	if format == configs.JSONFormat {
+		err = convertVariablesTF(keyPath)
+		if err != nil {
+			return clusterNames, err
 		}
 	}
 
-	keyPath = rancher2.SetKeyPath(keypath.RancherKeyPath)
+	return clusterNames, nil
+}
 
-	file, err = os.Create(keyPath + configs.MainTF)
+// configCluster sets the HCL of a single cluster with the generator registered for its module and writes it to the
+// given file.
+func configCluster(clusterPath, format string, input *generators.Input) (*hclwrite.File, error) {
+	input.NewFile = hclwrite.NewEmptyFile()
+	input.RootBody = input.NewFile.Body()
+
+	module := input.TerraformConfig.Module
+
+	generator, ok := generators.Lookup(module)
+	if !ok {
+		logrus.Errorf("Unsupported module: %v", module)
+		return input.NewFile, nil
+	}
+
+	// Some generators write the cluster to the file as they go, it is overwritten with the complete cluster below.
+	file, err := os.Create(clusterPath)
 	if err != nil {
-		logrus.Infof("Failed to reset/overwrite main.tf file. Error: %v", err)
-		return clusterNames, err
+		logrus.Errorf("Failed to create %s file. Error: %v", filepath.Base(clusterPath), err)
+		return nil, err
 	}
 
-	_, err = file.Write(newFile.Bytes())
+	defer file.Close()
+
+	input.File = file
+
+	err = generator.Generate(input)
 	if err != nil {
-		logrus.Infof("Failed to write RKE2/K3S configurations to main.tf file. Error: %v", err)
-		return clusterNames, err
+		return nil, err
 	}
 
-	return clusterNames, nil
+	err = writeTF(clusterPath, input.NewFile, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return input.NewFile, nil
+}
+
+// outputFormat returns the format the generated files are written in, as set by the first config.
+func outputFormat(configMap []map[string]any) string {
+	if len(configMap) == 0 {
+		return configs.HCLFormat
+	}
+
+	_, terraformConfig, _ := config.LoadTFPConfigs(configMap[0])
+	if terraformConfig.OutputFormat == "" {
+		return configs.HCLFormat
+	}
+
+	return terraformConfig.OutputFormat
+}
+
+// convertVariablesTF rewrites the variables.tf file written by the variables package as variables.tf.json.
+func convertVariablesTF(keyPath string) error {
+	contents, err := os.ReadFile(keyPath + configs.VariablesTF)
+	if err != nil {
+		logrus.Errorf("Failed to read variables.tf file. Error: %v", err)
+		return err
+	}
+
+	variablesFile, diags := hclwrite.ParseConfig(contents, configs.VariablesTF, hcl.InitialPos)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	return writeTF(keyPath+configs.VariablesTF, variablesFile, configs.JSONFormat)
+}
+
+// resetWorkingDirectory removes the .tf files written by a previous ConfigTF run and, if AuthConfig or ImportTF wrote
+// the main.tf file, resets it to its placeholder.
+func resetWorkingDirectory(keyPath string) error {
+	err := cleanup.RemoveGeneratedFiles(keyPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stat(keyPath + configs.MainTF)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return cleanup.ResetMainTF(keyPath)
+}
+
+// writeTF writes the given HCL to the given file, under the header cleanup recognizes generated files by. In the json
+// format, the file is converted to Terraform JSON syntax and written next to it as .tf.json, removing the .tf file.
+func writeTF(path string, newFile *hclwrite.File, format string) error {
+	contents := append([]byte(configs.GeneratedTFHeader+"\n\n"), bytes.TrimRight(newFile.Bytes(), "\n")...)
+	contents = append(contents, '\n')
+
+	if format == configs.JSONFormat {
+		module, err := document.Parse(filepath.Base(path), newFile.Bytes())
+		if err != nil {
+			return err
+		}
+
+		contents, err = module.JSON(configs.GeneratedTFComment)
+		if err != nil {
+			return err
+		}
+
+		err = os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("Failed to delete %s file. Error: %v", filepath.Base(path), err)
+			return err
+		}
+
+		path += configs.JSONExtension
+	}
+
+	err := os.WriteFile(path, contents, 0644)
+	if err != nil {
+		logrus.Errorf("Failed to write %s file. Error: %v", filepath.Base(path), err)
+		return err
+	}
+
+	return nil
 }
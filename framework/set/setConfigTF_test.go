@@ -0,0 +1,360 @@
+package set
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/golden"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fixturesDir = "testdata/fixtures"
+	goldenDir   = "testdata/golden"
+	testUser    = "tfp-test-user"
+	testPass    = "tfp-test-password"
+)
+
+// setProviderVersions sets the provider versions the generators read from the environment.
+func setProviderVersions(t *testing.T) {
+	t.Setenv("RANCHER2_PROVIDER_VERSION", "5.1.0")
+	t.Setenv("AWS_PROVIDER_VERSION", "5.53.0")
+	t.Setenv("LOCALS_PROVIDER_VERSION", "2.5.1")
+	t.Setenv("RKE_PROVIDER_VERSION", "1.7.0")
+	t.Setenv("TERRAFORM_BINARY", "")
+}
+
+func TestConfigTFGolden(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join(fixturesDir, "*.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures)
+
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), filepath.Ext(fixture))
+
+		t.Run(name, func(t *testing.T) {
+			setProviderVersions(t)
+			golden.SetupHome(t)
+			keyPath := t.TempDir()
+
+			configMap := golden.LoadFixture(t, fixture)
+
+			isWindows := false
+			for _, cattleConfig := range configMap {
+				rancherConfig, terraformConfig, terratestConfig := config.LoadTFPConfigs(cattleConfig)
+				require.NoError(t, config.Validate(terraformConfig.Module, rancherConfig, terraformConfig, terratestConfig))
+
+				isWindows = isWindows || terratestConfig.WindowsNodeCount > 0
+			}
+
+			clusterNames, err := ConfigTF(keyPath, "", testUser, testPass, "", configMap, isWindows)
+			require.NoError(t, err)
+			require.Len(t, clusterNames, len(configMap))
+
+			requireNoInlinedSecrets(t, keyPath)
+			golden.AssertDir(t, filepath.Join(goldenDir, name), keyPath)
+		})
+	}
+}
+
+func TestConfigTFOpenTofuProviderSources(t *testing.T) {
+	setProviderVersions(t)
+	t.Setenv("TERRAFORM_BINARY", "tofu")
+	golden.SetupHome(t)
+	keyPath := t.TempDir()
+
+	configMap := golden.LoadFixture(t, filepath.Join(fixturesDir, "custom_ec2_rke1.yaml"))
+
+	_, err := ConfigTF(keyPath, "", testUser, testPass, "", configMap, false)
+	require.NoError(t, err)
+
+	providersTF, err := os.ReadFile(keyPath + configs.ProvidersTF)
+	require.NoError(t, err)
+
+	require.Contains(t, string(providersTF), `"registry.opentofu.org/rancher/rancher2"`)
+	require.Contains(t, string(providersTF), `"registry.opentofu.org/hashicorp/aws"`)
+	require.Contains(t, string(providersTF), `"registry.opentofu.org/hashicorp/local"`)
+}
+
+// requireNoInlinedSecrets fails the test if any value written to terraform.tfvars.json also appears in main.tf or in a
+// .tf file written by ConfigTF.
+func requireNoInlinedSecrets(t *testing.T, keyPath string) {
+	tfvarsJSON, err := os.ReadFile(keyPath + configs.TFVarsJSON)
+	require.NoError(t, err)
+
+	tfvars := map[string]string{}
+	require.NoError(t, json.Unmarshal(tfvarsJSON, &tfvars))
+
+	generatedFiles, err := cleanup.GeneratedFiles(keyPath)
+	require.NoError(t, err)
+
+	if _, err := os.Stat(keyPath + configs.MainTF); err == nil {
+		generatedFiles = append(generatedFiles, keyPath+configs.MainTF)
+	}
+
+	require.NotEmpty(t, generatedFiles)
+
+	for _, generatedFile := range generatedFiles {
+		contents, err := os.ReadFile(generatedFile)
+		require.NoError(t, err)
+
+		for name, value := range tfvars {
+			if value != "" {
+				require.NotContains(t, string(contents), value, "the value of variable %s is inlined in %s", name,
+					filepath.Base(generatedFile))
+			}
+		}
+	}
+}
+
+func TestConfigTFGoldenOutputParses(t *testing.T) {
+	goldenFiles, err := filepath.Glob(filepath.Join(goldenDir, "*", "*.golden.tf"))
+	require.NoError(t, err)
+
+	for _, goldenFile := range goldenFiles {
+		content, err := os.ReadFile(goldenFile)
+		require.NoError(t, err)
+
+		golden.RequireValidHCL(t, goldenFile, content)
+	}
+
+	jsonGoldenFiles, err := filepath.Glob(filepath.Join(goldenDir, "*", "*.tf.golden.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, jsonGoldenFiles)
+
+	for _, jsonGoldenFile := range jsonGoldenFiles {
+		_, diags := hcljson.ParseFile(jsonGoldenFile)
+		require.False(t, diags.HasErrors(), "%s is not valid Terraform JSON: %s", jsonGoldenFile, diags.Error())
+	}
+}
+
+// topLevelSchema is the schema of the top-level blocks the generators write.
+var topLevelSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "data", LabelNames: []string{"type", "name"}},
+		{Type: "locals"},
+		{Type: "output", LabelNames: []string{"name"}},
+		{Type: "provider", LabelNames: []string{"name"}},
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "terraform"},
+		{Type: "variable", LabelNames: []string{"name"}},
+	},
+}
+
+// topLevelBlocks returns the type and labels of every top-level block of the given file, and the name of every local.
+func topLevelBlocks(t *testing.T, file *hcl.File) []string {
+	content, diags := file.Body.Content(topLevelSchema)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	blocks := []string{}
+	for _, block := range content.Blocks {
+		blocks = append(blocks, strings.Join(append([]string{block.Type}, block.Labels...), "."))
+
+		if block.Type == "locals" {
+			attributes, diags := block.Body.JustAttributes()
+			require.False(t, diags.HasErrors(), diags.Error())
+
+			for name := range attributes {
+				blocks = append(blocks, "local."+name)
+			}
+		}
+	}
+
+	return blocks
+}
+
+func TestConfigTFJSONMatchesHCL(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join(fixturesDir, "*.yaml"))
+	require.NoError(t, err)
+
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), filepath.Ext(fixture))
+
+		t.Run(name, func(t *testing.T) {
+			setProviderVersions(t)
+			golden.SetupHome(t)
+			hclKeyPath := t.TempDir()
+			jsonKeyPath := t.TempDir()
+
+			configMap := golden.LoadFixture(t, fixture)
+			for _, cattleConfig := range configMap {
+				cattleConfig[config.TerraformConfigurationFileKey].(map[string]any)["outputFormat"] = configs.HCLFormat
+			}
+
+			_, err := ConfigTF(hclKeyPath, "", testUser, testPass, "", configMap, false)
+			require.NoError(t, err)
+
+			for _, cattleConfig := range configMap {
+				cattleConfig[config.TerraformConfigurationFileKey].(map[string]any)["outputFormat"] = configs.JSONFormat
+			}
+
+			clusterNames, err := ConfigTF(jsonKeyPath, "", testUser, testPass, "", configMap, false)
+			require.NoError(t, err)
+
+			for _, clusterName := range clusterNames {
+				hclTargets, err := cleanup.ClusterTargets(hclKeyPath, clusterName)
+				require.NoError(t, err)
+
+				jsonTargets, err := cleanup.ClusterTargets(jsonKeyPath, clusterName)
+				require.NoError(t, err)
+				require.ElementsMatch(t, hclTargets, jsonTargets)
+			}
+
+			hclFiles, err := cleanup.GeneratedFiles(hclKeyPath)
+			require.NoError(t, err)
+
+			hclFiles = append(hclFiles, hclKeyPath+configs.VariablesTF)
+
+			jsonFiles, err := cleanup.GeneratedFiles(jsonKeyPath)
+			require.NoError(t, err)
+			require.Len(t, jsonFiles, len(hclFiles))
+
+			for _, hclFile := range hclFiles {
+				jsonFile := jsonKeyPath + "/" + filepath.Base(hclFile) + configs.JSONExtension
+				require.NoFileExists(t, strings.TrimSuffix(jsonFile, configs.JSONExtension))
+
+				hclContent, err := os.ReadFile(hclFile)
+				require.NoError(t, err)
+
+				hclModule, diags := hclsyntax.ParseConfig(hclContent, hclFile, hcl.InitialPos)
+				require.False(t, diags.HasErrors(), diags.Error())
+
+				jsonModule, diags := hcljson.ParseFile(jsonFile)
+				require.False(t, diags.HasErrors(), diags.Error())
+
+				require.ElementsMatch(t, topLevelBlocks(t, hclModule), topLevelBlocks(t, jsonModule), filepath.Base(jsonFile))
+			}
+		})
+	}
+}
+
+func TestConfigTFRejectsDuplicateResourcePrefixes(t *testing.T) {
+	setProviderVersions(t)
+	golden.SetupHome(t)
+
+	configMap := golden.LoadFixture(t, filepath.Join(fixturesDir, "eks.yaml"))
+	configMap = append(configMap, configMap[0])
+
+	_, err := ConfigTF(t.TempDir(), "", testUser, testPass, "", configMap, false)
+	require.ErrorContains(t, err, "resourcePrefix tfp-eks is used by more than one cluster")
+}
+
+func TestConfigTFProviderVersionsFromConfig(t *testing.T) {
+	setProviderVersions(t)
+	t.Setenv("RANCHER2_PROVIDER_VERSION", "")
+	t.Setenv("LOCALS_PROVIDER_VERSION", "")
+	golden.SetupHome(t)
+	keyPath := t.TempDir()
+
+	configMap := golden.LoadFixture(t, filepath.Join(fixturesDir, "custom_ec2_rke1.yaml"))
+
+	_, err := ConfigTF(keyPath, "", testUser, testPass, "", configMap, false)
+	require.ErrorContains(t, err, "no version set for the rancher2 provider")
+	require.ErrorContains(t, err, "no version set for the local provider")
+
+	terraformConfig := configMap[0][config.TerraformConfigurationFileKey].(map[string]any)
+	terraformConfig["providers"] = map[string]any{
+		"rancher2": map[string]any{"version": "5.1.0"},
+		"local":    map[string]any{"source": "example/local", "version": "~> 2.5"},
+		"aws":      map[string]any{"version": "5.0.0"},
+	}
+
+	_, err = ConfigTF(keyPath, "", testUser, testPass, "", configMap, false)
+	require.NoError(t, err)
+
+	providersTF, err := os.ReadFile(keyPath + configs.ProvidersTF)
+	require.NoError(t, err)
+
+	require.Contains(t, string(providersTF), `rancher2 = {
+      source  = "rancher/rancher2"
+      version = "5.1.0"`)
+	require.Contains(t, string(providersTF), `local = {
+      source  = "example/local"
+      version = "~> 2.5"`)
+	require.Contains(t, string(providersTF), `aws = {
+      source  = "hashicorp/aws"
+      version = "5.53.0"`, "the version environment variable overrides the config")
+}
+
+func TestConfigTFSplitsClusters(t *testing.T) {
+	setProviderVersions(t)
+	golden.SetupHome(t)
+	keyPath := t.TempDir()
+
+	require.NoError(t, os.WriteFile(keyPath+configs.MainTF, []byte(`resource "rancher2_user" "stale" {}`), 0644))
+	require.NoError(t, os.WriteFile(keyPath+configs.OutputsTF, []byte(`output "kept" {}`), 0644))
+	require.NoError(t, os.WriteFile(keyPath+"/tfp-removed.tf", []byte(configs.GeneratedTFHeader+"\n"), 0644))
+
+	configMap := golden.LoadFixture(t, filepath.Join(fixturesDir, "multi_cluster.yaml"))
+
+	clusterNames, err := ConfigTF(keyPath, "", testUser, testPass, "", configMap, false)
+	require.NoError(t, err)
+
+	expectedFiles := []string{keyPath + configs.LocalsTF, keyPath + configs.ProvidersTF, keyPath + configs.UsersTF}
+	for _, clusterName := range clusterNames {
+		expectedFiles = append(expectedFiles, keyPath+"/"+clusterName+configs.TFExtension)
+
+		targets, err := cleanup.ClusterTargets(keyPath, clusterName)
+		require.NoError(t, err)
+		require.Contains(t, targets, "rancher2_cluster_v2."+clusterName)
+	}
+
+	generatedFiles, err := cleanup.GeneratedFiles(keyPath)
+	require.NoError(t, err)
+	require.ElementsMatch(t, expectedFiles, generatedFiles)
+
+	mainTF, err := os.ReadFile(keyPath + configs.MainTF)
+	require.NoError(t, err)
+	require.Equal(t, configs.MainTFPlaceholder, string(mainTF))
+
+	require.NoError(t, cleanup.RemoveGeneratedFiles(keyPath))
+	require.FileExists(t, keyPath+configs.OutputsTF)
+	require.NoFileExists(t, keyPath+configs.ProvidersTF)
+}
+
+func TestConfigTFRejectsReservedResourcePrefixes(t *testing.T) {
+	setProviderVersions(t)
+	golden.SetupHome(t)
+
+	configMap := golden.LoadFixture(t, filepath.Join(fixturesDir, "eks.yaml"))
+	terraformConfig := configMap[0][config.TerraformConfigurationFileKey].(map[string]any)
+	terraformConfig["resourcePrefix"] = "providers"
+
+	_, err := ConfigTF(t.TempDir(), "", testUser, testPass, "", configMap, false)
+	require.ErrorContains(t, err, "resourcePrefix providers is reserved")
+}
+
+func TestConfigTFBackendKeepsCredentialsOut(t *testing.T) {
+	setProviderVersions(t)
+	golden.SetupHome(t)
+	keyPath := t.TempDir()
+
+	configMap := golden.LoadFixture(t, filepath.Join(fixturesDir, "s3_backend_ec2_rke2.yaml"))
+
+	_, err := ConfigTF(keyPath, "", testUser, testPass, "", configMap, false)
+	require.NoError(t, err)
+
+	providersTF, err := os.ReadFile(keyPath + configs.ProvidersTF)
+	require.NoError(t, err)
+	require.Contains(t, string(providersTF), `backend "s3"`)
+
+	files, err := filepath.Glob(filepath.Join(keyPath, "*"))
+	require.NoError(t, err)
+
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		require.NotContains(t, string(contents), "fake-minio-access-key", filepath.Base(file))
+		require.NotContains(t, string(contents), "fake-minio-secret-key", filepath.Base(file))
+	}
+}
@@ -0,0 +1,94 @@
+package set
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/format"
+	"github.com/rancher/tfp-automation/framework/redact"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/provisioning/imported"
+	"github.com/rancher/tfp-automation/framework/set/provisioning/providers/aws"
+	"github.com/rancher/tfp-automation/framework/set/rbac"
+	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework/set/tags"
+	"github.com/rancher/tfp-automation/framework/set/variables"
+	"github.com/sirupsen/logrus"
+)
+
+// ImportTF is a function that will set the main.tf file in the given working directory to the resources the import
+// tests create outside of Terraform. Every config gets an imported cluster, a project in that cluster and a PSACT, all
+// named after its resourcePrefix, and an AWS cloud credential when AWS credentials are set. The test user and its
+// global role binding are set like in ConfigTF, and so is the given test name.
+func ImportTF(keyPath, testName, testUser, testPassword string, configMap []map[string]any) ([]string, error) {
+	// The files ConfigTF writes would declare the providers and the test user a second time.
+	err := cleanup.RemoveGeneratedFiles(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	newFile, rootBody, err := resources.SetProvidersAndUsersTF(testUser, testPassword, false, configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	rootBody.AppendNewline()
+
+	resourcePrefixes := []string{}
+	values := variables.Values{}
+
+	for i, cattleConfig := range configMap {
+		rancherConfig, terraform, _ := config.LoadTFPConfigs(cattleConfig)
+		redact.Register(rancherConfig, terraform)
+
+		terraform = tags.WithTestName(terraform, testName)
+
+		if i == 0 {
+			values.Merge(variables.ProviderValues(rancherConfig, terraform))
+		}
+
+		values.Merge(variables.ClusterValues(terraform))
+
+		if slices.Contains(resourcePrefixes, terraform.ResourcePrefix) {
+			return resourcePrefixes, fmt.Errorf("resourcePrefix %s is used by more than one cluster, every cluster in the same "+
+				"module needs its own resourcePrefix", terraform.ResourcePrefix)
+		}
+
+		resourcePrefixes = append(resourcePrefixes, terraform.ResourcePrefix)
+
+		err := imported.SetImportedCluster(rootBody, terraform.ResourcePrefix)
+		if err != nil {
+			return resourcePrefixes, err
+		}
+
+		rootBody.AppendNewline()
+
+		clusterBlockID := format.Reference(defaults.Cluster, terraform.ResourcePrefix, defaults.ID)
+		rbac.SetProject(rootBody, terraform.ResourcePrefix, terraform.ResourcePrefix, clusterBlockID)
+
+		if terraform.AWSCredentials.AWSAccessKey != "" {
+			aws.SetAWSRKE2K3SProvider(rootBody, terraform)
+			rootBody.AppendNewline()
+		}
+
+		resources.SetPSACT(newFile, rootBody, terraform.ResourcePrefix, terraform.ResourcePrefix)
+		rootBody.AppendNewline()
+	}
+
+	err = os.WriteFile(keyPath+configs.MainTF, newFile.Bytes(), 0644)
+	if err != nil {
+		logrus.Infof("Failed to write import configurations to main.tf file. Error: %v", err)
+		return resourcePrefixes, err
+	}
+
+	err = variables.WriteFiles(keyPath, newFile.Bytes(), values)
+	if err != nil {
+		return resourcePrefixes, err
+	}
+
+	return resourcePrefixes, nil
+}
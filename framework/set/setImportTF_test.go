@@ -0,0 +1,26 @@
+package set
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rancher/tfp-automation/framework/set/golden"
+	"github.com/stretchr/testify/require"
+)
+
+const importGoldenDir = "import"
+
+func TestImportTFGolden(t *testing.T) {
+	setProviderVersions(t)
+	golden.SetupHome(t)
+	keyPath := t.TempDir()
+
+	configMap := golden.LoadFixture(t, filepath.Join(fixturesDir, "multi_cluster.yaml"))
+
+	resourcePrefixes, err := ImportTF(keyPath, "", testUser, testPass, configMap)
+	require.NoError(t, err)
+	require.Len(t, resourcePrefixes, len(configMap))
+
+	requireNoInlinedSecrets(t, keyPath)
+	golden.AssertDir(t, filepath.Join(goldenDir, importGoldenDir), keyPath)
+}
@@ -0,0 +1,190 @@
+package tags
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	BuildNumber    = "BuildNumber"
+	ExpiresAt      = "ExpiresAt"
+	JobName        = "JobName"
+	Owner          = "Owner"
+	ResourcePrefix = "ResourcePrefix"
+	TestName       = "TestName"
+
+	BuildNumberEnvVar = "BUILD_NUMBER"
+	JobNameEnvVar     = "JOB_NAME"
+	TestNameEnvVar    = "TFP_TEST_NAME"
+
+	expiresAtKey = "expiresAt"
+	tagsKey      = "tags"
+	ttlKey       = "ttl"
+
+	// maxLinodeTagLength is the longest tag Linode accepts.
+	maxLinodeTagLength = 50
+)
+
+// invalidValueCharacters matches the characters AWS does not allow in tag keys and values. Commas are not allowed
+// either, as the node driver machine configs take their tags as a comma separated list.
+var invalidValueCharacters = regexp.MustCompile(`[^\p{L}\p{N} _.:/=+\-@]`)
+
+// Get is a function that will return the ownership tags of the given Terraform config: the custom tags, then the
+// owner, job name, build number, expiry and test name, each when set, and always the resourcePrefix. The job name,
+// build number and test name default to the JOB_NAME, BUILD_NUMBER and TFP_TEST_NAME environment variables, which are
+// only read, the test name being set in the config with WithTestName. When a TTL is set without an expiry, the expiry
+// is stamped into the config, so that regenerating the configuration during the same run does not change it.
+func Get(terraformConfig *config.TerraformConfig) map[string]string {
+	tags := map[string]string{}
+
+	configTags := terraformConfig.Tags
+	if configTags == nil {
+		configTags = &config.Tags{}
+	}
+
+	for key, value := range configTags.Custom {
+		tags[key] = value
+	}
+
+	if configTags.TTL != "" && configTags.ExpiresAt == "" {
+		configTags.ExpiresAt = expiry(configTags.TTL, time.Now())
+	}
+
+	set(tags, Owner, configTags.Owner)
+	set(tags, JobName, fallback(configTags.JobName, JobNameEnvVar))
+	set(tags, BuildNumber, fallback(configTags.BuildNumber, BuildNumberEnvVar))
+	set(tags, ExpiresAt, configTags.ExpiresAt)
+	set(tags, TestName, fallback(configTags.TestName, TestNameEnvVar))
+	set(tags, ResourcePrefix, terraformConfig.ResourcePrefix)
+
+	for key, value := range tags {
+		tags[key] = strings.TrimSpace(invalidValueCharacters.ReplaceAllString(value, "_"))
+	}
+
+	return tags
+}
+
+// WithTestName is a function that will return a copy of the given Terraform config whose tags carry the given test
+// name, unless a test name is configured. The given config is left as is, as it is shared by the tests of a suite,
+// which may run in parallel.
+func WithTestName(terraformConfig *config.TerraformConfig, testName string) *config.TerraformConfig {
+	if testName == "" || (terraformConfig.Tags != nil && terraformConfig.Tags.TestName != "") {
+		return terraformConfig
+	}
+
+	configTags := config.Tags{}
+	if terraformConfig.Tags != nil {
+		configTags = *terraformConfig.Tags
+	}
+
+	configTags.TestName = testName
+
+	named := *terraformConfig
+	named.Tags = &configTags
+
+	return &named
+}
+
+// SetExpiry is a function that will stamp the expiry into the given cattle config when a TTL is set without one. The
+// cattle configs are reloaded each time the configuration is generated, so the expiry is kept in them rather than in
+// the loaded Terraform config.
+func SetExpiry(cattleConfig map[string]any, now time.Time) error {
+	ttl, _ := operations.GetValue([]string{config.TerraformConfigurationFileKey, tagsKey, ttlKey}, cattleConfig)
+	expiresAt, _ := operations.GetValue([]string{config.TerraformConfigurationFileKey, tagsKey, expiresAtKey}, cattleConfig)
+
+	ttlValue, _ := ttl.(string)
+	if ttlValue == "" || expiresAt != nil {
+		return nil
+	}
+
+	_, err := operations.ReplaceValue([]string{config.TerraformConfigurationFileKey, tagsKey, expiresAtKey}, expiry(ttlValue, now), cattleConfig)
+
+	return err
+}
+
+// SetBlock is a function that will set the given tags, sorted by key, in the given tags block. Keys that are not
+// valid identifiers are quoted.
+func SetBlock(tagsBlockBody *hclwrite.Body, tags map[string]string) {
+	for _, key := range keys(tags) {
+		name := key
+		if !hclsyntax.ValidIdentifier(key) {
+			name = string(hclwrite.TokensForValue(cty.StringVal(key)).Bytes())
+		}
+
+		tagsBlockBody.SetAttributeValue(name, cty.StringVal(tags[key]))
+	}
+}
+
+// EC2 is a function that will return the given tags in the key1,value1,key2,value2 form taken by the amazonec2 node
+// driver.
+func EC2(tags map[string]string) string {
+	var pairs []string
+	for _, key := range keys(tags) {
+		pairs = append(pairs, key, tags[key])
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// Linode is a function that will return the given tags in the key:value,key:value form taken by the linode node
+// driver. Linode tags are not key value pairs, so each is truncated to the longest tag Linode accepts.
+func Linode(tags map[string]string) string {
+	var pairs []string
+	for _, key := range keys(tags) {
+		pair := []rune(key + ":" + tags[key])
+		if len(pair) > maxLinodeTagLength {
+			pair = pair[:maxLinodeTagLength]
+		}
+
+		pairs = append(pairs, string(pair))
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// expiry returns the RFC 3339 time the given TTL runs out at, or an empty string if it is not a duration.
+func expiry(ttl string, now time.Time) string {
+	duration, err := time.ParseDuration(ttl)
+	if err != nil {
+		return ""
+	}
+
+	return now.Add(duration).UTC().Format(time.RFC3339)
+}
+
+// fallback returns the given value, or the value of the given environment variable if it is empty.
+func fallback(value, envVar string) string {
+	if value != "" {
+		return value
+	}
+
+	return os.Getenv(envVar)
+}
+
+// set sets the given tag when its value is not empty.
+func set(tags map[string]string, key, value string) {
+	if value != "" {
+		tags[key] = value
+	}
+}
+
+// keys returns the keys of the given tags, sorted.
+func keys(tags map[string]string) []string {
+	var sorted []string
+	for key := range tags {
+		sorted = append(sorted, key)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted
+}
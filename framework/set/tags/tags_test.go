@@ -0,0 +1,98 @@
+package tags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+func TestGet(t *testing.T) {
+	t.Setenv(JobNameEnvVar, "tfp-automation-nightly")
+	t.Setenv(BuildNumberEnvVar, "42")
+	t.Setenv(TestNameEnvVar, "TestProvisioning/RKE2_->_3_nodes")
+
+	terraformConfig := &config.TerraformConfig{
+		ResourcePrefix: "tfp-abc",
+		Tags: &config.Tags{
+			Owner:       "qa-team",
+			BuildNumber: "7",
+			TTL:         "6h",
+			Custom:      map[string]string{"team": "qa, rancher", Owner: "overridden"},
+		},
+	}
+
+	tags := Get(terraformConfig)
+	require.NotEmpty(t, terraformConfig.Tags.ExpiresAt)
+
+	delete(tags, ExpiresAt)
+	require.Equal(t, map[string]string{
+		BuildNumber:    "7",
+		JobName:        "tfp-automation-nightly",
+		Owner:          "qa-team",
+		ResourcePrefix: "tfp-abc",
+		TestName:       "TestProvisioning/RKE2_-__3_nodes",
+		"team":         "qa_ rancher",
+	}, tags)
+
+	expiresAt := terraformConfig.Tags.ExpiresAt
+	require.Equal(t, expiresAt, Get(terraformConfig)[ExpiresAt])
+}
+
+func TestGetWithoutTags(t *testing.T) {
+	t.Setenv(JobNameEnvVar, "")
+	t.Setenv(BuildNumberEnvVar, "")
+	t.Setenv(TestNameEnvVar, "")
+
+	require.Equal(t, map[string]string{ResourcePrefix: "tfp-abc"}, Get(&config.TerraformConfig{ResourcePrefix: "tfp-abc"}))
+}
+
+func TestSetExpiry(t *testing.T) {
+	cattleConfig := map[string]any{config.TerraformConfigurationFileKey: map[string]any{tagsKey: map[string]any{ttlKey: "6h"}}}
+
+	require.NoError(t, SetExpiry(cattleConfig, now))
+	require.NoError(t, SetExpiry(cattleConfig, now.Add(time.Hour)))
+
+	_, terraformConfig, _ := config.LoadTFPConfigs(cattleConfig)
+	require.Equal(t, "2026-01-02T18:00:00Z", terraformConfig.Tags.ExpiresAt)
+
+	withoutTTL := map[string]any{config.TerraformConfigurationFileKey: map[string]any{}}
+	require.NoError(t, SetExpiry(withoutTTL, now))
+	require.Equal(t, map[string]any{config.TerraformConfigurationFileKey: map[string]any{}}, withoutTTL)
+}
+
+func TestWithTestName(t *testing.T) {
+	t.Setenv(TestNameEnvVar, "")
+
+	shared := &config.TerraformConfig{ResourcePrefix: "tfp", Tags: &config.Tags{Owner: "qa"}}
+
+	named := WithTestName(shared, "TestProvisioning/RKE2")
+	require.Equal(t, "TestProvisioning/RKE2", Get(named)[TestName])
+	require.Equal(t, "qa", Get(named)[Owner])
+	require.Empty(t, shared.Tags.TestName)
+
+	shared.Tags.TestName = "configured"
+	require.Equal(t, "configured", Get(WithTestName(shared, "TestProvisioning/RKE2"))[TestName])
+
+	require.Equal(t, "TestScale", Get(WithTestName(&config.TerraformConfig{ResourcePrefix: "tfp"}, "TestScale"))[TestName])
+}
+
+func TestFormats(t *testing.T) {
+	tags := map[string]string{ResourcePrefix: "tfp-abc", Owner: "qa-team", "kubernetes.io/purpose": "a-purpose-long-enough-to-be-truncated"}
+
+	require.Equal(t, "Owner,qa-team,ResourcePrefix,tfp-abc,kubernetes.io/purpose,a-purpose-long-enough-to-be-truncated", EC2(tags))
+	require.Equal(t, "Owner:qa-team,ResourcePrefix:tfp-abc,kubernetes.io/purpose:a-purpose-long-enough-to-be-", Linode(tags))
+
+	file := hclwrite.NewEmptyFile()
+	SetBlock(file.Body().AppendNewBlock("tags =", nil).Body(), tags)
+
+	_, diags := hclsyntax.ParseConfig(file.Bytes(), "main.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+	require.Contains(t, string(file.Bytes()), `"kubernetes.io/purpose" = "a-purpose-long-enough-to-be-truncated"`)
+}
@@ -0,0 +1,59 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+resource "rancher2_cloud_credential" "tfp-aks" {
+  name = "tfp-aks"
+  azure_credential_config {
+    client_id       = "fake-client-id"
+    client_secret   = var.tfp-aks_azure_client_secret
+    subscription_id = "fake-subscription-id"
+    tenant_id       = "fake-tenant-id"
+  }
+}
+
+resource "rancher2_cluster" "tfp-aks" {
+  name = "tfp-aks"
+  aks_config_v2 {
+    cloud_credential_id        = rancher2_cloud_credential.tfp-aks.id
+    outbound_type              = "loadBalancer"
+    resource_group             = "tfp-resource-group"
+    resource_location          = "eastus"
+    dns_prefix                 = "tfp-aks"
+    kubernetes_version         = "1.30.5"
+    network_plugin             = ""
+    virtual_network            = "tfp-vnet"
+    subnet                     = "tfp-subnet"
+    network_dns_service_ip     = "10.0.0.10"
+    network_docker_bridge_cidr = "172.17.0.1/16"
+    network_service_cidr       = "10.0.0.0/16"
+    node_pools {
+      availability_zones   = ["1", "2", "3"]
+      mode                 = "System"
+      name                 = "agentpool"
+      count                = 1
+      orchestrator_version = "1.30.5"
+      os_disk_size_gb      = 128
+      vm_size              = "Standard_DS2_v2"
+      taints               = ["none:PreferNoSchedule"]
+    }
+    node_pools {
+      availability_zones   = ["1", "2", "3"]
+      mode                 = "System"
+      name                 = "agentpool"
+      count                = 2
+      orchestrator_version = "1.30.5"
+      os_disk_size_gb      = 128
+      vm_size              = "Standard_DS2_v2"
+      taints               = ["none:PreferNoSchedule"]
+    }
+  }
+}
+
+output "tfp-aks" {
+  value = {
+    cluster_id         = rancher2_cluster.tfp-aks.id
+    kubeconfig         = rancher2_cluster.tfp-aks.kube_config
+    registration_token = rancher2_cluster.tfp-aks.cluster_registration_token[0].token
+    v1_cluster_id      = rancher2_cluster.tfp-aks.id
+  }
+  sensitive = true
+}
@@ -0,0 +1,9 @@
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-aks_azure_client_secret" {
+  type      = string
+  sensitive = true
+}
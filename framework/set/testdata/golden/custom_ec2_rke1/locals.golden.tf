@@ -0,0 +1,5 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+locals {
+  role_flags = ["--etcd", "--controlplane", "--worker"]
+}
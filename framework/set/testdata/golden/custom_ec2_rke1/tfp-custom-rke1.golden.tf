@@ -0,0 +1,63 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+resource "aws_instance" "tfp-custom-rke1" {
+  count                  = 3
+  ami                    = "ami-aaaaaaaa"
+  instance_type          = "t3.xlarge"
+  subnet_id              = "subnet-aaaaaaaa"
+  vpc_security_group_ids = ["sg-aaaaaaaa"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name           = "tfp-custom-rke1-tfp-custom-rke1-${count.index}"
+    ResourcePrefix = "tfp-custom-rke1"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["cloud-init status --wait"]
+  }
+}
+resource "rancher2_cluster" "tfp-custom-rke1" {
+  name = "tfp-custom-rke1"
+  rke_config {
+    kubernetes_version = "v1.30.5-rancher1-1"
+    network {
+      plugin = ""
+    }
+  }
+}
+resource "null_resource" "register_nodes-tfp-custom-rke1" {
+  count = length(aws_instance.tfp-custom-rke1)
+  provisioner "remote-exec" {
+    inline = ["${rancher2_cluster.tfp-custom-rke1.cluster_registration_token[0].node_command} ${local.role_flags[count.index]}"]
+    connection {
+      type        = "ssh"
+      user        = "ubuntu"
+      host        = aws_instance.tfp-custom-rke1[count.index].public_ip
+      private_key = file("testdata/id_rsa")
+    }
+  }
+  depends_on = [rancher2_cluster.tfp-custom-rke1]
+}
+
+output "tfp-custom-rke1" {
+  value = {
+    cluster_id         = rancher2_cluster.tfp-custom-rke1.id
+    kubeconfig         = rancher2_cluster.tfp-custom-rke1.kube_config
+    registration_token = rancher2_cluster.tfp-custom-rke1.cluster_registration_token[0].token
+    v1_cluster_id      = rancher2_cluster.tfp-custom-rke1.id
+  }
+  sensitive = true
+}
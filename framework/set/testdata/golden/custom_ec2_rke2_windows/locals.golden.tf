@@ -0,0 +1,9 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+locals {
+  role_flags                                   = ["--etcd", "--controlplane", "--worker"]
+  tfp-custom-win_original_node_command         = rancher2_cluster_v2.tfp-custom-win.cluster_registration_token[0].node_command
+  tfp-custom-win_windows_original_node_command = rancher2_cluster_v2.tfp-custom-win.cluster_registration_token[0].windows_node_command
+  tfp-custom-win_insecure_node_command         = "${replace(local.tfp-custom-win_original_node_command, "curl", "curl --insecure")}"
+  tfp-custom-win_insecure_windows_node_command = "${replace(local.tfp-custom-win_windows_original_node_command, "curl.exe", "curl.exe --insecure")}"
+}
@@ -0,0 +1,111 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+resource "aws_instance" "tfp-custom-win" {
+  count                  = 3
+  ami                    = "ami-aaaaaaaa"
+  instance_type          = "t3.xlarge"
+  subnet_id              = "subnet-aaaaaaaa"
+  vpc_security_group_ids = ["sg-aaaaaaaa"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name           = "tfp-custom-win-tfp-custom-win-${count.index}"
+    ResourcePrefix = "tfp-custom-win"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["cloud-init status --wait"]
+  }
+}
+
+resource "aws_instance" "tfp-custom-win-windows" {
+  count                  = 1
+  ami                    = "ami-bbbbbbbb"
+  instance_type          = "t3.xlarge"
+  subnet_id              = "subnet-aaaaaaaa"
+  vpc_security_group_ids = ["sg-aaaaaaaa"]
+  key_name               = "tfp-windows-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name           = "tfp-custom-win-windows-${count.index}"
+    ResourcePrefix = "tfp-custom-win"
+  }
+
+  connection {
+    type            = "ssh"
+    user            = "Administrator"
+    host            = self.public_ip
+    target_platform = "windows"
+    private_key     = file("testdata/id_rsa")
+    timeout         = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["echo Connected!!!"]
+  }
+}
+
+resource "rancher2_cluster_v2" "tfp-custom-win" {
+  name               = "tfp-custom-win"
+  kubernetes_version = "v1.30.5+rke2r1"
+  rke_config {
+    machine_global_config = <<EOF
+cni: calico
+EOF
+  }
+  depends_on = [aws_instance.tfp-custom-win-windows]
+}
+
+resource "null_resource" "register_nodes-tfp-custom-win" {
+  count = length(aws_instance.tfp-custom-win)
+  provisioner "remote-exec" {
+    connection {
+      type        = "ssh"
+      user        = "ubuntu"
+      host        = aws_instance.tfp-custom-win[count.index].public_ip
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["${local.tfp-custom-win_insecure_node_command} ${local.role_flags[count.index]}"]
+  }
+  depends_on = [rancher2_cluster_v2.tfp-custom-win]
+}
+
+resource "null_resource" "register_nodes-tfp-custom-win-windows" {
+  count = length(aws_instance.tfp-custom-win-windows)
+  provisioner "remote-exec" {
+    connection {
+      type            = "ssh"
+      user            = "Administrator"
+      host            = aws_instance.tfp-custom-win-windows[count.index].public_ip
+      target_platform = "windows"
+      private_key     = file("testdata/id_rsa")
+    }
+    inline = ["powershell.exe ${local.tfp-custom-win_insecure_windows_node_command}"]
+  }
+}
+
+output "tfp-custom-win" {
+  value = {
+    cluster_id         = rancher2_cluster_v2.tfp-custom-win.cluster_v1_id
+    kubeconfig         = rancher2_cluster_v2.tfp-custom-win.kube_config
+    registration_token = rancher2_cluster_v2.tfp-custom-win.cluster_registration_token[0].token
+    v1_cluster_id      = rancher2_cluster_v2.tfp-custom-win.id
+  }
+  sensitive = true
+}
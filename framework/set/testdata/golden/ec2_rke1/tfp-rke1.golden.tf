@@ -0,0 +1,101 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+resource "rancher2_node_template" "tfp-rke1" {
+  name = "tfp-rke1"
+  amazonec2_config {
+    access_key     = var.tfp-rke1_aws_access_key
+    secret_key     = var.tfp-rke1_aws_secret_key
+    region         = "us-east-2"
+    ami            = "ami-aaaaaaaa"
+    instance_type  = "t3.medium"
+    ssh_user       = ""
+    volume_type    = ""
+    root_size      = 80
+    security_group = ["tfp-security-group"]
+    subnet_id      = "subnet-aaaaaaaa"
+    vpc_id         = "vpc-aaaaaaaa"
+    zone           = "a"
+    tags           = "ResourcePrefix,tfp-rke1"
+  }
+}
+
+resource "rancher2_pod_security_admission_configuration_template" "tfp-rke1" {
+  name        = "rancher-baseline"
+  description = "This is a custom baseline Pod Security Admission Configuration Template.It defines a minimally restrictive policy which prevents known privilege escalations. This policy contains namespace level exemptions for Rancher components."
+  defaults {
+    audit           = "baseline"
+    audit_version   = "latest"
+    enforce         = "baseline"
+    enforce_version = "latest"
+    warn            = "baseline"
+    warn_version    = "latest"
+  }
+  exemptions {
+    namespaces = ["ingress-nginx", "kube-system", "cattle-system", "cattle-epinio-system", "cattle-fleet-system", "longhorn-system", "cattle-neuvector-system", "cattle-monitoring-system", "rancher-alerting-drivers", "cis-operator-system", "cattle-csp-adapter-system", "cattle-externalip-system", "cattle-gatekeeper-system", "istio-system", "cattle-istio-system", "cattle-logging-system", "cattle-windows-gmsa-system", "cattle-sriov-system", "cattle-ui-plugin-system", "tigera-operator"]
+  }
+}
+
+resource "rancher2_cluster" "tfp-rke1" {
+  depends_on                                                 = [rancher2_node_template.tfp-rke1, rancher2_pod_security_admission_configuration_template.tfp-rke1]
+  name                                                       = "tfp-rke1"
+  default_pod_security_admission_configuration_template_name = "rancher-baseline"
+  rke_config {
+    kubernetes_version = "v1.30.5-rancher1-1"
+    network {
+      plugin = ""
+    }
+  }
+}
+
+resource "rancher2_node_pool" "tfp-rke1node-pool0" {
+  depends_on       = [rancher2_cluster.tfp-rke1]
+  cluster_id       = rancher2_cluster.tfp-rke1.id
+  name             = "pool0"
+  hostname_prefix  = "tfp-rke1-pool0"
+  node_template_id = rancher2_node_template.tfp-rke1.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = true
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfp-rke1node-pool1" {
+  depends_on       = [rancher2_cluster.tfp-rke1]
+  cluster_id       = rancher2_cluster.tfp-rke1.id
+  name             = "pool1"
+  hostname_prefix  = "tfp-rke1-pool1"
+  node_template_id = rancher2_node_template.tfp-rke1.id
+  quantity         = 1
+  control_plane    = true
+  etcd             = false
+  worker           = false
+}
+
+resource "rancher2_node_pool" "tfp-rke1node-pool2" {
+  depends_on       = [rancher2_cluster.tfp-rke1]
+  cluster_id       = rancher2_cluster.tfp-rke1.id
+  name             = "pool2"
+  hostname_prefix  = "tfp-rke1-pool2"
+  node_template_id = rancher2_node_template.tfp-rke1.id
+  quantity         = 1
+  control_plane    = false
+  etcd             = false
+  worker           = true
+}
+
+resource "rancher2_cluster_sync" "tfp-rke1" {
+  cluster_id    = rancher2_cluster.tfp-rke1.id
+  node_pool_ids = [rancher2_node_pool.tfp-rke1node-pool0.id, rancher2_node_pool.tfp-rke1node-pool1.id, rancher2_node_pool.tfp-rke1node-pool2.id]
+  state_confirm = 2
+}
+
+
+output "tfp-rke1" {
+  value = {
+    cluster_id         = rancher2_cluster.tfp-rke1.id
+    kubeconfig         = rancher2_cluster.tfp-rke1.kube_config
+    registration_token = rancher2_cluster.tfp-rke1.cluster_registration_token[0].token
+    v1_cluster_id      = rancher2_cluster.tfp-rke1.id
+  }
+  sensitive = true
+}
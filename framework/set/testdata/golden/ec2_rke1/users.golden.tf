@@ -0,0 +1,14 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+resource "rancher2_user" "rancher2_user" {
+  name     = "tfp-test-user"
+  username = "tfp-test-user"
+  password = "tfp-test-password"
+  enabled  = true
+}
+
+resource "rancher2_global_role_binding" "rancher2_global_role_binding" {
+  name           = "tfp-test-user"
+  global_role_id = "user"
+  user_id        = rancher2_user.rancher2_user.id
+}
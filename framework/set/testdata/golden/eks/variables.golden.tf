@@ -0,0 +1,14 @@
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-eks_aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-eks_aws_secret_key" {
+  type      = string
+  sensitive = true
+}
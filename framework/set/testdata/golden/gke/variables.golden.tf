@@ -0,0 +1,9 @@
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-gke_google_auth_encoded_json" {
+  type      = string
+  sensitive = true
+}
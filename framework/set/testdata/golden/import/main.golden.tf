@@ -0,0 +1,147 @@
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "5.1.0"
+    }
+    aws = {
+      source  = "hashicorp/aws"
+      version = "5.53.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.1"
+    }
+  }
+}
+
+provider "aws" {
+  region     = "us-east-2"
+  access_key = var.aws_access_key
+  secret_key = var.aws_secret_key
+}
+
+provider "local" {
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
+
+resource "rancher2_user" "rancher2_user" {
+  name     = "tfp-test-user"
+  username = "tfp-test-user"
+  password = "tfp-test-password"
+  enabled  = true
+}
+
+resource "rancher2_global_role_binding" "rancher2_global_role_binding" {
+  name           = "tfp-test-user"
+  global_role_id = "user"
+  user_id        = rancher2_user.rancher2_user.id
+}
+
+resource "rancher2_cluster" "tfp-custom-rke2" {
+  name        = "tfp-custom-rke2"
+  description = "tfp-automation imported cluster"
+}
+
+resource "rancher2_project" "tfp-custom-rke2" {
+  name       = "tfp-custom-rke2"
+  cluster_id = rancher2_cluster.tfp-custom-rke2.id
+}
+
+resource "rancher2_cloud_credential" "tfp-custom-rke2" {
+  name = "tfp-custom-rke2"
+  amazonec2_credential_config {
+    access_key = var.tfp-custom-rke2_aws_access_key
+    secret_key = var.tfp-custom-rke2_aws_secret_key
+  }
+}
+
+resource "rancher2_pod_security_admission_configuration_template" "tfp-custom-rke2" {
+  name        = "tfp-custom-rke2"
+  description = "This is a custom baseline Pod Security Admission Configuration Template.It defines a minimally restrictive policy which prevents known privilege escalations. This policy contains namespace level exemptions for Rancher components."
+  defaults {
+    audit           = "baseline"
+    audit_version   = "latest"
+    enforce         = "baseline"
+    enforce_version = "latest"
+    warn            = "baseline"
+    warn_version    = "latest"
+  }
+  exemptions {
+    namespaces = ["ingress-nginx", "kube-system", "cattle-system", "cattle-epinio-system", "cattle-fleet-system", "longhorn-system", "cattle-neuvector-system", "cattle-monitoring-system", "rancher-alerting-drivers", "cis-operator-system", "cattle-csp-adapter-system", "cattle-externalip-system", "cattle-gatekeeper-system", "istio-system", "cattle-istio-system", "cattle-logging-system", "cattle-windows-gmsa-system", "cattle-sriov-system", "cattle-ui-plugin-system", "tigera-operator"]
+  }
+}
+
+resource "rancher2_cluster" "tfp-custom-k3s" {
+  name        = "tfp-custom-k3s"
+  description = "tfp-automation imported cluster"
+}
+
+resource "rancher2_project" "tfp-custom-k3s" {
+  name       = "tfp-custom-k3s"
+  cluster_id = rancher2_cluster.tfp-custom-k3s.id
+}
+
+resource "rancher2_cloud_credential" "tfp-custom-k3s" {
+  name = "tfp-custom-k3s"
+  amazonec2_credential_config {
+    access_key = var.tfp-custom-k3s_aws_access_key
+    secret_key = var.tfp-custom-k3s_aws_secret_key
+  }
+}
+
+resource "rancher2_pod_security_admission_configuration_template" "tfp-custom-k3s" {
+  name        = "tfp-custom-k3s"
+  description = "This is a custom baseline Pod Security Admission Configuration Template.It defines a minimally restrictive policy which prevents known privilege escalations. This policy contains namespace level exemptions for Rancher components."
+  defaults {
+    audit           = "baseline"
+    audit_version   = "latest"
+    enforce         = "baseline"
+    enforce_version = "latest"
+    warn            = "baseline"
+    warn_version    = "latest"
+  }
+  exemptions {
+    namespaces = ["ingress-nginx", "kube-system", "cattle-system", "cattle-epinio-system", "cattle-fleet-system", "longhorn-system", "cattle-neuvector-system", "cattle-monitoring-system", "rancher-alerting-drivers", "cis-operator-system", "cattle-csp-adapter-system", "cattle-externalip-system", "cattle-gatekeeper-system", "istio-system", "cattle-istio-system", "cattle-logging-system", "cattle-windows-gmsa-system", "cattle-sriov-system", "cattle-ui-plugin-system", "tigera-operator"]
+  }
+}
+
+resource "rancher2_cluster" "tfp-rke2" {
+  name        = "tfp-rke2"
+  description = "tfp-automation imported cluster"
+}
+
+resource "rancher2_project" "tfp-rke2" {
+  name       = "tfp-rke2"
+  cluster_id = rancher2_cluster.tfp-rke2.id
+}
+
+resource "rancher2_cloud_credential" "tfp-rke2" {
+  name = "tfp-rke2"
+  amazonec2_credential_config {
+    access_key = var.tfp-rke2_aws_access_key
+    secret_key = var.tfp-rke2_aws_secret_key
+  }
+}
+
+resource "rancher2_pod_security_admission_configuration_template" "tfp-rke2" {
+  name        = "tfp-rke2"
+  description = "This is a custom baseline Pod Security Admission Configuration Template.It defines a minimally restrictive policy which prevents known privilege escalations. This policy contains namespace level exemptions for Rancher components."
+  defaults {
+    audit           = "baseline"
+    audit_version   = "latest"
+    enforce         = "baseline"
+    enforce_version = "latest"
+    warn            = "baseline"
+    warn_version    = "latest"
+  }
+  exemptions {
+    namespaces = ["ingress-nginx", "kube-system", "cattle-system", "cattle-epinio-system", "cattle-fleet-system", "longhorn-system", "cattle-neuvector-system", "cattle-monitoring-system", "rancher-alerting-drivers", "cis-operator-system", "cattle-csp-adapter-system", "cattle-externalip-system", "cattle-gatekeeper-system", "istio-system", "cattle-istio-system", "cattle-logging-system", "cattle-windows-gmsa-system", "cattle-sriov-system", "cattle-ui-plugin-system", "tigera-operator"]
+  }
+}
+
@@ -0,0 +1,44 @@
+variable "aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-custom-k3s_aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-custom-k3s_aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-custom-rke2_aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-custom-rke2_aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-rke2_aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-rke2_aws_secret_key" {
+  type      = string
+  sensitive = true
+}
@@ -0,0 +1,37 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "5.1.0"
+    }
+    aws = {
+      source  = "hashicorp/aws"
+      version = "5.53.0"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = "2.5.1"
+    }
+    rke = {
+      source  = "rancher/rke"
+      version = "1.7.0"
+    }
+  }
+}
+
+provider "aws" {
+  region     = "us-east-2"
+  access_key = var.aws_access_key
+  secret_key = var.aws_secret_key
+}
+
+provider "local" {
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
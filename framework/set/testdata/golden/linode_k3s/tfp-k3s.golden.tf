@@ -0,0 +1,58 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+resource "rancher2_cloud_credential" "tfp-k3s" {
+  name = "tfp-k3s"
+  linode_credential_config {
+    token = var.tfp-k3s_linode_token
+  }
+}
+
+resource "rancher2_machine_config_v2" "tfp-k3s" {
+  generate_name = "tfp-k3s"
+  linode_config {
+    image     = "linode/ubuntu22.04"
+    region    = "us-east"
+    root_pass = var.tfp-k3s_linode_root_pass
+    tags      = "ResourcePrefix:tfp-k3s"
+  }
+}
+
+resource "rancher2_cluster_v2" "tfp-k3s" {
+  name                                                       = "tfp-k3s"
+  kubernetes_version                                         = "v1.30.5+k3s1"
+  enable_network_policy                                      = false
+  default_pod_security_admission_configuration_template_name = ""
+  default_cluster_role_for_project_members                   = "user"
+  rke_config {
+    machine_global_config = <<EOF
+cni: 
+disable-kube-proxy: 
+EOF
+    machine_pools {
+      name                         = "pool0"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp-k3s.id
+      control_plane_role           = true
+      etcd_role                    = true
+      worker_role                  = true
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp-k3s.kind
+        name = rancher2_machine_config_v2.tfp-k3s.name
+      }
+    }
+    upgrade_strategy {
+      control_plane_concurrency = "10%"
+      worker_concurrency        = "10%"
+    }
+  }
+}
+
+output "tfp-k3s" {
+  value = {
+    cluster_id         = rancher2_cluster_v2.tfp-k3s.cluster_v1_id
+    kubeconfig         = rancher2_cluster_v2.tfp-k3s.kube_config
+    registration_token = rancher2_cluster_v2.tfp-k3s.cluster_registration_token[0].token
+    v1_cluster_id      = rancher2_cluster_v2.tfp-k3s.id
+  }
+  sensitive = true
+}
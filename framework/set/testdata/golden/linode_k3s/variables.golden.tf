@@ -0,0 +1,14 @@
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-k3s_linode_root_pass" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-k3s_linode_token" {
+  type      = string
+  sensitive = true
+}
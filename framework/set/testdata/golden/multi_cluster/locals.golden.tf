@@ -0,0 +1,13 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+locals {
+  role_flags                                    = ["--etcd", "--controlplane", "--worker"]
+  tfp-custom-rke2_original_node_command         = rancher2_cluster_v2.tfp-custom-rke2.cluster_registration_token[0].node_command
+  tfp-custom-rke2_windows_original_node_command = rancher2_cluster_v2.tfp-custom-rke2.cluster_registration_token[0].windows_node_command
+  tfp-custom-rke2_insecure_node_command         = "${replace(local.tfp-custom-rke2_original_node_command, "curl", "curl --insecure")}"
+  tfp-custom-rke2_insecure_windows_node_command = "${replace(local.tfp-custom-rke2_windows_original_node_command, "curl.exe", "curl.exe --insecure")}"
+  tfp-custom-k3s_original_node_command          = rancher2_cluster_v2.tfp-custom-k3s.cluster_registration_token[0].node_command
+  tfp-custom-k3s_windows_original_node_command  = rancher2_cluster_v2.tfp-custom-k3s.cluster_registration_token[0].windows_node_command
+  tfp-custom-k3s_insecure_node_command          = "${replace(local.tfp-custom-k3s_original_node_command, "curl", "curl --insecure")}"
+  tfp-custom-k3s_insecure_windows_node_command  = "${replace(local.tfp-custom-k3s_windows_original_node_command, "curl.exe", "curl.exe --insecure")}"
+}
@@ -0,0 +1,62 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+resource "aws_instance" "tfp-custom-k3s" {
+  count                  = 1
+  ami                    = "ami-aaaaaaaa"
+  instance_type          = "t3.xlarge"
+  subnet_id              = "subnet-aaaaaaaa"
+  vpc_security_group_ids = ["sg-aaaaaaaa"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name           = "tfp-custom-k3s-tfp-custom-k3s-${count.index}"
+    ResourcePrefix = "tfp-custom-k3s"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["cloud-init status --wait"]
+  }
+}
+
+resource "rancher2_cluster_v2" "tfp-custom-k3s" {
+  name               = "tfp-custom-k3s"
+  kubernetes_version = "v1.30.5+k3s1"
+  rke_config {
+  }
+}
+
+resource "null_resource" "register_nodes-tfp-custom-k3s" {
+  count = length(aws_instance.tfp-custom-k3s)
+  provisioner "remote-exec" {
+    inline = ["${local.tfp-custom-k3s_insecure_node_command} ${local.role_flags[count.index]}"]
+    connection {
+      type        = "ssh"
+      user        = "ubuntu"
+      host        = aws_instance.tfp-custom-k3s[count.index].public_ip
+      private_key = file("testdata/id_rsa")
+    }
+  }
+  depends_on = [rancher2_cluster_v2.tfp-custom-k3s]
+}
+
+output "tfp-custom-k3s" {
+  value = {
+    cluster_id         = rancher2_cluster_v2.tfp-custom-k3s.cluster_v1_id
+    kubeconfig         = rancher2_cluster_v2.tfp-custom-k3s.kube_config
+    registration_token = rancher2_cluster_v2.tfp-custom-k3s.cluster_registration_token[0].token
+    v1_cluster_id      = rancher2_cluster_v2.tfp-custom-k3s.id
+  }
+  sensitive = true
+}
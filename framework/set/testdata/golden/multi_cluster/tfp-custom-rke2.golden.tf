@@ -0,0 +1,65 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+resource "aws_instance" "tfp-custom-rke2" {
+  count                  = 3
+  ami                    = "ami-aaaaaaaa"
+  instance_type          = "t3.xlarge"
+  subnet_id              = "subnet-aaaaaaaa"
+  vpc_security_group_ids = ["sg-aaaaaaaa"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name           = "tfp-custom-rke2-tfp-custom-rke2-${count.index}"
+    ResourcePrefix = "tfp-custom-rke2"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["cloud-init status --wait"]
+  }
+}
+
+resource "rancher2_cluster_v2" "tfp-custom-rke2" {
+  name               = "tfp-custom-rke2"
+  kubernetes_version = "v1.30.5+rke2r1"
+  rke_config {
+    machine_global_config = <<EOF
+cni: calico
+EOF
+  }
+}
+
+resource "null_resource" "register_nodes-tfp-custom-rke2" {
+  count = length(aws_instance.tfp-custom-rke2)
+  provisioner "remote-exec" {
+    inline = ["${local.tfp-custom-rke2_insecure_node_command} ${local.role_flags[count.index]}"]
+    connection {
+      type        = "ssh"
+      user        = "ubuntu"
+      host        = aws_instance.tfp-custom-rke2[count.index].public_ip
+      private_key = file("testdata/id_rsa")
+    }
+  }
+  depends_on = [rancher2_cluster_v2.tfp-custom-rke2]
+}
+
+output "tfp-custom-rke2" {
+  value = {
+    cluster_id         = rancher2_cluster_v2.tfp-custom-rke2.cluster_v1_id
+    kubeconfig         = rancher2_cluster_v2.tfp-custom-rke2.kube_config
+    registration_token = rancher2_cluster_v2.tfp-custom-rke2.cluster_registration_token[0].token
+    v1_cluster_id      = rancher2_cluster_v2.tfp-custom-rke2.id
+  }
+  sensitive = true
+}
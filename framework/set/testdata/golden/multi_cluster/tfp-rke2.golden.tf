@@ -0,0 +1,66 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+resource "rancher2_cloud_credential" "tfp-rke2" {
+  name = "tfp-rke2"
+  amazonec2_credential_config {
+    access_key = var.tfp-rke2_aws_access_key
+    secret_key = var.tfp-rke2_aws_secret_key
+  }
+}
+
+resource "rancher2_machine_config_v2" "tfp-rke2" {
+  generate_name = "tfp-rke2"
+  amazonec2_config {
+    region         = "us-east-2"
+    ami            = "ami-aaaaaaaa"
+    instance_type  = "t3.xlarge"
+    ssh_user       = "ubuntu"
+    volume_type    = ""
+    root_size      = 80
+    security_group = ["tfp-security-group"]
+    subnet_id      = "subnet-aaaaaaaa"
+    vpc_id         = "vpc-aaaaaaaa"
+    zone           = "a"
+    tags           = "ResourcePrefix,tfp-rke2"
+  }
+}
+
+resource "rancher2_cluster_v2" "tfp-rke2" {
+  name                                                       = "tfp-rke2"
+  kubernetes_version                                         = "v1.30.5+rke2r1"
+  enable_network_policy                                      = false
+  default_pod_security_admission_configuration_template_name = ""
+  default_cluster_role_for_project_members                   = ""
+  rke_config {
+    machine_global_config = <<EOF
+cni: calico
+disable-kube-proxy: 
+EOF
+    machine_pools {
+      name                         = "pool0"
+      cloud_credential_secret_name = rancher2_cloud_credential.tfp-rke2.id
+      control_plane_role           = true
+      etcd_role                    = true
+      worker_role                  = true
+      quantity                     = 1
+      machine_config {
+        kind = rancher2_machine_config_v2.tfp-rke2.kind
+        name = rancher2_machine_config_v2.tfp-rke2.name
+      }
+    }
+    upgrade_strategy {
+      control_plane_concurrency = "10%"
+      worker_concurrency        = "10%"
+    }
+  }
+}
+
+output "tfp-rke2" {
+  value = {
+    cluster_id         = rancher2_cluster_v2.tfp-rke2.cluster_v1_id
+    kubeconfig         = rancher2_cluster_v2.tfp-rke2.kube_config
+    registration_token = rancher2_cluster_v2.tfp-rke2.cluster_registration_token[0].token
+    v1_cluster_id      = rancher2_cluster_v2.tfp-rke2.id
+  }
+  sensitive = true
+}
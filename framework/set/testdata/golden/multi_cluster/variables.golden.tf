@@ -0,0 +1,24 @@
+variable "aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-rke2_aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-rke2_aws_secret_key" {
+  type      = string
+  sensitive = true
+}
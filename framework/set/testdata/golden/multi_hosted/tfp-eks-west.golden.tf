@@ -0,0 +1,39 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+resource "rancher2_cloud_credential" "tfp-eks-west" {
+  name = "tfp-eks-west"
+  amazonec2_credential_config {
+    access_key = var.tfp-eks-west_aws_access_key
+    secret_key = var.tfp-eks-west_aws_secret_key
+  }
+}
+
+resource "rancher2_cluster" "tfp-eks-west" {
+  name = "tfp-eks-west"
+  eks_config_v2 {
+    cloud_credential_id = rancher2_cloud_credential.tfp-eks-west.id
+    region              = "us-west-2"
+    kubernetes_version  = "1.30"
+    subnets             = ["subnet-aaaaaaaa", "subnet-bbbbbbbb"]
+    security_groups     = ["sg-aaaaaaaa"]
+    private_access      = true
+    public_access       = true
+    node_groups {
+      name          = "tfp-eks-west-pool0"
+      instance_type = "t3.medium"
+      desired_size  = 3
+      max_size      = 3
+      min_size      = 0
+    }
+  }
+}
+
+output "tfp-eks-west" {
+  value = {
+    cluster_id         = rancher2_cluster.tfp-eks-west.id
+    kubeconfig         = rancher2_cluster.tfp-eks-west.kube_config
+    registration_token = rancher2_cluster.tfp-eks-west.cluster_registration_token[0].token
+    v1_cluster_id      = rancher2_cluster.tfp-eks-west.id
+  }
+  sensitive = true
+}
@@ -0,0 +1,37 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+resource "rancher2_cloud_credential" "tfp-gke" {
+  name = "tfp-gke"
+  google_credential_config {
+    auth_encoded_json = var.tfp-gke_google_auth_encoded_json
+  }
+}
+
+resource "rancher2_cluster" "tfp-gke" {
+  name = "tfp-gke"
+  gke_config_v2 {
+    name                     = "tfp-gke"
+    google_credential_secret = rancher2_cloud_credential.tfp-gke.id
+    region                   = "us-central1-c"
+    project_id               = "fake-project"
+    kubernetes_version       = "1.30.5-gke.1014001"
+    network                  = "default"
+    subnetwork               = "default"
+    node_pools {
+      initial_node_count  = 2
+      max_pods_constraint = 110
+      name                = "tfp-gke-pool0"
+      version             = "1.30.5-gke.1014001"
+    }
+  }
+}
+
+output "tfp-gke" {
+  value = {
+    cluster_id         = rancher2_cluster.tfp-gke.id
+    kubeconfig         = rancher2_cluster.tfp-gke.kube_config
+    registration_token = rancher2_cluster.tfp-gke.cluster_registration_token[0].token
+    v1_cluster_id      = rancher2_cluster.tfp-gke.id
+  }
+  sensitive = true
+}
@@ -0,0 +1,44 @@
+variable "rancher_admin_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-aks_azure_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-eks-west_aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-eks-west_aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-eks_aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-eks_aws_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-gke_google_auth_encoded_json" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-rke2_aws_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "tfp-rke2_aws_secret_key" {
+  type      = string
+  sensitive = true
+}
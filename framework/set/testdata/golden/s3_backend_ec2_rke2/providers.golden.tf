@@ -0,0 +1,30 @@
+# Generated by tfp-automation from the test configs, any changes are overwritten.
+
+terraform {
+  required_providers {
+    rancher2 = {
+      source  = "rancher/rancher2"
+      version = "5.1.0"
+    }
+  }
+  backend "s3" {
+    bucket               = "tfp-state"
+    key                  = "terraform.tfstate"
+    region               = "us-east-1"
+    workspace_key_prefix = "tfp-automation"
+    endpoints = {
+      s3 = "http://minio.example.com:9000"
+    }
+    use_path_style              = true
+    skip_credentials_validation = true
+    skip_metadata_api_check     = true
+    skip_region_validation      = true
+    skip_requesting_account_id  = true
+  }
+}
+
+provider "rancher2" {
+  api_url   = "https://rancher.example.com"
+  token_key = var.rancher_admin_token
+  insecure  = true
+}
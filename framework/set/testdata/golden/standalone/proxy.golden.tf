@@ -0,0 +1,377 @@
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "5.53.0"
+    }
+  }
+}
+
+provider "aws" {
+  region     = "us-east-2"
+  access_key = var.aws_access_key
+  secret_key = var.aws_secret_key
+}
+
+resource "aws_instance" "rke2_bastion" {
+  ami                    = "ami-aaaaaaaa"
+  instance_type          = "t3.xlarge"
+  subnet_id              = "subnet-aaaaaaaa"
+  vpc_security_group_ids = ["sg-aaaaaaaa"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name           = "tfp-standalone-rke2_bastion"
+    ResourcePrefix = "tfp-standalone"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["cloud-init status --wait"]
+  }
+}
+
+resource "aws_instance" "rke2_server1" {
+  ami                    = "ami-aaaaaaaa"
+  instance_type          = "t3.xlarge"
+  subnet_id              = "subnet-aaaaaaaa"
+  vpc_security_group_ids = ["sg-aaaaaaaa"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name           = "tfp-standalone-rke2_server1"
+    ResourcePrefix = "tfp-standalone"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["cloud-init status --wait"]
+  }
+}
+
+resource "aws_instance" "rke2_server2" {
+  ami                    = "ami-aaaaaaaa"
+  instance_type          = "t3.xlarge"
+  subnet_id              = "subnet-aaaaaaaa"
+  vpc_security_group_ids = ["sg-aaaaaaaa"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name           = "tfp-standalone-rke2_server2"
+    ResourcePrefix = "tfp-standalone"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["cloud-init status --wait"]
+  }
+}
+
+resource "aws_instance" "rke2_server3" {
+  ami                    = "ami-aaaaaaaa"
+  instance_type          = "t3.xlarge"
+  subnet_id              = "subnet-aaaaaaaa"
+  vpc_security_group_ids = ["sg-aaaaaaaa"]
+  key_name               = "tfp-key"
+
+  root_block_device {
+    volume_size = 100
+  }
+
+  tags = {
+    Name           = "tfp-standalone-rke2_server3"
+    ResourcePrefix = "tfp-standalone"
+  }
+
+  connection {
+    type        = "ssh"
+    user        = "ubuntu"
+    host        = self.public_ip
+    private_key = file("testdata/id_rsa")
+    timeout     = "5m"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["cloud-init status --wait"]
+  }
+}
+
+locals {
+  rke2_instance_ids = {
+    rke2_server1 = aws_instance.rke2_server1.id
+    rke2_server2 = aws_instance.rke2_server2.id
+    rke2_server3 = aws_instance.rke2_server3.id
+  }
+}
+
+resource "aws_lb_target_group_attachment" "aws_tg_attachment_80_server" {
+  for_each         = local.rke2_instance_ids
+  target_group_arn = aws_lb_target_group.aws_tg_80.arn
+  target_id        = each.value
+  port             = 80
+}
+
+resource "aws_lb_target_group_attachment" "aws_tg_attachment_443_server" {
+  for_each         = local.rke2_instance_ids
+  target_group_arn = aws_lb_target_group.aws_tg_443.arn
+  target_id        = each.value
+  port             = 443
+}
+
+resource "aws_lb_target_group_attachment" "aws_tg_attachment_6443_server" {
+  for_each         = local.rke2_instance_ids
+  target_group_arn = aws_lb_target_group.aws_tg_6443.arn
+  target_id        = each.value
+  port             = 6443
+}
+
+resource "aws_lb_target_group_attachment" "aws_tg_attachment_9345_server" {
+  for_each         = local.rke2_instance_ids
+  target_group_arn = aws_lb_target_group.aws_tg_9345.arn
+  target_id        = each.value
+  port             = 9345
+}
+
+resource "aws_lb" "aws_lb" {
+  internal           = false
+  load_balancer_type = "network"
+  subnets            = ["subnet-aaaaaaaa"]
+  name               = "tfp-standalone"
+
+  tags = {
+    ResourcePrefix = "tfp-standalone"
+  }
+}
+
+resource "aws_lb_target_group" "aws_tg_80" {
+  port     = 80
+  protocol = "TCP"
+  vpc_id   = "vpc-aaaaaaaa"
+  name     = "tfp-standalone-tg-80"
+  health_check {
+    protocol            = "HTTP"
+    port                = "traffic-port"
+    path                = "/ping"
+    interval            = 10
+    timeout             = 6
+    healthy_threshold   = 3
+    unhealthy_threshold = 3
+    matcher             = "200-399"
+  }
+
+  tags = {
+    ResourcePrefix = "tfp-standalone"
+  }
+}
+
+resource "aws_lb_listener" "aws_lb_listener_80" {
+  load_balancer_arn = aws_lb.aws_lb.arn
+  port              = 80
+  protocol          = "TCP"
+  default_action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.aws_tg_80.arn
+  }
+}
+
+resource "aws_lb_target_group" "aws_tg_443" {
+  port     = 443
+  protocol = "TCP"
+  vpc_id   = "vpc-aaaaaaaa"
+  name     = "tfp-standalone-tg-443"
+  health_check {
+    protocol            = "HTTP"
+    port                = "traffic-port"
+    path                = "/ping"
+    interval            = 10
+    timeout             = 6
+    healthy_threshold   = 3
+    unhealthy_threshold = 3
+    matcher             = "200-399"
+  }
+
+  tags = {
+    ResourcePrefix = "tfp-standalone"
+  }
+}
+
+resource "aws_lb_listener" "aws_lb_listener_443" {
+  load_balancer_arn = aws_lb.aws_lb.arn
+  port              = 443
+  protocol          = "TCP"
+  default_action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.aws_tg_443.arn
+  }
+}
+
+resource "aws_lb_target_group" "aws_tg_6443" {
+  port     = 6443
+  protocol = "TCP"
+  vpc_id   = "vpc-aaaaaaaa"
+  name     = "tfp-standalone-tg-6443"
+  health_check {
+    protocol            = "HTTP"
+    port                = "traffic-port"
+    path                = "/ping"
+    interval            = 10
+    timeout             = 6
+    healthy_threshold   = 3
+    unhealthy_threshold = 3
+    matcher             = "200-399"
+  }
+
+  tags = {
+    ResourcePrefix = "tfp-standalone"
+  }
+}
+
+resource "aws_lb_listener" "aws_lb_listener_6443" {
+  load_balancer_arn = aws_lb.aws_lb.arn
+  port              = 6443
+  protocol          = "TCP"
+  default_action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.aws_tg_6443.arn
+  }
+}
+
+resource "aws_lb_target_group" "aws_tg_9345" {
+  port     = 9345
+  protocol = "TCP"
+  vpc_id   = "vpc-aaaaaaaa"
+  name     = "tfp-standalone-tg-9345"
+  health_check {
+    protocol            = "HTTP"
+    port                = "traffic-port"
+    path                = "/ping"
+    interval            = 10
+    timeout             = 6
+    healthy_threshold   = 3
+    unhealthy_threshold = 3
+    matcher             = "200-399"
+  }
+
+  tags = {
+    ResourcePrefix = "tfp-standalone"
+  }
+}
+
+resource "aws_lb_listener" "aws_lb_listener_9345" {
+  load_balancer_arn = aws_lb.aws_lb.arn
+  port              = 9345
+  protocol          = "TCP"
+  default_action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.aws_tg_9345.arn
+  }
+}
+
+resource "aws_route53_record" "aws_route53_record" {
+  zone_id = data.aws_route53_zone.selected.zone_id
+  name    = "tfp-standalone"
+  type    = "CNAME"
+  ttl     = 300
+  records = [aws_lb.aws_lb.dns_name]
+}
+
+data "aws_route53_zone" "selected" {
+  name         = "example.com"
+  private_zone = false
+}
+
+resource "null_resource" "install_squid_proxy" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "bastion.example.com"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["printf '#!/bin/bash\n\nUSER=$1\nBASTION=$2\nPASSWORD=$3\nDOCKER_DIR=\"/etc/systemd/system/docker.service.d\"\nPORT=\"3128\"\n\nset -e\n   \necho \"Starting proxy...\"\nsudo mkdir -p /home/$USER/squid\nPROXY_DIR=/home/$USER/squid\nsudo mv /tmp/squid.conf $${PROXY_DIR}/squid.conf\n\nsudo docker run -d -v $${PROXY_DIR}/squid.conf:/etc/squid/squid.conf -p $${PORT}:$${PORT} ubuntu/squid' > /tmp/setup.sh", "printf 'acl localnet src 10.0.0.0/8\nacl localnet src 172.16.0.0/12\nacl localnet src 192.168.0.0/16\nacl localnet src fc00::/7\nacl localnet src fe80::/10\n\nacl SSL_ports port 443\nacl SSL_ports port 6443\nacl SSL_ports port 22\nacl SSL_ports port 2376\nacl Safe_ports port 80\nacl Safe_ports port 21\nacl Safe_ports port 443\nacl Safe_ports port 70\nacl Safe_ports port 210\nacl Safe_ports port 1025-65535\nacl Safe_ports port 280\nacl Safe_ports port 488\nacl Safe_ports port 591\nacl Safe_ports port 777\nacl Safe_ports port 6443\nacl Safe_ports port 22\nacl Safe_ports port 2376\nacl CONNECT method CONNECT\n\nhttp_access deny !Safe_ports\n\nhttp_access deny CONNECT !SSL_ports\n\nhttp_access allow localhost manager\nhttp_access deny manager\n\nhttp_access allow localnet\nhttp_access allow localhost\n\nhttp_access allow all\n\nhttp_port 3128\n\ncoredump_dir /var/cache/squid\n\nrefresh_pattern ^ftp:\t\t1440\t20%%\t10080\nrefresh_pattern ^gopher:\t1440\t0%%\t1440\nrefresh_pattern -i (/cgi-bin/|\\?) 0\t0%%\t0\nrefresh_pattern .\t\t0\t20%%\t4320' > /tmp/squid.conf", "chmod +x /tmp/setup.sh", "bash -c '/tmp/setup.sh ubuntu bastion.example.com fake-bootstrap-password || true'"]
+  }
+}
+
+resource "null_resource" "rke2_server1" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "server1.example.com"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["printf '#!/bin/bash\n\nUSER=$1\nGROUP=$2\nK8S_VERSION=$3\nRKE2_SERVER_IP=$4\nRKE2_TOKEN=$5\nBASTION=$6\nPORT=\"3128\"\n\nset -e\n\ncat <<EOF | sudo tee /etc/default/rke2-server > /dev/null\nHTTP_PROXY=http://$${BASTION}:$${PORT}\nHTTPS_PROXY=http://$${BASTION}:$${PORT}\nNO_PROXY=localhost,127.0.0.0/8,10.0.0/8,cattle-system.svc,172.16.0.0/12,192.168.0.0/16,.svc,.cluster.local\nCONTAINERD_HTTP_PROXY=http://$${BASTION}:$${PORT}\nCONTAINERD_HTTPS_PROXY=http://$${BASTION}:$${PORT}\nCONTAINERD_NO_PROXY=localhost,127.0.0.0/8,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,169.254.169.254,.svc,.cluster.local,cattle-system.svc\nhttp_proxy=http://$${BASTION}:$${PORT}\nhttps_proxy=http://$${BASTION}:$${PORT}\nEOF\n\nsudo mkdir -p /etc/rancher/rke2\nsudo touch /etc/rancher/rke2/config.yaml\n\necho \"token: $${RKE2_TOKEN}\ntls-san:\n  - $${RKE2_SERVER_IP}\" | sudo tee /etc/rancher/rke2/config.yaml > /dev/null\n\ncurl -sfL https://get.rke2.io --output install.sh\nsudo chmod +x install.sh\nsudo INSTALL_RKE2_VERSION=$${K8S_VERSION} INSTALL_RKE2_TYPE='server' ./install.sh\n\nsudo systemctl enable rke2-server\nsudo systemctl start rke2-server\n\nsudo mkdir -p /home/$${USER}/.kube\nsudo cp /etc/rancher/rke2/rke2.yaml /home/$${USER}/.kube/config\nsudo chown -R $${USER}:$${GROUP} /home/$${USER}/.kube' > /tmp/init-server.sh", "chmod +x /tmp/init-server.sh", "bash -c '/tmp/init-server.sh ubuntu ubuntu v1.30.5+rke2r1 10.0.0.1 auto-token-xxxxx bastion.example.com || true'"]
+  }
+}
+
+resource "null_resource" "rke2_server2" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "server2.example.com"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["printf '#!/bin/bash\n\nUSER=$1\nGROUP=$2\nK8S_VERSION=$3\nRKE2_SERVER_IP=$4\nRKE2_TOKEN=$5\nBASTION=$6\nPORT=\"3128\"\n\nset -e\n\ncat <<EOF | sudo tee /etc/default/rke2-server > /dev/null\nHTTP_PROXY=http://$${BASTION}:$${PORT}\nHTTPS_PROXY=http://$${BASTION}:$${PORT}\nNO_PROXY=localhost,127.0.0.0/8,10.0.0/8,cattle-system.svc,172.16.0.0/12,192.168.0.0/16,.svc,.cluster.local\nCONTAINERD_HTTP_PROXY=http://$${BASTION}:$${PORT}\nCONTAINERD_HTTPS_PROXY=http://$${BASTION}:$${PORT}\nCONTAINERD_NO_PROXY=localhost,127.0.0.0/8,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,169.254.169.254,.svc,.cluster.local,cattle-system.svc\nhttp_proxy=http://$${BASTION}:$${PORT}\nhttps_proxy=http://$${BASTION}:$${PORT}\nEOF\n\nsudo mkdir -p /etc/rancher/rke2\nsudo touch /etc/rancher/rke2/config.yaml\n\necho \"server: https://$${RKE2_SERVER_IP}:9345\ntoken: $${RKE2_TOKEN}\ntls-san:\n  - $${RKE2_SERVER_IP}\" | sudo tee /etc/rancher/rke2/config.yaml > /dev/null\n\ncurl -sfL https://get.rke2.io --output install.sh\nsudo chmod +x install.sh\nsudo INSTALL_RKE2_VERSION=$${K8S_VERSION} INSTALL_RKE2_TYPE='server' ./install.sh\n\nsudo systemctl enable rke2-server\nsudo systemctl start rke2-server' > /tmp/add-servers.sh", "chmod +x /tmp/add-servers.sh", "bash -c '/tmp/add-servers.sh ubuntu ubuntu v1.30.5+rke2r1 10.0.0.1 auto-token-xxxxx bastion.example.com || true'"]
+  }
+  depends_on = [null_resource.rke2_server1]
+}
+
+resource "null_resource" "rke2_server3" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "server3.example.com"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["printf '#!/bin/bash\n\nUSER=$1\nGROUP=$2\nK8S_VERSION=$3\nRKE2_SERVER_IP=$4\nRKE2_TOKEN=$5\nBASTION=$6\nPORT=\"3128\"\n\nset -e\n\ncat <<EOF | sudo tee /etc/default/rke2-server > /dev/null\nHTTP_PROXY=http://$${BASTION}:$${PORT}\nHTTPS_PROXY=http://$${BASTION}:$${PORT}\nNO_PROXY=localhost,127.0.0.0/8,10.0.0/8,cattle-system.svc,172.16.0.0/12,192.168.0.0/16,.svc,.cluster.local\nCONTAINERD_HTTP_PROXY=http://$${BASTION}:$${PORT}\nCONTAINERD_HTTPS_PROXY=http://$${BASTION}:$${PORT}\nCONTAINERD_NO_PROXY=localhost,127.0.0.0/8,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,169.254.169.254,.svc,.cluster.local,cattle-system.svc\nhttp_proxy=http://$${BASTION}:$${PORT}\nhttps_proxy=http://$${BASTION}:$${PORT}\nEOF\n\nsudo mkdir -p /etc/rancher/rke2\nsudo touch /etc/rancher/rke2/config.yaml\n\necho \"server: https://$${RKE2_SERVER_IP}:9345\ntoken: $${RKE2_TOKEN}\ntls-san:\n  - $${RKE2_SERVER_IP}\" | sudo tee /etc/rancher/rke2/config.yaml > /dev/null\n\ncurl -sfL https://get.rke2.io --output install.sh\nsudo chmod +x install.sh\nsudo INSTALL_RKE2_VERSION=$${K8S_VERSION} INSTALL_RKE2_TYPE='server' ./install.sh\n\nsudo systemctl enable rke2-server\nsudo systemctl start rke2-server' > /tmp/add-servers.sh", "chmod +x /tmp/add-servers.sh", "bash -c '/tmp/add-servers.sh ubuntu ubuntu v1.30.5+rke2r1 10.0.0.1 auto-token-xxxxx bastion.example.com || true'"]
+  }
+  depends_on = [null_resource.rke2_server1]
+}
+
+resource "null_resource" "install_proxy_rancher" {
+  provisioner "remote-exec" {
+    connection {
+      host        = "server1.example.com"
+      type        = "ssh"
+      user        = "ubuntu"
+      private_key = file("testdata/id_rsa")
+    }
+    inline = ["printf '#!/bin/bash\n\nRANCHER_CHART_REPO=$1\nREPO=$2\nCERT_MANAGER_VERSION=$3\nHOSTNAME=$4\nRANCHER_TAG_VERSION=$5\nBOOTSTRAP_PASSWORD=$6\nRANCHER_IMAGE=$7\nBASTION=$8\nRANCHER_AGENT_IMAGE=$${9}\nPROXY_PORT=\"3128\"\nNO_PROXY=\"localhost\\\\,127.0.0.0/8\\\\,10.0.0.0/8\\\\,172.0.0.0/8\\\\,192.168.0.0/16\\\\,.svc\\\\,.cluster.local\\\\,cattle-system.svc\\\\,169.254.169.254\"\n\nset -ex\n\necho \"Installing kubectl\"\ncurl -LO \"https://dl.k8s.io/release/$(curl -L -s https://dl.k8s.io/release/stable.txt)/bin/linux/amd64/kubectl\"\nsudo install -o root -g root -m 0755 kubectl /usr/local/bin/kubectl\nmkdir -p ~/.kube\nrm kubectl\n\necho \"Installing Helm\"\ncurl -fsSL -o get_helm.sh https://raw.githubusercontent.com/helm/helm/main/scripts/get-helm-3\nchmod +x get_helm.sh\n./get_helm.sh\nrm get_helm.sh\n\necho \"Adding Helm chart repo\"\nhelm repo add rancher-$${REPO} $${RANCHER_CHART_REPO}$${REPO}\n\necho \"Installing cert manager\"\nkubectl create ns cattle-system\nkubectl apply -f https://github.com/jetstack/cert-manager/releases/download/$${CERT_MANAGER_VERSION}/cert-manager.crds.yaml\nhelm repo add jetstack https://charts.jetstack.io\nhelm repo update\nhelm upgrade --install cert-manager jetstack/cert-manager --namespace cert-manager --create-namespace \\\n                                                                                   --version $${CERT_MANAGER_VERSION} \\\n                                                                                   --set http_proxy=\"http://$${BASTION}:$${PROXY_PORT}\" \\\n                                                                                   --set https_proxy=\"http://$${BASTION}:$${PROXY_PORT}\" \\\n                                                                                   --set no_proxy=\"$${NO_PROXY}\"\n\nkubectl get pods --namespace cert-manager\n\necho \"Waiting 1 minute for Rancher\"\nsleep 60\n\necho \"Installing Rancher\"\nif [ -n \"$RANCHER_AGENT_IMAGE\" ]; then\n    helm upgrade --install rancher rancher-$${REPO}/rancher --namespace cattle-system --set global.cattle.psp.enabled=false \\\n                                                                                 --set hostname=$${HOSTNAME} \\\n                                                                                 --set rancherImageTag=$${RANCHER_TAG_VERSION} \\\n                                                                                 --set rancherImage=$${RANCHER_IMAGE} \\\n                                                                                 --set 'extraEnv[0].name=CATTLE_AGENT_IMAGE' \\\n                                                                                 --set \"extraEnv[0].value=$${RANCHER_AGENT_IMAGE}:$${RANCHER_TAG_VERSION}\" \\\n                                                                                 --set proxy=\"http://$${BASTION}:$${PROXY_PORT}\" \\\n                                                                                 --set noProxy=\"$${NO_PROXY}\" \\\n                                                                                 --set bootstrapPassword=$${BOOTSTRAP_PASSWORD} --devel\n\nelse\n    helm upgrade --install rancher rancher-$${REPO}/rancher --namespace cattle-system --set global.cattle.psp.enabled=false \\\n                                                                                 --set hostname=$${HOSTNAME} \\\n                                                                                 --set rancherImage=$${RANCHER_IMAGE} \\\n                                                                                 --set rancherImageTag=$${RANCHER_TAG_VERSION} \\\n                                                                                 --set proxy=\"http://$${BASTION}:$${PROXY_PORT}\" \\\n                                                                                 --set noProxy=\"$${NO_PROXY}\" \\\n                                                                                 --set bootstrapPassword=$${BOOTSTRAP_PASSWORD}\nfi\n\necho \"Waiting for Rancher to be rolled out\"\nkubectl -n cattle-system rollout status deploy/rancher\nkubectl -n cattle-system get deploy rancher\n\necho \"Waiting 3 minutes for Rancher to be ready to deploy downstream clusters\"\nsleep 180' > /tmp/setup.sh", "chmod +x /tmp/setup.sh", "bash -c '/tmp/setup.sh https://releases.rancher.com/server-charts/ latest v1.15.3 tfp-standalone.example.com v2.10.0 fake-bootstrap-password rancher/rancher bastion.example.com rancher/rancher-agent || true'"]
+  }
+}
+
@@ -0,0 +1,215 @@
+package variables
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/framework/format"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	variable  = "variable"
+	varPrefix = "var"
+	sensitive = "sensitive"
+	varType   = "type"
+	varString = "string"
+
+	AdminToken            = "rancher_admin_token"
+	AWSAccessKey          = "aws_access_key"
+	AWSSecretKey          = "aws_secret_key"
+	AzureClientSecret     = "azure_client_secret"
+	GoogleAuthEncodedJSON = "google_auth_encoded_json"
+	HarvesterKubeconfig   = "harvester_kubeconfig_content"
+	LinodeRootPass        = "linode_root_pass"
+	LinodeToken           = "linode_token"
+	VspherePassword       = "vsphere_password"
+	VsphereSSHPassword    = "vsphere_ssh_password"
+	RegistryPassword      = "registry_password"
+	S3BackupAccessKey     = "s3_backup_access_key"
+	S3BackupSecretKey     = "s3_backup_secret_key"
+
+	ADServiceAccountPassword       = "ad_service_account_password"
+	ADTestPassword                 = "ad_test_password"
+	AzureADApplicationSecret       = "azuread_application_secret"
+	GithubClientSecret             = "github_client_secret"
+	OktaSPKey                      = "okta_sp_key"
+	OpenLDAPServiceAccountPassword = "openldap_service_account_password"
+	OpenLDAPTestPassword           = "openldap_test_password"
+)
+
+// Values is a map of Terraform input variable names to the sensitive values they hold.
+type Values map[string]string
+
+// Name is a function that will return the name of the input variable holding the given key for the resources with the
+// given resource prefix.
+func Name(resourcePrefix, key string) string {
+	if resourcePrefix == "" {
+		return key
+	}
+
+	return resourcePrefix + "_" + key
+}
+
+// Reference is a function that will return the tokens referencing the input variable with the given name.
+func Reference(name string) hclwrite.Tokens {
+	return format.Reference(varPrefix, name)
+}
+
+// ProviderValues is a function that will return the values of the input variables referenced by the provider blocks.
+func ProviderValues(rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig) Values {
+	values := AWSProviderValues(terraformConfig)
+	values[AdminToken] = rancherConfig.AdminToken
+
+	return values
+}
+
+// AWSProviderValues is a function that will return the values of the input variables referenced by the aws provider
+// block.
+func AWSProviderValues(terraformConfig *config.TerraformConfig) Values {
+	return Values{
+		AWSAccessKey: terraformConfig.AWSCredentials.AWSAccessKey,
+		AWSSecretKey: terraformConfig.AWSCredentials.AWSSecretKey,
+	}
+}
+
+// ClusterValues is a function that will return the values of the input variables referenced by the resources with the
+// resource prefix of the given Terraform config.
+func ClusterValues(terraformConfig *config.TerraformConfig) Values {
+	values := map[string]string{
+		AWSAccessKey:          terraformConfig.AWSCredentials.AWSAccessKey,
+		AWSSecretKey:          terraformConfig.AWSCredentials.AWSSecretKey,
+		AzureClientSecret:     terraformConfig.AzureCredentials.ClientSecret,
+		GoogleAuthEncodedJSON: terraformConfig.GoogleCredentials.AuthEncodedJSON,
+		HarvesterKubeconfig:   terraformConfig.HarvesterCredentials.KubeconfigContent,
+		LinodeRootPass:        terraformConfig.LinodeConfig.LinodeRootPass,
+		LinodeToken:           terraformConfig.LinodeCredentials.LinodeToken,
+		VspherePassword:       terraformConfig.VsphereCredentials.Password,
+		VsphereSSHPassword:    terraformConfig.VsphereConfig.SSHPassword,
+
+		ADServiceAccountPassword:       terraformConfig.ADConfig.ServiceAccountPassword,
+		ADTestPassword:                 terraformConfig.ADConfig.TestPassword,
+		AzureADApplicationSecret:       terraformConfig.AzureADConfig.ApplicationSecret,
+		GithubClientSecret:             terraformConfig.GithubConfig.ClientSecret,
+		OktaSPKey:                      terraformConfig.OktaConfig.SPKey,
+		OpenLDAPServiceAccountPassword: terraformConfig.OpenLDAPConfig.ServiceAccountPassword,
+		OpenLDAPTestPassword:           terraformConfig.OpenLDAPConfig.TestPassword,
+	}
+
+	if terraformConfig.PrivateRegistries != nil {
+		values[RegistryPassword] = terraformConfig.PrivateRegistries.Password
+	}
+
+	if terraformConfig.ETCDRKE1 != nil && terraformConfig.ETCDRKE1.BackupConfig != nil && terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig != nil {
+		values[S3BackupAccessKey] = terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.AccessKey
+		values[S3BackupSecretKey] = terraformConfig.ETCDRKE1.BackupConfig.S3BackupConfig.SecretKey
+	}
+
+	clusterValues := Values{}
+	for key, value := range values {
+		clusterValues[Name(terraformConfig.ResourcePrefix, key)] = value
+	}
+
+	return clusterValues
+}
+
+// Merge is a function that will add the given values to the values.
+func (v Values) Merge(values Values) {
+	for name, value := range values {
+		v[name] = value
+	}
+}
+
+// WriteFiles is a function that will declare every input variable referenced by the given main.tf contents as a
+// sensitive variable in variables.tf, and write their values to terraform.tfvars.json in the given directory.
+func WriteFiles(keyPath string, mainTF []byte, values Values) error {
+	names, err := referencedVariables(mainTF)
+	if err != nil {
+		logrus.Errorf("Failed to parse main.tf file. Error: %v", err)
+		return err
+	}
+
+	variablesFile := hclwrite.NewEmptyFile()
+	variablesBody := variablesFile.Body()
+	tfvars := map[string]string{}
+
+	for i, name := range names {
+		value, ok := values[name]
+		if !ok {
+			return fmt.Errorf("No value found for variable %s", name)
+		}
+
+		if i > 0 {
+			variablesBody.AppendNewline()
+		}
+
+		variableBlockBody := variablesBody.AppendNewBlock(variable, []string{name}).Body()
+		variableBlockBody.SetAttributeRaw(varType, hclwrite.TokensForIdentifier(varString))
+		variableBlockBody.SetAttributeValue(sensitive, cty.True)
+
+		tfvars[name] = value
+	}
+
+	err = os.WriteFile(keyPath+configs.VariablesTF, variablesFile.Bytes(), 0644)
+	if err != nil {
+		logrus.Errorf("Failed to write variables.tf file. Error: %v", err)
+		return err
+	}
+
+	tfvarsJSON, err := json.MarshalIndent(tfvars, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(keyPath+configs.TFVarsJSON, append(tfvarsJSON, '\n'), 0600)
+	if err != nil {
+		logrus.Errorf("Failed to write terraform.tfvars.json file. Error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// referencedVariables returns the sorted names of the input variables referenced by the given Terraform contents.
+func referencedVariables(content []byte) ([]string, error) {
+	file, diags := hclsyntax.ParseConfig(content, "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	referenced := map[string]bool{}
+
+	diags = hclsyntax.VisitAll(file.Body.(*hclsyntax.Body), func(node hclsyntax.Node) hcl.Diagnostics {
+		expression, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok || expression.Traversal.RootName() != varPrefix || len(expression.Traversal) < 2 {
+			return nil
+		}
+
+		if attribute, ok := expression.Traversal[1].(hcl.TraverseAttr); ok {
+			referenced[attribute.Name] = true
+		}
+
+		return nil
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	names := []string{}
+	for name := range referenced {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
@@ -1,35 +1,100 @@
 package framework
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/binaries"
+	"github.com/rancher/tfp-automation/defaults/configs"
 	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/backend"
+	"github.com/rancher/tfp-automation/framework/mirror"
+	"github.com/rancher/tfp-automation/framework/redact"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
 )
 
-// Setup is a function that will set the Terraform configuration and return the Terraform options.
+// Setup is a function that will set the Terraform configuration and return the Terraform options. The options run
+// the binary, terraform or tofu, set by TERRAFORM_BINARY, and install release candidate providers, and providers with
+// a mirror set, from filesystem mirrors. When a remote state backend is set, the state of each test is kept in its own
+// workspace, named after its working directory. The process environment is left as is, so that tests calling Setup can
+// run in parallel.
+// The sensitive values of the Terraform configuration are masked in the Terraform output that is logged.
 func Setup(t *testing.T, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, keyPath string) *terraform.Options {
 	var terratestLogger logger.Logger
 
-	if strings.Contains(keyPath, keypath.RancherKeyPath) {
+	redact.Register(terraformConfig)
+
+	rancherKeyPath := strings.Contains(keyPath, keypath.RancherKeyPath)
+	if rancherKeyPath {
 		terratestLogger = getLogger(terratestConfig.TFLogging)
+		keyPath = workingDir(t, terratestConfig)
 	} else {
 		terratestLogger = getLogger(terratestConfig.StandaloneLogging)
 	}
 
+	binary, err := binaries.Binary()
+	require.NoError(t, err)
+
+	logrus.Infof("Running the tests with the %s binary.", binary)
+
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: keyPath,
-		NoColor:      true,
-		Logger:       &terratestLogger,
+		TerraformBinary: binary,
+		TerraformDir:    keyPath,
+		NoColor:         true,
+		Logger:          redact.Logger(&terratestLogger),
 	})
 
+	cliConfig, err := mirror.CLIConfig(t.TempDir(), terraformConfig, terratestConfig)
+	require.NoError(t, err)
+
+	if cliConfig != "" {
+		terraformOptions.EnvVars = map[string]string{mirror.CLIConfigEnvVar: cliConfig}
+	}
+
+	if rancherKeyPath {
+		backend.Configure(t, terraformOptions, terraformConfig, filepath.Base(keyPath))
+	}
+
 	return terraformOptions
 }
 
+// workingDir is a function that will create a unique Terraform working directory for the given test, named after the
+// test and the time it started at. The directory is created under the configured working directory root, or the
+// system temp directory if none is set. It is removed
+// once the test finishes, unless it still holds Terraform state that has not been destroyed.
+func workingDir(t *testing.T, terratestConfig *config.TerratestConfig) string {
+	root := terratestConfig.WorkingDirRoot
+	if root == "" {
+		root = os.TempDir()
+	}
+
+	err := os.MkdirAll(root, 0755)
+	require.NoError(t, err)
+
+	dir, err := os.MkdirTemp(root, backend.RunPrefix(t.Name(), time.Now()))
+	require.NoError(t, err)
+
+	logrus.Infof("Terraform working directory: %s", dir)
+
+	t.Cleanup(func() {
+		if _, err := os.Stat(dir + configs.TFState); err == nil {
+			logrus.Warnf("Keeping Terraform working directory %s, as it still holds Terraform state.", dir)
+			return
+		}
+
+		os.RemoveAll(dir)
+	})
+
+	return dir
+}
+
 func getLogger(tfLogging bool) logger.Logger {
 	if tfLogging {
 		logrus.Infof("Logging enabled. Terraform logs will be displayed.")
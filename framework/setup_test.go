@@ -0,0 +1,41 @@
+package framework
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/set/tags"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupParallel(t *testing.T) {
+	terratestConfig := &config.TerratestConfig{WorkingDirRoot: t.TempDir()}
+	testNameEnv := os.Getenv(tags.TestNameEnvVar)
+
+	var mutex sync.Mutex
+	dirs := map[string]string{}
+
+	t.Run("group", func(t *testing.T) {
+		for _, name := range []string{"first", "second"} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				terraformOptions := Setup(t, &config.TerraformConfig{}, terratestConfig, keypath.RancherKeyPath)
+
+				require.Equal(t, terratestConfig.WorkingDirRoot, filepath.Dir(terraformOptions.TerraformDir))
+				require.Equal(t, testNameEnv, os.Getenv(tags.TestNameEnvVar))
+
+				mutex.Lock()
+				dirs[t.Name()] = terraformOptions.TerraformDir
+				mutex.Unlock()
+			})
+		}
+	})
+
+	require.Len(t, dirs, 2)
+	require.NotEqual(t, dirs["TestSetupParallel/group/first"], dirs["TestSetupParallel/group/second"])
+}
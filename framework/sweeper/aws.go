@@ -0,0 +1,411 @@
+package sweeper
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/set/tags"
+)
+
+const (
+	EC2InstanceKind   = "EC2 instance"
+	LoadBalancerKind  = "load balancer"
+	Route53RecordKind = "Route 53 record"
+	TargetGroupKind   = "target group"
+
+	cname           = "CNAME"
+	maxDescribeTags = 20
+	deleteAction    = "DELETE"
+	nameTag         = "Name"
+	tagNameFilter   = "tag:Name"
+	stateFilter     = "instance-state-name"
+)
+
+var liveInstanceStates = []string{ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning,
+	ec2.InstanceStateNameStopping, ec2.InstanceStateNameStopped}
+
+// AWSSweeper finds the EC2 instances, load balancers, target groups and Route 53 records leaked by the sanity/aws
+// builders and the custom and imported cluster modules. Instances are matched on their Name tag, and load balancers and
+// target groups on their names. Target groups and Route 53 records have no creation time, so they are only swept along
+// with the load balancers they belong to, or, for a target group no load balancer uses, once its ExpiresAt tag has
+// passed.
+type AWSSweeper struct {
+	ec2     ec2iface.EC2API
+	elbv2   elbv2iface.ELBV2API
+	route53 route53iface.Route53API
+	zone    string
+}
+
+// NewAWSSweeper is a function that will return an AWSSweeper for the AWS credentials, region and Route 53 zone of the
+// given Terraform config.
+func NewAWSSweeper(terraformConfig *config.TerraformConfig) (*AWSSweeper, error) {
+	awsSession, err := session.NewSession(&aws.Config{
+		Region: aws.String(terraformConfig.AWSConfig.Region),
+		Credentials: credentials.NewStaticCredentials(terraformConfig.AWSCredentials.AWSAccessKey,
+			terraformConfig.AWSCredentials.AWSSecretKey, ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AWSSweeper{
+		ec2:     ec2.New(awsSession),
+		elbv2:   elbv2.New(awsSession),
+		route53: route53.New(awsSession),
+		zone:    terraformConfig.AWSConfig.AWSRoute53Zone,
+	}, nil
+}
+
+// Name returns the name of the platform swept.
+func (a *AWSSweeper) Name() string {
+	return "AWS"
+}
+
+// Find returns the leaked AWS resources selected by the given options. The Route 53 records come first, then the load
+// balancers, whose listeners are removed with them, then the target groups the listeners forwarded to and finally the
+// EC2 instances registered with them.
+func (a *AWSSweeper) Find(options Options, now time.Time) ([]Resource, error) {
+	loadBalancers, err := a.findLoadBalancers(options, now)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := a.findRoute53Records(options, loadBalancers)
+	if err != nil {
+		return nil, err
+	}
+
+	targetGroups, err := a.findTargetGroups(options, loadBalancers, now)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := a.findInstances(options, now)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := records
+	for _, loadBalancer := range loadBalancers {
+		resources = append(resources, loadBalancer.resource)
+	}
+
+	resources = append(resources, targetGroups...)
+	resources = append(resources, instances...)
+
+	return resources, nil
+}
+
+// sweptLoadBalancer is a load balancer being swept, along with what its Route 53 records and target groups are
+// matched against.
+type sweptLoadBalancer struct {
+	arn      string
+	dnsName  string
+	resource Resource
+}
+
+// findLoadBalancers returns the load balancers selected by the given options.
+func (a *AWSSweeper) findLoadBalancers(options Options, now time.Time) ([]sweptLoadBalancer, error) {
+	var loadBalancers []sweptLoadBalancer
+
+	err := a.elbv2.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{},
+		func(page *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+			for _, loadBalancer := range page.LoadBalancers {
+				name := aws.StringValue(loadBalancer.LoadBalancerName)
+				created := aws.TimeValue(loadBalancer.CreatedTime)
+
+				if !options.Matches(name, created, now) {
+					continue
+				}
+
+				arn := aws.StringValue(loadBalancer.LoadBalancerArn)
+
+				loadBalancers = append(loadBalancers, sweptLoadBalancer{
+					arn:     arn,
+					dnsName: normalizeDNSName(aws.StringValue(loadBalancer.DNSName)),
+					resource: Resource{
+						Kind:    LoadBalancerKind,
+						Name:    name,
+						Created: created,
+						Delete: func() error {
+							return a.deleteLoadBalancer(arn)
+						},
+					},
+				})
+			}
+
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return loadBalancers, nil
+}
+
+// findRoute53Records returns the CNAME records of the configured zone selected by the given options that point at one
+// of the given load balancers.
+func (a *AWSSweeper) findRoute53Records(options Options, loadBalancers []sweptLoadBalancer) ([]Resource, error) {
+	if a.zone == "" || len(loadBalancers) == 0 {
+		return nil, nil
+	}
+
+	zones, err := a.route53.ListHostedZonesByName(&route53.ListHostedZonesByNameInput{DNSName: aws.String(a.zone)})
+	if err != nil {
+		return nil, err
+	}
+
+	zoneName := normalizeDNSName(a.zone)
+
+	var zoneID string
+	for _, zone := range zones.HostedZones {
+		if normalizeDNSName(aws.StringValue(zone.Name)) == zoneName && (zone.Config == nil || !aws.BoolValue(zone.Config.PrivateZone)) {
+			zoneID = aws.StringValue(zone.Id)
+			break
+		}
+	}
+
+	if zoneID == "" {
+		return nil, nil
+	}
+
+	sweptDNSNames := map[string]Resource{}
+	for _, loadBalancer := range loadBalancers {
+		sweptDNSNames[loadBalancer.dnsName] = loadBalancer.resource
+	}
+
+	var records []Resource
+
+	err = a.route53.ListResourceRecordSetsPages(&route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(zoneID)},
+		func(page *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+			for _, recordSet := range page.ResourceRecordSets {
+				name := strings.TrimSuffix(normalizeDNSName(aws.StringValue(recordSet.Name)), "."+zoneName)
+
+				if aws.StringValue(recordSet.Type) != cname || !options.MatchesName(name) || len(recordSet.ResourceRecords) != 1 {
+					continue
+				}
+
+				loadBalancer, ok := sweptDNSNames[normalizeDNSName(aws.StringValue(recordSet.ResourceRecords[0].Value))]
+				if !ok {
+					continue
+				}
+
+				records = append(records, Resource{
+					Kind:    Route53RecordKind,
+					Name:    name,
+					Created: loadBalancer.Created,
+					Delete: func() error {
+						_, err := a.route53.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+							HostedZoneId: aws.String(zoneID),
+							ChangeBatch: &route53.ChangeBatch{
+								Changes: []*route53.Change{{Action: aws.String(deleteAction), ResourceRecordSet: recordSet}},
+							},
+						})
+
+						return err
+					},
+				})
+			}
+
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// findTargetGroups returns the target groups selected by the given options that are only used by the given load
+// balancers, or by none. A target group used by no load balancer has no creation time, and may belong to a run that
+// has not created its listeners yet, so it is only swept once the ExpiresAt tag stamped on it has passed.
+func (a *AWSSweeper) findTargetGroups(options Options, loadBalancers []sweptLoadBalancer, now time.Time) ([]Resource, error) {
+	sweptARNs := map[string]Resource{}
+	for _, loadBalancer := range loadBalancers {
+		sweptARNs[loadBalancer.arn] = loadBalancer.resource
+	}
+
+	var targetGroups []Resource
+	var targetGroupARNs, orphanARNs []string
+
+	err := a.elbv2.DescribeTargetGroupsPages(&elbv2.DescribeTargetGroupsInput{},
+		func(page *elbv2.DescribeTargetGroupsOutput, lastPage bool) bool {
+			for _, targetGroup := range page.TargetGroups {
+				name := aws.StringValue(targetGroup.TargetGroupName)
+				if !options.MatchesName(name) {
+					continue
+				}
+
+				var created time.Time
+				inUse := false
+
+				for _, loadBalancerARN := range targetGroup.LoadBalancerArns {
+					loadBalancer, ok := sweptARNs[aws.StringValue(loadBalancerARN)]
+					if !ok {
+						inUse = true
+						break
+					}
+
+					created = loadBalancer.Created
+				}
+
+				if inUse {
+					continue
+				}
+
+				arn := targetGroup.TargetGroupArn
+
+				if len(targetGroup.LoadBalancerArns) == 0 {
+					orphanARNs = append(orphanARNs, aws.StringValue(arn))
+				}
+
+				targetGroupARNs = append(targetGroupARNs, aws.StringValue(arn))
+				targetGroups = append(targetGroups, Resource{
+					Kind:    TargetGroupKind,
+					Name:    name,
+					Created: created,
+					Delete: func() error {
+						_, err := a.elbv2.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: arn})
+						return err
+					},
+				})
+			}
+
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	expiries, err := a.expiries(orphanARNs)
+	if err != nil {
+		return nil, err
+	}
+
+	var swept []Resource
+	for i, targetGroup := range targetGroups {
+		if slices.Contains(orphanARNs, targetGroupARNs[i]) {
+			expiresAt, ok := expiries[targetGroupARNs[i]]
+			if !ok || expiresAt.After(now) {
+				continue
+			}
+		}
+
+		swept = append(swept, targetGroup)
+	}
+
+	return swept, nil
+}
+
+// expiries returns the time in the ExpiresAt tag of each of the given load balancing resources, by ARN. Resources
+// without the tag are left out.
+func (a *AWSSweeper) expiries(arns []string) (map[string]time.Time, error) {
+	expiries := map[string]time.Time{}
+
+	for start := 0; start < len(arns); start += maxDescribeTags {
+		end := min(start+maxDescribeTags, len(arns))
+
+		output, err := a.elbv2.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: aws.StringSlice(arns[start:end])})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, description := range output.TagDescriptions {
+			for _, tag := range description.Tags {
+				if aws.StringValue(tag.Key) != tags.ExpiresAt {
+					continue
+				}
+
+				expiresAt, err := time.Parse(time.RFC3339, aws.StringValue(tag.Value))
+				if err == nil {
+					expiries[aws.StringValue(description.ResourceArn)] = expiresAt
+				}
+			}
+		}
+	}
+
+	return expiries, nil
+}
+
+// findInstances returns the EC2 instances that are not yet terminated and whose Name tag is selected by the given
+// options.
+func (a *AWSSweeper) findInstances(options Options, now time.Time) ([]Resource, error) {
+	var instances []Resource
+
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String(tagNameFilter), Values: aws.StringSlice([]string{options.Pattern})},
+			{Name: aws.String(stateFilter), Values: aws.StringSlice(liveInstanceStates)},
+		},
+	}
+
+	err := a.ec2.DescribeInstancesPages(input, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				name := instanceName(instance)
+				created := aws.TimeValue(instance.LaunchTime)
+
+				if !options.Matches(name, created, now) {
+					continue
+				}
+
+				instanceID := instance.InstanceId
+
+				instances = append(instances, Resource{
+					Kind:    EC2InstanceKind,
+					Name:    name + " (" + aws.StringValue(instanceID) + ")",
+					Created: created,
+					Delete: func() error {
+						_, err := a.ec2.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: []*string{instanceID}})
+						return err
+					},
+				})
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
+
+// deleteLoadBalancer deletes the load balancer with the given ARN, along with its listeners, and waits for it to be
+// removed, so that the target groups it forwarded to can be deleted.
+func (a *AWSSweeper) deleteLoadBalancer(arn string) error {
+	_, err := a.elbv2.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(arn)})
+	if err != nil {
+		return err
+	}
+
+	return a.elbv2.WaitUntilLoadBalancersDeleted(&elbv2.DescribeLoadBalancersInput{LoadBalancerArns: aws.StringSlice([]string{arn})})
+}
+
+// instanceName returns the value of the Name tag of the given instance.
+func instanceName(instance *ec2.Instance) string {
+	for _, tag := range instance.Tags {
+		if aws.StringValue(tag.Key) == nameTag {
+			return aws.StringValue(tag.Value)
+		}
+	}
+
+	return ""
+}
+
+// normalizeDNSName returns the given DNS name in lower case, without its trailing dot.
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
@@ -0,0 +1,136 @@
+package sweeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/rancher/tfp-automation/framework/set/tags"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEC2 struct {
+	ec2iface.EC2API
+	instances []*ec2.Instance
+}
+
+func (f *fakeEC2) DescribeInstancesPages(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: f.instances}}}, true)
+	return nil
+}
+
+type fakeELBV2 struct {
+	elbv2iface.ELBV2API
+	loadBalancers []*elbv2.LoadBalancer
+	targetGroups  []*elbv2.TargetGroup
+	tags          map[string][]*elbv2.Tag
+}
+
+func (f *fakeELBV2) DescribeLoadBalancersPages(input *elbv2.DescribeLoadBalancersInput, fn func(*elbv2.DescribeLoadBalancersOutput, bool) bool) error {
+	fn(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: f.loadBalancers}, true)
+	return nil
+}
+
+func (f *fakeELBV2) DescribeTargetGroupsPages(input *elbv2.DescribeTargetGroupsInput, fn func(*elbv2.DescribeTargetGroupsOutput, bool) bool) error {
+	fn(&elbv2.DescribeTargetGroupsOutput{TargetGroups: f.targetGroups}, true)
+	return nil
+}
+
+func (f *fakeELBV2) DescribeTags(input *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
+	output := &elbv2.DescribeTagsOutput{}
+	for _, arn := range input.ResourceArns {
+		output.TagDescriptions = append(output.TagDescriptions, &elbv2.TagDescription{ResourceArn: arn, Tags: f.tags[aws.StringValue(arn)]})
+	}
+
+	return output, nil
+}
+
+func expiresAtTag(expiresAt time.Time) []*elbv2.Tag {
+	return []*elbv2.Tag{{Key: aws.String(tags.ExpiresAt), Value: aws.String(expiresAt.Format(time.RFC3339))}}
+}
+
+type fakeRoute53 struct {
+	route53iface.Route53API
+	recordSets []*route53.ResourceRecordSet
+}
+
+func (f *fakeRoute53) ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+	return &route53.ListHostedZonesByNameOutput{HostedZones: []*route53.HostedZone{
+		{Id: aws.String("/hostedzone/PRIVATE"), Name: aws.String("example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)}},
+		{Id: aws.String("/hostedzone/PUBLIC"), Name: aws.String("example.com.")},
+	}}, nil
+}
+
+func (f *fakeRoute53) ListResourceRecordSetsPages(input *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
+	if aws.StringValue(input.HostedZoneId) == "/hostedzone/PUBLIC" {
+		fn(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: f.recordSets}, true)
+	}
+
+	return nil
+}
+
+func cnameRecord(name, value string) *route53.ResourceRecordSet {
+	return &route53.ResourceRecordSet{
+		Name:            aws.String(name),
+		Type:            aws.String(cname),
+		ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(value)}},
+	}
+}
+
+func TestAWSSweeperFind(t *testing.T) {
+	old := now.Add(-24 * time.Hour)
+	recent := now.Add(-time.Hour)
+
+	awsSweeper := &AWSSweeper{
+		ec2: &fakeEC2{instances: []*ec2.Instance{
+			{InstanceId: aws.String("i-old"), LaunchTime: aws.Time(old), Tags: []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("tfp-old-server1")}}},
+			{InstanceId: aws.String("i-recent"), LaunchTime: aws.Time(recent), Tags: []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("tfp-recent-server1")}}},
+		}},
+		elbv2: &fakeELBV2{
+			loadBalancers: []*elbv2.LoadBalancer{
+				{LoadBalancerArn: aws.String("arn:old"), LoadBalancerName: aws.String("tfp-old"), CreatedTime: aws.Time(old), DNSName: aws.String("tfp-old.elb.amazonaws.com")},
+				{LoadBalancerArn: aws.String("arn:recent"), LoadBalancerName: aws.String("tfp-recent"), CreatedTime: aws.Time(recent), DNSName: aws.String("tfp-recent.elb.amazonaws.com")},
+				{LoadBalancerArn: aws.String("arn:other"), LoadBalancerName: aws.String("other"), CreatedTime: aws.Time(old), DNSName: aws.String("other.elb.amazonaws.com")},
+			},
+			targetGroups: []*elbv2.TargetGroup{
+				{TargetGroupArn: aws.String("arn:tg-old"), TargetGroupName: aws.String("tfp-old-tg-80"), LoadBalancerArns: aws.StringSlice([]string{"arn:old"})},
+				{TargetGroupArn: aws.String("arn:tg-recent"), TargetGroupName: aws.String("tfp-recent-tg-80"), LoadBalancerArns: aws.StringSlice([]string{"arn:recent"})},
+				{TargetGroupArn: aws.String("arn:tg-expired"), TargetGroupName: aws.String("tfp-expired-tg-443")},
+				{TargetGroupArn: aws.String("arn:tg-unexpired"), TargetGroupName: aws.String("tfp-unexpired-tg-443")},
+				{TargetGroupArn: aws.String("arn:tg-untagged"), TargetGroupName: aws.String("tfp-untagged-tg-443")},
+			},
+			tags: map[string][]*elbv2.Tag{
+				"arn:tg-expired":   expiresAtTag(recent),
+				"arn:tg-unexpired": expiresAtTag(now.Add(time.Hour)),
+			},
+		},
+		route53: &fakeRoute53{recordSets: []*route53.ResourceRecordSet{
+			cnameRecord("tfp-old.example.com.", "tfp-old.elb.amazonaws.com"),
+			cnameRecord("tfp-recent.example.com.", "tfp-recent.elb.amazonaws.com"),
+			cnameRecord("tfp-other.example.com.", "other.elb.amazonaws.com"),
+		}},
+		zone: "example.com",
+	}
+
+	resources, err := awsSweeper.Find(Options{Pattern: "tfp-*", MinAge: 6 * time.Hour}, now)
+	require.NoError(t, err)
+
+	var found []string
+	for _, resource := range resources {
+		found = append(found, resource.String())
+	}
+
+	require.Equal(t, []string{
+		"Route 53 record tfp-old",
+		"load balancer tfp-old",
+		"target group tfp-old-tg-80",
+		"target group tfp-expired-tg-443",
+		"EC2 instance tfp-old-server1 (i-old)",
+	}, found)
+}
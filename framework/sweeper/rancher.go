@@ -0,0 +1,221 @@
+package sweeper
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rancher/norman/types"
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	v1 "github.com/rancher/shepherd/clients/rancher/v1"
+	"github.com/rancher/shepherd/extensions/defaults"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/stevetypes"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	CloudCredentialKind = "cloud credential"
+	ClusterKind         = "cluster"
+	ClusterV2Kind       = "provisioning cluster"
+	NodeTemplateKind    = "node template"
+	PSACTKind           = "PSACT"
+	UserKind            = "user"
+
+	fleetDefault = "fleet-default"
+	localCluster = "local"
+	notFound     = "404"
+)
+
+// RancherSweeper finds the clusters, cloud credentials, node templates, PSACTs and test users leaked in a Rancher
+// server. Test users are not named after the resourcePrefix, so when Users is set, every test user at least the
+// minimum age is swept, whatever the pattern.
+type RancherSweeper struct {
+	client *rancher.Client
+}
+
+// NewRancherSweeper is a function that will return a RancherSweeper using the given admin client.
+func NewRancherSweeper(client *rancher.Client) *RancherSweeper {
+	return &RancherSweeper{client: client}
+}
+
+// Name returns the name of the platform swept.
+func (r *RancherSweeper) Name() string {
+	return "Rancher"
+}
+
+// Find returns the leaked Rancher resources selected by the given options. The clusters come first, as the node
+// templates, cloud credentials and PSACTs they use cannot be removed, or are still needed, until the clusters are gone.
+func (r *RancherSweeper) Find(options Options, now time.Time) ([]Resource, error) {
+	var resources []Resource
+
+	clustersV2, err := r.client.Steve.SteveType(stevetypes.Provisioning).ListAll(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	provisioningClusters := map[string]bool{}
+
+	for _, cluster := range clustersV2.Data {
+		if cluster.Namespace != fleetDefault {
+			continue
+		}
+
+		provisioningClusters[cluster.Name] = true
+
+		if !options.Matches(cluster.Name, cluster.CreationTimestamp.Time, now) {
+			continue
+		}
+
+		resources = append(resources, Resource{
+			Kind:    ClusterV2Kind,
+			Name:    cluster.Name,
+			Created: cluster.CreationTimestamp.Time,
+			Delete: func() error {
+				return r.deleteClusterV2(&cluster)
+			},
+		})
+	}
+
+	clusters, err := r.client.Management.Cluster.ListAll(&types.ListOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range clusters.Data {
+		created := parseCreated(cluster.Created)
+		if cluster.ID == localCluster || provisioningClusters[cluster.Name] || !options.Matches(cluster.Name, created, now) {
+			continue
+		}
+
+		resources = append(resources, Resource{
+			Kind:    ClusterKind,
+			Name:    cluster.Name,
+			Created: created,
+			Delete: func() error {
+				return r.deleteCluster(&cluster)
+			},
+		})
+	}
+
+	nodeTemplates, err := r.client.Management.NodeTemplate.ListAll(&types.ListOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nodeTemplate := range nodeTemplates.Data {
+		resources = r.appendIfMatches(resources, options, now, NodeTemplateKind, nodeTemplate.Name, nodeTemplate.Created, func() error {
+			return r.client.Management.NodeTemplate.Delete(&nodeTemplate)
+		})
+	}
+
+	cloudCredentials, err := r.client.Management.CloudCredential.ListAll(&types.ListOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cloudCredential := range cloudCredentials.Data {
+		resources = r.appendIfMatches(resources, options, now, CloudCredentialKind, cloudCredential.Name, cloudCredential.Created, func() error {
+			return r.client.Management.CloudCredential.Delete(&cloudCredential)
+		})
+	}
+
+	psacts, err := r.client.Management.PodSecurityAdmissionConfigurationTemplate.ListAll(&types.ListOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, psact := range psacts.Data {
+		resources = r.appendIfMatches(resources, options, now, PSACTKind, psact.Name, psact.Created, func() error {
+			return r.client.Management.PodSecurityAdmissionConfigurationTemplate.Delete(&psact)
+		})
+	}
+
+	if !options.Users {
+		return resources, nil
+	}
+
+	users, err := r.client.Management.User.ListAll(&types.ListOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users.Data {
+		created := parseCreated(user.Created)
+		if !strings.HasPrefix(user.Username, configs.TestUser+"-") || !options.OldEnough(created, now) {
+			continue
+		}
+
+		resources = append(resources, Resource{
+			Kind:    UserKind,
+			Name:    user.Username,
+			Created: created,
+			Delete: func() error {
+				return r.client.Management.User.Delete(&user)
+			},
+		})
+	}
+
+	return resources, nil
+}
+
+// appendIfMatches appends the resource with the given kind, name and creation time to the given resources, if it is
+// selected by the given options.
+func (r *RancherSweeper) appendIfMatches(resources []Resource, options Options, now time.Time, kind, name, created string,
+	deleteFunc func() error) []Resource {
+	createdTime := parseCreated(created)
+	if !options.Matches(name, createdTime, now) {
+		return resources
+	}
+
+	return append(resources, Resource{Kind: kind, Name: name, Created: createdTime, Delete: deleteFunc})
+}
+
+// deleteClusterV2 deletes the given provisioning cluster and waits for it to be removed, so that its machines are
+// deprovisioned while their cloud credential still exists.
+func (r *RancherSweeper) deleteClusterV2(cluster *v1.SteveAPIObject) error {
+	steveClient := r.client.Steve.SteveType(stevetypes.Provisioning)
+
+	err := steveClient.Delete(cluster)
+	if err != nil {
+		return err
+	}
+
+	return kwait.PollUntilContextTimeout(context.TODO(), defaults.TenSecondTimeout, defaults.ThirtyMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		_, err = steveClient.ByID(cluster.ID)
+		if err != nil && strings.Contains(err.Error(), notFound) {
+			return true, nil
+		}
+
+		return false, nil
+	})
+}
+
+// deleteCluster deletes the given cluster and waits for it to be removed, so that its nodes are deprovisioned while
+// their node template and cloud credential still exist.
+func (r *RancherSweeper) deleteCluster(cluster *management.Cluster) error {
+	err := r.client.Management.Cluster.Delete(cluster)
+	if err != nil {
+		return err
+	}
+
+	return kwait.PollUntilContextTimeout(context.TODO(), defaults.TenSecondTimeout, defaults.ThirtyMinuteTimeout, true, func(ctx context.Context) (done bool, err error) {
+		_, err = r.client.Management.Cluster.ByID(cluster.ID)
+		if err != nil && strings.Contains(err.Error(), notFound) {
+			return true, nil
+		}
+
+		return false, nil
+	})
+}
+
+// parseCreated parses the given creation timestamp of a Rancher resource, returning the zero time if it is not valid.
+func parseCreated(created string) time.Time {
+	createdTime, err := time.Parse(time.RFC3339, created)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return createdTime
+}
@@ -0,0 +1,130 @@
+package sweeper
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Options selects the leaked resources to sweep. Pattern is matched, as a shell pattern such as tfp-*, against the
+// name each resource is given from the resourcePrefix. Only resources created at least MinAge ago are swept, so that
+// the resources of runs still in progress are left alone. Test users are not named after the resourcePrefix, so they
+// are only swept when Users is set, and then regardless of Pattern. With DryRun set, the resources are only logged.
+type Options struct {
+	Pattern string
+	MinAge  time.Duration
+	Users   bool
+	DryRun  bool
+}
+
+// Resource is a leaked resource found by a Sweeper, along with the function that deletes it.
+type Resource struct {
+	Kind    string
+	Name    string
+	Created time.Time
+	Delete  func() error
+}
+
+// String returns the kind and name of the resource.
+func (r Resource) String() string {
+	return r.Kind + " " + r.Name
+}
+
+// Sweeper finds the leaked resources of a single platform, such as Rancher or a cloud provider. The resources are
+// returned in the order they have to be deleted in, dependents first.
+type Sweeper interface {
+	Name() string
+	Find(options Options, now time.Time) ([]Resource, error)
+}
+
+// Validate is a function that will return an error if the given options could match every resource.
+func (o Options) Validate() error {
+	if o.Pattern == "" || o.Pattern == "*" {
+		return errors.New("a pattern narrower than * is required to sweep resources")
+	}
+
+	_, err := path.Match(o.Pattern, "")
+	if err != nil {
+		return fmt.Errorf("pattern %s is not valid: %w", o.Pattern, err)
+	}
+
+	if o.MinAge < 0 {
+		return fmt.Errorf("minimum age %s cannot be negative", o.MinAge)
+	}
+
+	return nil
+}
+
+// Matches is a function that will return whether a resource with the given name and creation time is selected by
+// the given options at the given time.
+func (o Options) Matches(name string, created, now time.Time) bool {
+	return o.MatchesName(name) && o.OldEnough(created, now)
+}
+
+// MatchesName is a function that will return whether the given name matches the pattern of the given options.
+func (o Options) MatchesName(name string) bool {
+	matched, err := path.Match(o.Pattern, name)
+
+	return err == nil && matched
+}
+
+// OldEnough is a function that will return whether a resource created at the given time is at least the minimum age
+// of the given options at the given time. Resources with an unknown creation time are never old enough.
+func (o Options) OldEnough(created, now time.Time) bool {
+	return !created.IsZero() && !created.After(now.Add(-o.MinAge))
+}
+
+// Sweep is a function that will find the resources selected by the given options with each of the given sweepers, in
+// order, and delete them, or only log them when DryRun is set. A resource that fails to delete does not stop the sweep;
+// every error is returned once all sweepers have run.
+func Sweep(sweepers []Sweeper, options Options) ([]Resource, error) {
+	err := options.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var swept []Resource
+	var errs []error
+
+	for _, sweeper := range sweepers {
+		resources, err := sweeper.Find(options, time.Now())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to find %s resources: %w", sweeper.Name(), err))
+			continue
+		}
+
+		logrus.Infof("Found %d leaked %s resources matching %s.", len(resources), sweeper.Name(), options.Pattern)
+
+		for _, resource := range resources {
+			if options.DryRun {
+				logrus.Infof("Would delete %s, created %s.", resource, created(resource))
+				swept = append(swept, resource)
+				continue
+			}
+
+			logrus.Infof("Deleting %s...", resource)
+
+			err = resource.Delete()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete %s: %w", resource, err))
+				continue
+			}
+
+			swept = append(swept, resource)
+		}
+	}
+
+	return swept, errors.Join(errs...)
+}
+
+// created returns the creation time of the given resource, for logging.
+func created(resource Resource) string {
+	if resource.Created.IsZero() {
+		return "at an unknown time"
+	}
+
+	return resource.Created.Format(time.RFC3339)
+}
@@ -0,0 +1,66 @@
+package sweeper
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+// fakeSweeper returns the given resources, recording the ones deleted.
+type fakeSweeper struct {
+	resources []Resource
+	deleted   []string
+}
+
+func (f *fakeSweeper) Name() string {
+	return "fake"
+}
+
+func (f *fakeSweeper) Find(options Options, now time.Time) ([]Resource, error) {
+	return f.resources, nil
+}
+
+func (f *fakeSweeper) resource(name string, err error) Resource {
+	return Resource{Kind: "fake", Name: name, Created: now, Delete: func() error {
+		f.deleted = append(f.deleted, name)
+		return err
+	}}
+}
+
+func TestOptions(t *testing.T) {
+	options := Options{Pattern: "tfp-*", MinAge: 6 * time.Hour}
+	require.NoError(t, options.Validate())
+
+	require.True(t, options.Matches("tfp-abc", now.Add(-7*time.Hour), now))
+	require.True(t, options.Matches("tfp-abc", now.Add(-6*time.Hour), now))
+	require.False(t, options.Matches("tfp-abc", now.Add(-time.Hour), now))
+	require.False(t, options.Matches("tfp-abc", time.Time{}, now))
+	require.False(t, options.Matches("auto-abc", now.Add(-7*time.Hour), now))
+
+	require.Error(t, Options{}.Validate())
+	require.Error(t, Options{Pattern: "*"}.Validate())
+	require.Error(t, Options{Pattern: "tfp-["}.Validate())
+	require.Error(t, Options{Pattern: "tfp-*", MinAge: -time.Hour}.Validate())
+}
+
+func TestSweep(t *testing.T) {
+	fake := &fakeSweeper{}
+	fake.resources = []Resource{fake.resource("first", nil), fake.resource("failed", errors.New("in use")), fake.resource("last", nil)}
+
+	swept, err := Sweep([]Sweeper{fake}, Options{Pattern: "tfp-*", DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, swept, 3)
+	require.Empty(t, fake.deleted)
+
+	swept, err = Sweep([]Sweeper{fake}, Options{Pattern: "tfp-*"})
+	require.ErrorContains(t, err, "failed to delete fake failed: in use")
+	require.Equal(t, []string{"first", "failed", "last"}, fake.deleted)
+	require.Len(t, swept, 2)
+
+	_, err = Sweep([]Sweeper{fake}, Options{Pattern: "*"})
+	require.Error(t, err)
+}
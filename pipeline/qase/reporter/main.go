@@ -5,11 +5,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/antihax/optional"
+	shepherdConfig "github.com/rancher/shepherd/pkg/config"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/binaries"
+	"github.com/rancher/tfp-automation/framework/redact"
 	defaults "github.com/rancher/tfp-automation/pipeline/qase"
 	"github.com/rancher/tfp-automation/pipeline/qase/testcase"
 	"github.com/sirupsen/logrus"
@@ -26,6 +31,8 @@ var (
 
 func main() {
 	if runIDEnvVar != "" {
+		registerSecrets()
+
 		cfg := qase.NewConfiguration()
 		cfg.AddDefaultHeader("Token", qaseToken)
 		client := qase.NewAPIClient(cfg)
@@ -250,14 +257,20 @@ func updateTestInRun(client *qase.APIClient, testCase testcase.GoTestCase, qaseT
 		}
 	}
 
+	// The binary is recorded with every result, so that runs against terraform and tofu can be told apart.
+	binary, err := binaries.Binary()
+	if err != nil {
+		return err
+	}
+
 	resultBody := qase.ResultCreate{
 		CaseId:  qaseTestCaseID,
 		Status:  status,
-		Comment: testCase.StackTrace,
+		Comment: redact.String(fmt.Sprintf("Binary: %s\n%s", binary, testCase.StackTrace)),
 		Time:    int64(elapsedTime),
 	}
 
-	_, _, err := client.ResultsApi.CreateResult(context.TODO(), resultBody, defaults.RancherManagerProjectID, testRunID)
+	_, _, err = client.ResultsApi.CreateResult(context.TODO(), resultBody, defaults.RancherManagerProjectID, testRunID)
 	if err != nil {
 		return err
 	}
@@ -265,6 +278,28 @@ func updateTestInRun(client *qase.APIClient, testCase testcase.GoTestCase, qaseT
 	return nil
 }
 
+// registerSecrets registers the sensitive values of the cattle-config set by CATTLE_TEST_CONFIG, so that they are
+// masked in the comments built from the test output. Without a config, only the values assigned to sensitive
+// attributes are masked.
+func registerSecrets() {
+	base := os.Getenv(shepherdConfig.ConfigEnvironmentKey)
+	if base == "" {
+		return
+	}
+
+	cattleConfig, err := config.LoadConfigFromFiles(base, filepath.SplitList(os.Getenv(config.OverlaysEnvironmentKey))...)
+	if err == nil {
+		err = config.ResolveSecrets(cattleConfig)
+	}
+
+	if err != nil {
+		logrus.Warnf("Failed to load the cattle-config, only sensitive attributes will be masked. Error: %v", err)
+		return
+	}
+
+	redact.Register(cattleConfig)
+}
+
 func getAutomationTestName(customFields []qase.CustomFieldValue) string {
 	for _, field := range customFields {
 		if field.Id == defaults.AutomationTestNameID {
@@ -1,7 +1,6 @@
 package airgap
 
 import (
-	"os"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
@@ -9,7 +8,6 @@ import (
 	"github.com/rancher/shepherd/clients/rancher"
 	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
 	"github.com/rancher/shepherd/extensions/token"
-	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/shepherd/pkg/session"
 	"github.com/rancher/tfp-automation/config"
@@ -34,7 +32,6 @@ type TfpAirgapProvisioningTestSuite struct {
 	terraformConfig            *config.TerraformConfig
 	terratestConfig            *config.TerratestConfig
 	standaloneTerraformOptions *terraform.Options
-	terraformOptions           *terraform.Options
 	registry                   string
 }
 
@@ -44,9 +41,12 @@ func (a *TfpAirgapProvisioningTestSuite) TearDownSuite() {
 }
 
 func (a *TfpAirgapProvisioningTestSuite) SetupSuite() {
-	a.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	a.cattleConfig = framework.LoadCattleConfig(a.T())
 	a.rancherConfig, a.terraformConfig, a.terratestConfig = config.LoadTFPConfigs(a.cattleConfig)
 
+	err := config.ValidateStandalone(a.terraformConfig)
+	require.NoError(a.T(), err)
+
 	keyPath := rancher2.SetKeyPath(keypath.AirgapKeyPath)
 	standaloneTerraformOptions := framework.Setup(a.T(), a.terraformConfig, a.terratestConfig, keyPath)
 	a.standaloneTerraformOptions = standaloneTerraformOptions
@@ -61,7 +61,7 @@ func (a *TfpAirgapProvisioningTestSuite) TfpSetupSuite() map[string]any {
 	testSession := session.NewSession()
 	a.session = testSession
 
-	a.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	a.cattleConfig = framework.LoadCattleConfig(a.T())
 	configMap, err := provisioning.UniquifyTerraform([]map[string]any{a.cattleConfig})
 	require.NoError(a.T(), err)
 
@@ -97,10 +97,6 @@ func (a *TfpAirgapProvisioningTestSuite) TfpSetupSuite() map[string]any {
 
 	operations.ReplaceValue([]string{"rancher", "host"}, a.rancherConfig.Host, configMap[0])
 
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-	terraformOptions := framework.Setup(a.T(), a.terraformConfig, a.terratestConfig, keyPath)
-	a.terraformOptions = terraformOptions
-
 	return a.cattleConfig
 }
 
@@ -135,9 +131,10 @@ func (a *TfpAirgapProvisioningTestSuite) TestTfpAirgapProvisioning() {
 
 		a.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(a.T(), a.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(a.T(), a.terraformConfig, a.terratestConfig, keyPath)
+			defer cleanup.Cleanup(a.T(), terraformOptions, terraformOptions.TerraformDir)
 
-			clusterIDs := provisioning.Provision(a.T(), a.client, a.rancherConfig, terraform, terratest, testUser, testPassword, a.terraformOptions, configMap, false)
+			clusterIDs := provisioning.Provision(a.T(), a.client, a.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap, false)
 			provisioning.VerifyClustersState(a.T(), a.client, clusterIDs)
 			provisioning.VerifyRegistry(a.T(), a.client, clusterIDs[0], terraform)
 		})
@@ -179,13 +176,14 @@ func (a *TfpAirgapProvisioningTestSuite) TestTfpAirgapUpgrading() {
 
 		a.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(a.T(), a.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(a.T(), a.terraformConfig, a.terratestConfig, keyPath)
+			defer cleanup.Cleanup(a.T(), terraformOptions, terraformOptions.TerraformDir)
 
-			clusterIDs := provisioning.Provision(a.T(), a.client, a.rancherConfig, terraform, terratest, testUser, testPassword, a.terraformOptions, configMap, false)
+			clusterIDs := provisioning.Provision(a.T(), a.client, a.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap, false)
 			provisioning.VerifyClustersState(a.T(), a.client, clusterIDs)
 			provisioning.VerifyRegistry(a.T(), a.client, clusterIDs[0], terraform)
 
-			provisioning.KubernetesUpgrade(a.T(), a.client, a.rancherConfig, terraform, terratest, testUser, testPassword, a.terraformOptions, configMap)
+			provisioning.KubernetesUpgrade(a.T(), a.client, a.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap)
 			provisioning.VerifyClustersState(a.T(), a.client, clusterIDs)
 			provisioning.VerifyRegistry(a.T(), a.client, clusterIDs[0], terraform)
 		})
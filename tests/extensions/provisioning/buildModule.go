@@ -2,34 +2,42 @@ package provisioning
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/configs"
-	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/redact"
 	framework "github.com/rancher/tfp-automation/framework/set"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
 	"github.com/sirupsen/logrus"
 )
 
-// BuildModule is a function that builds the Terraform module.
+// BuildModule is a function that builds the Terraform module and logs every file it is split into, with the
+// sensitive values masked.
 func BuildModule(t *testing.T, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig, configMap []map[string]any) error {
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
+	keyPath := t.TempDir()
 
-	_, err := framework.ConfigTF(nil, "", "", "", configMap, false)
+	_, err := framework.ConfigTF(keyPath, t.Name(), "", "", "", configMap, false)
 	if err != nil {
 		return err
 	}
 
-	module, err := os.ReadFile(keyPath + configs.MainTF)
+	generatedFiles, err := cleanup.GeneratedFiles(keyPath)
 	if err != nil {
-		logrus.Errorf("Failed to read main.tf file contents. Error: %v", err)
-
 		return err
 	}
 
-	t.Log(string(module))
+	for _, generatedFile := range generatedFiles {
+		module, err := os.ReadFile(generatedFile)
+		if err != nil {
+			logrus.Errorf("Failed to read %s file contents. Error: %v", filepath.Base(generatedFile), err)
+
+			return err
+		}
+
+		redact.Logf(t, "%s:\n%s", filepath.Base(generatedFile), string(module))
+	}
 
 	return nil
 }
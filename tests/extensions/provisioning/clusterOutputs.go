@@ -0,0 +1,20 @@
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/tfp-automation/framework/set/outputs"
+	"github.com/stretchr/testify/require"
+)
+
+// ClusterID is a function that will return the ID of the given cluster, as read from the Terraform output of the
+// cluster after terraform apply.
+func ClusterID(t *testing.T, terraformOptions *terraform.Options, clusterName string) string {
+	clusterOutputs := terraform.OutputMap(t, terraformOptions, clusterName)
+
+	clusterID := clusterOutputs[outputs.ClusterID]
+	require.NotEmpty(t, clusterID, "cluster %s has no %s output", clusterName, outputs.ClusterID)
+
+	return clusterID
+}
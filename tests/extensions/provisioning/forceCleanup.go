@@ -1,25 +1,204 @@
 package provisioning
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
-	"github.com/rancher/tfp-automation/defaults/keypath"
-	"github.com/rancher/tfp-automation/framework/cleanup"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/binaries"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/framework/backend"
+	"github.com/rancher/tfp-automation/framework/mirror"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/sirupsen/logrus"
 )
 
-// ForceCleanup is a function that will forcibly run terraform destroy and cleanup Terraform resources.
-func ForceCleanup(t *testing.T) error {
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
+const (
+	testWorkspacePrefix = "Test"
+
+	// defaultForceCleanupMinAge is how long ago a run has to have started, and its state last changed, for its
+	// leftovers to be cleaned up when forceCleanupMinAge is not set.
+	defaultForceCleanupMinAge = 6 * time.Hour
+)
+
+// ForceCleanup is a function that will forcibly run terraform destroy and cleanup Terraform resources in every
+// working directory, under the configured working directory root, that still holds Terraform state. The working
+// directories are only searched when workingDirRoot is set, as the system temp directory they default to is shared
+// with the suites running on the same machine. Only the working directories of runs that started, and whose state
+// last changed, longer than forceCleanupMinAge ago are cleaned up, as the others may belong to suites still running.
+// When a remote state backend is set, the resources of every test workspace left in it by a run that started longer
+// than forceCleanupMinAge ago are destroyed as well, so that leftovers of runs on other machines are cleaned up too.
+func ForceCleanup(t *testing.T, cattleConfig map[string]any) error {
+	_, terraformConfig, terratestConfig := config.LoadTFPConfigs(cattleConfig)
+
+	minAge, err := forceCleanupMinAge(terratestConfig)
+	if err != nil {
+		return err
+	}
+
+	var stateFiles []string
+
+	switch {
+	case terratestConfig.WorkingDirRoot != "":
+		stateFiles, err = filepath.Glob(filepath.Join(terratestConfig.WorkingDirRoot, testWorkspacePrefix+"*") + configs.TFState)
+		if err != nil {
+			return err
+		}
+	case backend.Type(terraformConfig.Backend) == "":
+		return errors.New("workingDirRoot is required to clean up the working directories left by other test runs")
+	default:
+		logrus.Warnf("workingDirRoot is not set, only the workspaces of the remote state backend are cleaned up.")
+	}
+
+	binary, err := binaries.Binary()
+	if err != nil {
+		return err
+	}
+
+	cliConfig, err := mirror.CLIConfig(t.TempDir(), terraformConfig, terratestConfig)
+	if err != nil {
+		return err
+	}
+
+	for _, stateFile := range stateFiles {
+		keyPath := filepath.Dir(stateFile)
+
+		if !leftoverWorkingDir(keyPath, stateFile, minAge) {
+			logrus.Infof("Skipping %s, as its run started or last changed its state less than %s ago.", keyPath, minAge)
+			continue
+		}
+
+		logrus.Infof("Cleaning up Terraform resources in %s...", keyPath)
+
+		terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+			TerraformBinary: binary,
+			TerraformDir:    keyPath,
+			NoColor:         true,
+		})
+
+		if cliConfig != "" {
+			terraformOptions.EnvVars = map[string]string{mirror.CLIConfigEnvVar: cliConfig}
+		}
+
+		terraform.Destroy(t, terraformOptions)
+
+		err = os.RemoveAll(keyPath)
+		if err != nil {
+			logrus.Errorf("Failed to delete Terraform working directory %s. Error: %v", keyPath, err)
+			return err
+		}
+	}
+
+	switch backend.Type(terraformConfig.Backend) {
+	case "":
+		return nil
+	case backend.HTTP:
+		logrus.Warnf("The http backend has no workspaces to list, leftover state in it has to be destroyed by hand.")
+		return nil
+	}
+
+	return forceCleanupWorkspaces(t, binary, cliConfig, cattleConfig, minAge)
+}
+
+// forceCleanupWorkspaces runs terraform destroy in, and then deletes, every test workspace of the remote backend set
+// by the given config whose run started at least the given minimum age ago. The workspaces of runs on other machines
+// that may still be in progress, and those whose name does not hold the time their run started at, are left alone.
+// The module is regenerated from the config, so that the providers the state needs are installed.
+func forceCleanupWorkspaces(t *testing.T, binary, cliConfig string, cattleConfig map[string]any, minAge time.Duration) error {
+	_, terraformConfig, _ := config.LoadTFPConfigs(cattleConfig)
+
+	keyPath := t.TempDir()
+	testUser, testPassword := configs.CreateTestCredentials()
+
+	_, err := framework.ConfigTF(keyPath, "", testUser, testPassword, "", []map[string]any{cattleConfig}, false)
+	if err != nil {
+		return err
+	}
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: keyPath,
-		NoColor:      true,
+		TerraformBinary: binary,
+		TerraformDir:    keyPath,
+		NoColor:         true,
 	})
 
-	terraform.Destroy(t, terraformOptions)
-	cleanup.TFFilesCleanup(keyPath)
+	if cliConfig != "" {
+		terraformOptions.EnvVars = map[string]string{mirror.CLIConfigEnvVar: cliConfig}
+	}
+
+	backend.SetOptions(terraformOptions, terraformConfig.Backend, "")
+
+	_, err = terraform.InitE(t, terraformOptions)
+	if err != nil {
+		return err
+	}
+
+	workspaces, err := backend.Workspaces(t, terraformOptions)
+	if err != nil {
+		return err
+	}
+
+	for _, workspace := range workspaces {
+		if !strings.HasPrefix(workspace, testWorkspacePrefix) {
+			continue
+		}
+
+		started, ok := backend.RunStarted(workspace)
+		if !ok || time.Since(started) < minAge {
+			logrus.Infof("Skipping the %s workspace, as its run may still be in progress.", workspace)
+			continue
+		}
+
+		logrus.Infof("Cleaning up Terraform resources in the %s workspace...", workspace)
+
+		terraformOptions.EnvVars[backend.WorkspaceEnvVar] = workspace
+
+		_, err = terraform.DestroyE(t, terraformOptions)
+		if err != nil {
+			return err
+		}
+
+		err = backend.DeleteWorkspace(t, terraformOptions, workspace)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
+
+// forceCleanupMinAge returns the minimum age of the runs whose leftovers are cleaned up, as set in the given config.
+func forceCleanupMinAge(terratestConfig *config.TerratestConfig) (time.Duration, error) {
+	if terratestConfig.ForceCleanupMinAge == "" {
+		return defaultForceCleanupMinAge, nil
+	}
+
+	minAge, err := time.ParseDuration(terratestConfig.ForceCleanupMinAge)
+	if err != nil {
+		return 0, fmt.Errorf("forceCleanupMinAge %q is not a duration: %w", terratestConfig.ForceCleanupMinAge, err)
+	}
+
+	return minAge, nil
+}
+
+// leftoverWorkingDir returns whether the run of the given working directory started, and last changed the given state
+// file, at least the given minimum age ago. A working directory whose name does not hold the time its run started at
+// is not a leftover, as its age cannot be told.
+func leftoverWorkingDir(keyPath, stateFile string, minAge time.Duration) bool {
+	started, ok := backend.RunStarted(filepath.Base(keyPath))
+	if !ok || time.Since(started) < minAge {
+		return false
+	}
+
+	info, err := os.Stat(stateFile)
+	if err != nil {
+		return false
+	}
+
+	return time.Since(info.ModTime()) >= minAge
+}
@@ -0,0 +1,237 @@
+package provisioning
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
+	"github.com/rancher/shepherd/extensions/cloudcredentials"
+	"github.com/rancher/tfp-automation/config"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/provisioning/imported"
+	resources "github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	globalRoleBindingResource = "rancher2_global_role_binding"
+	projectResource           = "rancher2_project"
+	userResource              = "rancher2_user"
+
+	amazonEC2Driver        = "amazonec2"
+	amazonEC2CredentialKey = "amazonec2_credential_config"
+	baseline               = "baseline"
+	latest                 = "latest"
+	notFound               = "404"
+	passwordKey            = "password"
+	standardUserRole       = "user"
+)
+
+// writeOnlyAttributes are the attributes, per resource type, that the rancher2 provider cannot read back from Rancher.
+// terraform import leaves them empty, so the plan right after an import is allowed to set them.
+var writeOnlyAttributes = map[string][]string{
+	userResource:             {passwordKey},
+	defaults.CloudCredential: {amazonEC2CredentialKey},
+}
+
+// ImportedResource is a resource created outside of Terraform, along with the ID terraform import adopts it by.
+type ImportedResource struct {
+	Address string
+	ID      string
+}
+
+// ImportResources is a function that will create the resources of the given configs through the Rancher API, set the main.tf
+// file declaring them, import them into the Terraform state and require the plan that follows to change nothing
+// besides the attributes the provider cannot read back. It returns the IDs of the imported clusters.
+func ImportResources(t *testing.T, client *rancher.Client, testUser, testPassword string, terraformOptions *terraform.Options,
+	configMap []map[string]any) []string {
+	_, err := framework.ImportTF(terraformOptions.TerraformDir, t.Name(), testUser, testPassword, configMap)
+	require.NoError(t, err)
+
+	importedResources := createUser(t, client, testUser, testPassword)
+	clusterIDs := []string{}
+
+	for _, cattleConfig := range configMap {
+		_, terraformConfig, _ := config.LoadTFPConfigs(cattleConfig)
+
+		clusterID, clusterResources := createClusterResources(t, client, terraformConfig)
+
+		clusterIDs = append(clusterIDs, clusterID)
+		importedResources = append(importedResources, clusterResources...)
+	}
+
+	terraform.Init(t, terraformOptions)
+
+	for _, importedResource := range importedResources {
+		TerraformImport(t, terraformOptions, importedResource)
+	}
+
+	RequireImportedPlan(t, TerraformPlanStruct(t, terraformOptions))
+
+	return clusterIDs
+}
+
+// TerraformImport is a function that will run terraform import for the given resource in the working directory of the
+// given options.
+func TerraformImport(t *testing.T, terraformOptions *terraform.Options, importedResource ImportedResource) {
+	logrus.Infof("Importing %s with ID %s...", importedResource.Address, importedResource.ID)
+
+	_, err := terraform.RunTerraformCommandE(t, terraformOptions,
+		terraform.FormatArgs(terraformOptions, "import", "-input=false", importedResource.Address, importedResource.ID)...)
+	require.NoError(t, err)
+}
+
+// RequireImportedPlan is a function that will fail the test if the given plan, taken right after terraform import,
+// creates, replaces or deletes any resource, or changes any attribute other than the write-only attributes of its
+// resource type. A resource that is created means it was declared but never imported.
+func RequireImportedPlan(t *testing.T, plan *terraform.PlanStruct) {
+	for address, resourceChange := range plan.ResourceChangesMap {
+		actions := resourceChange.Change.Actions
+		require.True(t, actions.NoOp() || actions.Update(), "expected %s to be imported, terraform plans %v", address, actions)
+
+		for _, changedAttribute := range ChangedAttributes(plan, address) {
+			writeOnly := false
+			for _, attribute := range writeOnlyAttributes[resourceChange.Type] {
+				if matchesAttribute(changedAttribute, attribute) {
+					writeOnly = true
+					break
+				}
+			}
+
+			require.True(t, writeOnly, "expected no changes after importing %s, terraform plans to change %s", address,
+				changedAttribute)
+		}
+	}
+}
+
+// createUser creates the test user and its global role binding, which the main.tf file always declares. Cleanup
+// functions deleting them, if terraform destroy did not, are registered with the session.
+func createUser(t *testing.T, client *rancher.Client, testUser, testPassword string) []ImportedResource {
+	enabled := true
+
+	user, err := client.Management.User.Create(&management.User{
+		Name:     testUser,
+		Username: testUser,
+		Password: testPassword,
+		Enabled:  &enabled,
+	})
+	require.NoError(t, err)
+
+	registerCleanup(client, func() error { return client.Management.User.Delete(user) })
+
+	binding, err := client.Management.GlobalRoleBinding.Create(&management.GlobalRoleBinding{
+		Name:         testUser,
+		GlobalRoleID: standardUserRole,
+		UserID:       user.ID,
+	})
+	require.NoError(t, err)
+
+	registerCleanup(client, func() error { return client.Management.GlobalRoleBinding.Delete(binding) })
+
+	return []ImportedResource{
+		{Address: userResource + "." + userResource, ID: user.ID},
+		{Address: globalRoleBindingResource + "." + globalRoleBindingResource, ID: binding.ID},
+	}
+}
+
+// createClusterResources creates the imported cluster, the project, the PSACT and, when AWS credentials are set, the
+// cloud credential that the main.tf file declares for the given config. Cleanup functions deleting them, if terraform
+// destroy did not, are registered with the session. It returns the ID of the cluster.
+func createClusterResources(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) (string, []ImportedResource) {
+	resourcePrefix := terraformConfig.ResourcePrefix
+
+	logrus.Infof("Creating resources %s through the Rancher API...", resourcePrefix)
+
+	cluster, err := client.Management.Cluster.Create(&management.Cluster{
+		Name:        resourcePrefix,
+		Description: imported.ClusterDescription,
+	})
+	require.NoError(t, err)
+
+	registerCleanup(client, func() error { return client.Management.Cluster.Delete(cluster) })
+
+	project, err := client.Management.Project.Create(&management.Project{
+		Name:      resourcePrefix,
+		ClusterID: cluster.ID,
+	})
+	require.NoError(t, err)
+
+	registerCleanup(client, func() error { return client.Management.Project.Delete(project) })
+
+	psact, err := client.Management.PodSecurityAdmissionConfigurationTemplate.Create(&management.PodSecurityAdmissionConfigurationTemplate{
+		Name:        resourcePrefix,
+		Description: resources.BaselineDescription,
+		Configuration: &management.PodSecurityAdmissionConfigurationTemplateSpec{
+			Defaults: &management.PodSecurityAdmissionConfigurationTemplateDefaults{
+				Audit:          baseline,
+				AuditVersion:   latest,
+				Enforce:        baseline,
+				EnforceVersion: latest,
+				Warn:           baseline,
+				WarnVersion:    latest,
+			},
+			Exemptions: &management.PodSecurityAdmissionConfigurationTemplateExemptions{
+				Namespaces: resources.BaselineExemptionNamespaces,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	registerCleanup(client, func() error { return client.Management.PodSecurityAdmissionConfigurationTemplate.Delete(psact) })
+
+	importedResources := []ImportedResource{
+		{Address: defaults.Cluster + "." + resourcePrefix, ID: cluster.ID},
+		{Address: projectResource + "." + resourcePrefix, ID: project.ID},
+		{Address: defaults.PodSecurityAdmission + "." + resourcePrefix, ID: psact.ID},
+	}
+
+	if terraformConfig.AWSCredentials.AWSAccessKey != "" {
+		cloudCredentialID := createAWSCloudCredential(t, client, terraformConfig)
+
+		importedResources = append(importedResources, ImportedResource{
+			Address: defaults.CloudCredential + "." + resourcePrefix,
+			ID:      cloudCredentialID + "." + amazonEC2Driver,
+		})
+	}
+
+	return cluster.ID, importedResources
+}
+
+// createAWSCloudCredential creates an AWS cloud credential named after the resource prefix of the given config. The
+// shepherd session does not clean up resources created through the generic client, so a cleanup function deleting it,
+// if terraform destroy did not, is registered with the session.
+func createAWSCloudCredential(t *testing.T, client *rancher.Client, terraformConfig *config.TerraformConfig) string {
+	cloudCredential := cloudcredentials.CloudCredential{
+		Name: terraformConfig.ResourcePrefix,
+		AmazonEC2CredentialConfig: &cloudcredentials.AmazonEC2CredentialConfig{
+			AccessKey: terraformConfig.AWSCredentials.AWSAccessKey,
+			SecretKey: terraformConfig.AWSCredentials.AWSSecretKey,
+		},
+	}
+
+	createdCloudCredential := &management.CloudCredential{}
+
+	err := client.Management.APIBaseClient.Ops.DoCreate(management.CloudCredentialType, cloudCredential, createdCloudCredential)
+	require.NoError(t, err)
+
+	registerCleanup(client, func() error { return client.Management.CloudCredential.Delete(createdCloudCredential) })
+
+	return createdCloudCredential.ID
+}
+
+// registerCleanup registers the given delete function with the session of the given client. A resource that is already
+// gone, because terraform destroy deleted it, is not an error.
+func registerCleanup(client *rancher.Client, deleteResource func() error) {
+	client.Session.RegisterCleanupFunc(func() error {
+		err := deleteResource()
+		if err != nil && strings.Contains(err.Error(), notFound) {
+			return nil
+		}
+
+		return err
+	})
+}
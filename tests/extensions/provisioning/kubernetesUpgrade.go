@@ -11,13 +11,15 @@ import (
 )
 
 // KubernetesUpgrade is a function that will run terraform apply and uprade the
-// Kubernetes version of the provisioned cluster.
+// Kubernetes version of the provisioned cluster. The plan must update the cluster in place.
 func KubernetesUpgrade(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig,
 	terratestConfig *config.TerratestConfig, testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any) {
 	DefaultUpgradedK8sVersion(t, client, terratestConfig, terraformConfig, configMap)
 
-	_, err := framework.ConfigTF(client, testUser, testPassword, "", configMap, false)
+	clusterNames, err := framework.ConfigTF(terraformOptions.TerraformDir, t.Name(), testUser, testPassword, "", configMap, false)
 	require.NoError(t, err)
 
+	RequireClustersUpdatedInPlace(t, TerraformPlan(t, terraformOptions), clusterNames)
+
 	terraform.Apply(t, terraformOptions)
 }
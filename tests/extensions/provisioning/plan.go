@@ -0,0 +1,286 @@
+package provisioning
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/stretchr/testify/require"
+)
+
+// ChangeAction is the action Terraform plans to take on a resource.
+type ChangeAction string
+
+const (
+	NoOp    ChangeAction = "noop"
+	Create  ChangeAction = "create"
+	Read    ChangeAction = "read"
+	Update  ChangeAction = "update"
+	Replace ChangeAction = "replace"
+	Delete  ChangeAction = "delete"
+	Move    ChangeAction = "move"
+	Import  ChangeAction = "import"
+
+	plannedChange = "planned_change"
+	planFile      = "tfplan"
+)
+
+// ResourceChange is the change Terraform plans to make to a single resource.
+type ResourceChange struct {
+	Address      string
+	ResourceType string
+	ResourceName string
+	Action       ChangeAction
+}
+
+// PlanChanges holds the resource changes of a Terraform plan.
+type PlanChanges []ResourceChange
+
+// planMessage is a single line of the machine readable output of terraform plan -json. Only the planned_change
+// messages are decoded.
+type planMessage struct {
+	Type   string `json:"type"`
+	Change struct {
+		Resource struct {
+			Addr         string `json:"addr"`
+			ResourceType string `json:"resource_type"`
+			ResourceName string `json:"resource_name"`
+		} `json:"resource"`
+		Action ChangeAction `json:"action"`
+	} `json:"change"`
+}
+
+// TerraformPlan is a function that will run terraform plan -json in the working directory of the given options and
+// return the resource changes it plans. Nothing is applied.
+func TerraformPlan(t *testing.T, terraformOptions *terraform.Options) PlanChanges {
+	output, err := terraform.RunTerraformCommandAndGetStdoutE(t, terraformOptions,
+		terraform.FormatArgs(terraformOptions, "plan", "-json", "-input=false", "-lock=false")...)
+	require.NoError(t, err)
+
+	changes, err := ParsePlan(output)
+	require.NoError(t, err)
+
+	return changes
+}
+
+// ParsePlan is a function that will parse the machine readable output of terraform plan -json into the resource
+// changes it plans. Lines that are not JSON, such as the ones some wrappers print around the output, are ignored.
+func ParsePlan(output string) (PlanChanges, error) {
+	changes := PlanChanges{}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		var message planMessage
+
+		err := json.Unmarshal([]byte(line), &message)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse terraform plan output %q: %w", line, err)
+		}
+
+		if message.Type != plannedChange {
+			continue
+		}
+
+		changes = append(changes, ResourceChange{
+			Address:      message.Change.Resource.Addr,
+			ResourceType: message.Change.Resource.ResourceType,
+			ResourceName: message.Change.Resource.ResourceName,
+			Action:       message.Change.Action,
+		})
+	}
+
+	return changes, scanner.Err()
+}
+
+// Changed returns the changes that modify a resource, leaving out the no-op and read changes.
+func (p PlanChanges) Changed() PlanChanges {
+	changed := PlanChanges{}
+	for _, change := range p {
+		if change.Action != NoOp && change.Action != Read {
+			changed = append(changed, change)
+		}
+	}
+
+	return changed
+}
+
+// Find returns the change planned for the resource with the given address.
+func (p PlanChanges) Find(address string) (ResourceChange, bool) {
+	for _, change := range p {
+		if change.Address == address {
+			return change, true
+		}
+	}
+
+	return ResourceChange{}, false
+}
+
+// Clusters returns the changes planned for the rancher2_cluster and rancher2_cluster_v2 resources of the given cluster.
+func (p PlanChanges) Clusters(clusterName string) PlanChanges {
+	clusters := PlanChanges{}
+	for _, change := range p {
+		if (change.ResourceType == defaults.Cluster || change.ResourceType == defaults.ClusterV2) && change.ResourceName == clusterName {
+			clusters = append(clusters, change)
+		}
+	}
+
+	return clusters
+}
+
+// RequireEmptyPlan is a function that will fail the test if the given plan changes any resource. It is used right
+// after terraform apply to catch resources the provider does not apply idempotently.
+func RequireEmptyPlan(t *testing.T, changes PlanChanges) {
+	require.Empty(t, changes.Changed(), "expected an empty plan after terraform apply")
+}
+
+// RequireClustersNotReplaced is a function that will fail the test if the given plan replaces or deletes the cluster
+// resource of any of the given clusters.
+func RequireClustersNotReplaced(t *testing.T, changes PlanChanges, clusterNames []string) {
+	for _, clusterName := range clusterNames {
+		for _, change := range changes.Clusters(clusterName) {
+			require.NotContains(t, []ChangeAction{Replace, Delete}, change.Action, "expected %s not to be replaced or deleted",
+				change.Address)
+		}
+	}
+}
+
+// RequireClustersUpdatedInPlace is a function that will fail the test unless the given plan updates the cluster
+// resource of each of the given clusters in place.
+func RequireClustersUpdatedInPlace(t *testing.T, changes PlanChanges, clusterNames []string) {
+	for _, clusterName := range clusterNames {
+		clusters := changes.Clusters(clusterName)
+		require.NotEmpty(t, clusters, "expected a planned change for cluster %s", clusterName)
+
+		for _, change := range clusters {
+			require.Equal(t, Update, change.Action, "expected %s to be updated in place", change.Address)
+		}
+	}
+}
+
+// TerraformPlanStruct is a function that will run terraform plan in the working directory of the given options, saving
+// the plan to a temporary file, and return the plan as shown by terraform show -json. Unlike TerraformPlan, the result
+// holds the current and planned values of every resource.
+func TerraformPlanStruct(t *testing.T, terraformOptions *terraform.Options) *terraform.PlanStruct {
+	planOptions, err := terraformOptions.Clone()
+	require.NoError(t, err)
+
+	planOptions.PlanFilePath = filepath.Join(t.TempDir(), planFile)
+
+	terraform.Plan(t, planOptions)
+
+	return terraform.ShowWithStruct(t, planOptions)
+}
+
+// ChangedAttributes is a function that will return the sorted paths, such as rke_config[0].machine_pools[1].quantity,
+// of the attributes of the given resource whose planned value differs from its current value. Attributes only known
+// after apply are left out.
+func ChangedAttributes(plan *terraform.PlanStruct, address string) []string {
+	resourceChange, ok := plan.ResourceChangesMap[address]
+	if !ok || resourceChange.Change == nil {
+		return nil
+	}
+
+	attributes := changedAttributes(resourceChange.Change.Before, resourceChange.Change.After, resourceChange.Change.AfterUnknown, "")
+	sort.Strings(attributes)
+
+	return attributes
+}
+
+// changedAttributes returns the paths under the given path at which the before and after values differ.
+func changedAttributes(before, after, afterUnknown any, path string) []string {
+	if unknown, ok := afterUnknown.(bool); ok && unknown {
+		return nil
+	}
+
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+
+	if beforeIsMap && afterIsMap {
+		unknownMap, _ := afterUnknown.(map[string]any)
+
+		keys := map[string]bool{}
+		for key := range beforeMap {
+			keys[key] = true
+		}
+
+		for key := range afterMap {
+			keys[key] = true
+		}
+
+		attributes := []string{}
+		for key := range keys {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+
+			attributes = append(attributes, changedAttributes(beforeMap[key], afterMap[key], unknownMap[key], keyPath)...)
+		}
+
+		return attributes
+	}
+
+	beforeList, beforeIsList := before.([]any)
+	afterList, afterIsList := after.([]any)
+
+	if beforeIsList && afterIsList && len(beforeList) == len(afterList) {
+		unknownList, _ := afterUnknown.([]any)
+
+		attributes := []string{}
+		for i := range beforeList {
+			var unknown any
+			if i < len(unknownList) {
+				unknown = unknownList[i]
+			}
+
+			attributes = append(attributes, changedAttributes(beforeList[i], afterList[i], unknown, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+
+		return attributes
+	}
+
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+
+	return []string{path}
+}
+
+// RequireDrift is a function that will fail the test unless the given plan changes each of the given attributes of
+// the given resource back to its declared value. An attribute matches when it, or any attribute nested under it, is
+// changed.
+func RequireDrift(t *testing.T, plan *terraform.PlanStruct, address string, attributes ...string) {
+	changed := ChangedAttributes(plan, address)
+
+	for _, attribute := range attributes {
+		found := false
+		for _, changedAttribute := range changed {
+			if matchesAttribute(changedAttribute, attribute) {
+				found = true
+				break
+			}
+		}
+
+		require.True(t, found, "expected terraform plan to report drift of %s on %s, changed attributes: %v", attribute, address, changed)
+	}
+}
+
+// matchesAttribute returns whether the given changed attribute is the given attribute or nested under it.
+func matchesAttribute(changedAttribute, attribute string) bool {
+	return changedAttribute == attribute || strings.HasPrefix(changedAttribute, attribute+".") ||
+		strings.HasPrefix(changedAttribute, attribute+"[")
+}
@@ -0,0 +1,57 @@
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const planOutput = `{"@level":"info","@message":"Terraform 1.9.8","type":"version","terraform":"1.9.8","ui":"1.2"}
+{"@level":"info","@message":"rancher2_cluster_v2.tfp-rke2: Refreshing state... [id=fleet-default/tfp-rke2]","type":"refresh_start","hook":{"resource":{"addr":"rancher2_cluster_v2.tfp-rke2","resource_type":"rancher2_cluster_v2","resource_name":"tfp-rke2"}}}
+{"@level":"info","@message":"rancher2_cluster_v2.tfp-rke2: Plan to update","type":"planned_change","change":{"resource":{"addr":"rancher2_cluster_v2.tfp-rke2","module":"","resource":"rancher2_cluster_v2.tfp-rke2","implied_provider":"rancher2","resource_type":"rancher2_cluster_v2","resource_name":"tfp-rke2","resource_key":null},"action":"update"}}
+{"@level":"info","@message":"rancher2_machine_config_v2.tfp-rke2: Plan to replace","type":"planned_change","change":{"resource":{"addr":"rancher2_machine_config_v2.tfp-rke2","module":"","resource":"rancher2_machine_config_v2.tfp-rke2","implied_provider":"rancher2","resource_type":"rancher2_machine_config_v2","resource_name":"tfp-rke2","resource_key":null},"action":"replace","reason":"cannot_update"}}
+{"@level":"info","@message":"Plan: 1 to add, 1 to change, 1 to destroy.","type":"change_summary","changes":{"add":1,"change":1,"import":0,"remove":1,"operation":"plan"}}
+`
+
+func TestParsePlan(t *testing.T) {
+	changes, err := ParsePlan(planOutput)
+	require.NoError(t, err)
+
+	require.Equal(t, PlanChanges{
+		{Address: "rancher2_cluster_v2.tfp-rke2", ResourceType: "rancher2_cluster_v2", ResourceName: "tfp-rke2", Action: Update},
+		{Address: "rancher2_machine_config_v2.tfp-rke2", ResourceType: "rancher2_machine_config_v2", ResourceName: "tfp-rke2", Action: Replace},
+	}, changes)
+
+	require.Equal(t, PlanChanges{changes[0]}, changes.Clusters("tfp-rke2"))
+	RequireClustersUpdatedInPlace(t, changes, []string{"tfp-rke2"})
+	RequireClustersNotReplaced(t, changes, []string{"tfp-rke2"})
+}
+
+func TestParsePlanWithoutChanges(t *testing.T) {
+	changes, err := ParsePlan(`{"@level":"info","@message":"Plan: 0 to add, 0 to change, 0 to destroy.","type":"change_summary","changes":{"add":0,"change":0,"import":0,"remove":0,"operation":"plan"}}`)
+	require.NoError(t, err)
+
+	RequireEmptyPlan(t, changes)
+}
+
+func TestChangedAttributes(t *testing.T) {
+	before := map[string]any{
+		"kubernetes_version": "v1.30.5+rke2r1",
+		"agent_env_vars":     []any{map[string]any{"name": "TFP_DRIFT", "value": "true"}},
+		"rke_config": []any{map[string]any{
+			"machine_pools": []any{map[string]any{"name": "pool0", "quantity": float64(2)}},
+		}},
+		"cluster_v1_id": "c-m-aaaaaaaa",
+	}
+	after := map[string]any{
+		"kubernetes_version": "v1.30.5+rke2r1",
+		"agent_env_vars":     []any{},
+		"rke_config": []any{map[string]any{
+			"machine_pools": []any{map[string]any{"name": "pool0", "quantity": float64(1)}},
+		}},
+	}
+	afterUnknown := map[string]any{"cluster_v1_id": true}
+
+	require.ElementsMatch(t, []string{"agent_env_vars", "rke_config[0].machine_pools[0].quantity"},
+		changedAttributes(before, after, afterUnknown, ""))
+}
@@ -0,0 +1,85 @@
+package provisioning
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/framework/mirror"
+	"github.com/rancher/tfp-automation/framework/providers"
+	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+// IgnoreUpgradedProviderVersions is a function that will set ignoreVersionEnvVar, in the given cattle config, for the
+// providers upgraded by the given terratest config. Their version environment variables would otherwise override both
+// the versions the clusters are provisioned with and the versions they are upgraded to. The process environment is
+// left as is, so that tests can run in parallel.
+func IgnoreUpgradedProviderVersions(terratestConfig *config.TerratestConfig, cattleConfig map[string]any) {
+	names := providers.Names(terratestConfig.UpgradedProviders)
+
+	for _, name := range names {
+		envVar := providers.VersionEnvVar(name)
+		if os.Getenv(envVar) != "" {
+			logrus.Warnf("Ignoring %s, as the %s provider versions are set by the config.", envVar, name)
+		}
+	}
+
+	_, terraformConfig, _ := config.LoadTFPConfigs(cattleConfig)
+	ignored := providers.IgnoreVersionEnvVars(terraformConfig.Providers, names)
+
+	operations.ReplaceValue([]string{config.TerraformConfigurationFileKey, "providers"}, ignored, cattleConfig)
+}
+
+// UpgradeProviders is a function that will rewrite the required_providers block of the provisioned module to the
+// upgraded provider versions set by the given config and run terraform init -upgrade. The module is regenerated with
+// the given RBAC role and Windows setting, which must match the ones it was provisioned with. The plan must then be
+// empty, as the upgraded providers must read the state written by the previous ones without changing any resource.
+// The version environment variables of the upgraded providers are ignored.
+func UpgradeProviders(t *testing.T, terratestConfig *config.TerratestConfig, testUser, testPassword string, rbacRole config.Role,
+	terraformOptions *terraform.Options, configMap []map[string]any, isWindows bool) {
+	require.NotNil(t, terratestConfig.UpgradedProviders, "upgradedProviders is required to upgrade providers")
+
+	upgradedProviders := providers.IgnoreVersionEnvVars(terratestConfig.UpgradedProviders,
+		providers.Names(terratestConfig.UpgradedProviders))
+
+	for _, cattleConfig := range configMap {
+		_, terraformConfig, _ := config.LoadTFPConfigs(cattleConfig)
+		upgraded := providers.Merge(terraformConfig.Providers, upgradedProviders)
+
+		operations.ReplaceValue([]string{config.TerraformConfigurationFileKey, "providers"}, upgraded, cattleConfig)
+	}
+
+	_, terraformConfig, _ := config.LoadTFPConfigs(configMap[0])
+
+	for _, provider := range providers.Configured(terraformConfig) {
+		logrus.Infof("Upgrading the %s provider to %s %s...", provider.Name, provider.Source, provider.Version)
+	}
+
+	_, err := framework.ConfigTF(terraformOptions.TerraformDir, t.Name(), testUser, testPassword, rbacRole, configMap, isWindows)
+	require.NoError(t, err)
+
+	cliConfig, err := mirror.CLIConfig(t.TempDir(), terraformConfig, terratestConfig)
+	require.NoError(t, err)
+
+	delete(terraformOptions.EnvVars, mirror.CLIConfigEnvVar)
+	if cliConfig != "" {
+		if terraformOptions.EnvVars == nil {
+			terraformOptions.EnvVars = map[string]string{}
+		}
+
+		terraformOptions.EnvVars[mirror.CLIConfigEnvVar] = cliConfig
+	}
+
+	terraformOptions.Upgrade = true
+	defer func() {
+		terraformOptions.Upgrade = false
+	}()
+
+	terraform.Init(t, terraformOptions)
+
+	RequireEmptyPlan(t, TerraformPlan(t, terraformOptions))
+}
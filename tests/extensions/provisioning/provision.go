@@ -5,7 +5,6 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
-	clusterExtensions "github.com/rancher/shepherd/extensions/clusters"
 	"github.com/rancher/tfp-automation/config"
 	framework "github.com/rancher/tfp-automation/framework/set"
 	"github.com/stretchr/testify/require"
@@ -22,16 +21,15 @@ func Provision(t *testing.T, client *rancher.Client, rancherConfig *rancher.Conf
 	isSupported := SupportedModules(terraformOptions, configMap)
 	require.True(t, isSupported)
 
-	clusterNames, err = framework.ConfigTF(client, testUser, testPassword, "", configMap, isWindows)
+	clusterNames, err = framework.ConfigTF(terraformOptions.TerraformDir, t.Name(), testUser, testPassword, "", configMap, isWindows)
 	require.NoError(t, err)
 
 	terraform.InitAndApply(t, terraformOptions)
 
-	for _, clusterName := range clusterNames {
-		clusterID, err := clusterExtensions.GetClusterIDByName(client, clusterName)
-		require.NoError(t, err)
+	RequireEmptyPlan(t, TerraformPlan(t, terraformOptions))
 
-		clusterIDs = append(clusterIDs, clusterID)
+	for _, clusterName := range clusterNames {
+		clusterIDs = append(clusterIDs, ClusterID(t, terraformOptions, clusterName))
 	}
 
 	return clusterIDs
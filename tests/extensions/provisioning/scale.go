@@ -1,21 +1,40 @@
 package provisioning
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
 	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	framework "github.com/rancher/tfp-automation/framework/set"
 	"github.com/stretchr/testify/require"
 )
 
 // Scale is a function that will run terraform apply and scale the provisioned
-// cluster, according to user's desired amount.
+// cluster, according to user's desired amount. The plan must update the cluster in place, or, for RKE1 and custom
+// clusters, whose nodes are scaled through rancher2_node_pool or aws_instance resources, must not replace it.
 func Scale(t *testing.T, client *rancher.Client, rancherConfig *rancher.Config, terraformConfig *config.TerraformConfig, terratestConfig *config.TerratestConfig,
 	testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any) {
-	_, err := framework.ConfigTF(client, testUser, testPassword, "", configMap, false)
+	_, err := framework.ConfigTF(terraformOptions.TerraformDir, t.Name(), testUser, testPassword, "", configMap, false)
 	require.NoError(t, err)
 
+	var updatedClusters, notReplacedClusters []string
+
+	for _, cattleConfig := range configMap {
+		_, clusterConfig, _ := config.LoadTFPConfigs(cattleConfig)
+
+		if strings.Contains(clusterConfig.Module, clustertypes.RKE1) || strings.Contains(clusterConfig.Module, clustertypes.CUSTOM) {
+			notReplacedClusters = append(notReplacedClusters, clusterConfig.ResourcePrefix)
+		} else {
+			updatedClusters = append(updatedClusters, clusterConfig.ResourcePrefix)
+		}
+	}
+
+	changes := TerraformPlan(t, terraformOptions)
+	RequireClustersUpdatedInPlace(t, changes, updatedClusters)
+	RequireClustersNotReplaced(t, changes, notReplacedClusters)
+
 	terraform.Apply(t, terraformOptions)
 }
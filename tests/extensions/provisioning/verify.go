@@ -132,11 +132,9 @@ func VerifyRegistry(t *testing.T, client *rancher.Client, clusterID string, terr
 	}
 }
 
-// VerifyNodeCount validates that a cluster has the expected number of nodes.
-func VerifyNodeCount(t *testing.T, client *rancher.Client, clusterName string, terraformConfig *config.TerraformConfig, nodeCount int64) {
-	clusterID, err := clusterExtensions.GetClusterIDByName(client, clusterName)
-	require.NoError(t, err)
-
+// VerifyNodeCount validates that the cluster with the given ID, as read from its Terraform output, has the expected
+// number of nodes.
+func VerifyNodeCount(t *testing.T, client *rancher.Client, clusterID string, terraformConfig *config.TerraformConfig, nodeCount int64) {
 	cluster, err := client.Management.Cluster.ByID(clusterID)
 	require.NoError(t, err)
 
@@ -14,7 +14,7 @@ func AuthConfig(t *testing.T, terraformConfig *config.TerraformConfig, terraform
 	isSupported := SupportedAuthProviders(terraformConfig, terraformOptions)
 	require.True(t, isSupported)
 
-	err := framework.AuthConfig(terraformConfig, testUser, testPassword)
+	err := framework.AuthConfig(terraformConfig, terraformOptions.TerraformDir, testUser, testPassword)
 	require.NoError(t, err)
 
 	terraform.InitAndApply(t, terraformOptions)
@@ -4,7 +4,6 @@ import (
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
-	ranchFrame "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/keypath"
 	"github.com/rancher/tfp-automation/framework"
@@ -23,11 +22,8 @@ type ProxyRancherTestSuite struct {
 }
 
 func (i *ProxyRancherTestSuite) TestCreateProxyRancher() {
-	i.terraformConfig = new(config.TerraformConfig)
-	ranchFrame.LoadConfig(config.TerraformConfigurationFileKey, i.terraformConfig)
-
-	i.terratestConfig = new(config.TerratestConfig)
-	ranchFrame.LoadConfig(config.TerratestConfigurationFileKey, i.terratestConfig)
+	cattleConfig := framework.LoadCattleConfig(i.T())
+	_, i.terraformConfig, i.terratestConfig = config.LoadTFPConfigs(cattleConfig)
 
 	keyPath := rancher2.SetKeyPath(keypath.ProxyKeyPath)
 	terraformOptions := framework.Setup(i.T(), i.terraformConfig, i.terratestConfig, keyPath)
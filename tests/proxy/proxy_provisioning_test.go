@@ -1,7 +1,6 @@
 package proxy
 
 import (
-	"os"
 	"strings"
 	"testing"
 
@@ -10,7 +9,6 @@ import (
 	"github.com/rancher/shepherd/clients/rancher"
 	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
 	"github.com/rancher/shepherd/extensions/token"
-	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/shepherd/pkg/session"
 	"github.com/rancher/tfp-automation/config"
@@ -36,7 +34,6 @@ type TfpProxyProvisioningTestSuite struct {
 	terraformConfig            *config.TerraformConfig
 	terratestConfig            *config.TerratestConfig
 	standaloneTerraformOptions *terraform.Options
-	terraformOptions           *terraform.Options
 	proxyBastion               string
 }
 
@@ -46,9 +43,12 @@ func (p *TfpProxyProvisioningTestSuite) TearDownSuite() {
 }
 
 func (p *TfpProxyProvisioningTestSuite) SetupSuite() {
-	p.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	p.cattleConfig = framework.LoadCattleConfig(p.T())
 	p.rancherConfig, p.terraformConfig, p.terratestConfig = config.LoadTFPConfigs(p.cattleConfig)
 
+	err := config.ValidateStandalone(p.terraformConfig)
+	require.NoError(p.T(), err)
+
 	keyPath := rancher2.SetKeyPath(keypath.ProxyKeyPath)
 	standaloneTerraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
 	p.standaloneTerraformOptions = standaloneTerraformOptions
@@ -63,7 +63,7 @@ func (p *TfpProxyProvisioningTestSuite) TfpSetupSuite() map[string]any {
 	testSession := session.NewSession()
 	p.session = testSession
 
-	p.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	p.cattleConfig = framework.LoadCattleConfig(p.T())
 	configMap, err := provisioning.UniquifyTerraform([]map[string]any{p.cattleConfig})
 	require.NoError(p.T(), err)
 
@@ -95,10 +95,6 @@ func (p *TfpProxyProvisioningTestSuite) TfpSetupSuite() map[string]any {
 	err = pipeline.PostRancherInstall(p.client, p.client.RancherConfig.AdminPassword)
 	require.NoError(p.T(), err)
 
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-	terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
-	p.terraformOptions = terraformOptions
-
 	return p.cattleConfig
 }
 
@@ -137,13 +133,14 @@ func (p *TfpProxyProvisioningTestSuite) TestTfpNoProxyProvisioning() {
 
 		p.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(p.T(), p.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
+			defer cleanup.Cleanup(p.T(), terraformOptions, terraformOptions.TerraformDir)
 
-			clusterIDs := provisioning.Provision(p.T(), p.client, p.rancherConfig, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, false)
+			clusterIDs := provisioning.Provision(p.T(), p.client, p.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap, false)
 			provisioning.VerifyClustersState(p.T(), p.client, clusterIDs)
 
 			if strings.Contains(terraform.Module, modules.CustomEC2RKE2Windows) {
-				clusterIDs := provisioning.Provision(p.T(), p.client, p.rancherConfig, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, true)
+				clusterIDs := provisioning.Provision(p.T(), p.client, p.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap, true)
 				provisioning.VerifyClustersState(p.T(), p.client, clusterIDs)
 			}
 		})
@@ -188,9 +185,10 @@ func (p *TfpProxyProvisioningTestSuite) TestTfpProxyProvisioning() {
 
 		p.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(p.T(), p.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
+			defer cleanup.Cleanup(p.T(), terraformOptions, terraformOptions.TerraformDir)
 
-			clusterIDs := provisioning.Provision(p.T(), p.client, p.rancherConfig, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, false)
+			clusterIDs := provisioning.Provision(p.T(), p.client, p.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap, false)
 			provisioning.VerifyClustersState(p.T(), p.client, clusterIDs)
 		})
 	}
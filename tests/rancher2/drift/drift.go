@@ -0,0 +1,98 @@
+package drift
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/extensions/clusters"
+	waitState "github.com/rancher/tfp-automation/framework/wait/state"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	namespace = "fleet-default"
+
+	driftEnvVarName  = "TFP_DRIFT"
+	driftEnvVarValue = "true"
+	privilegedPSACT  = "rancher-privileged"
+	restrictedPSACT  = "rancher-restricted"
+
+	agentEnvVarsAttribute      = "agent_env_vars"
+	kubernetesVersionAttribute = "kubernetes_version"
+	machinePoolsAttribute      = "rke_config[0].machine_pools"
+	psactAttribute             = "default_pod_security_admission_configuration_template_name"
+)
+
+// declaredState holds the values of the cluster fields the drift tests change outside of Terraform.
+type declaredState struct {
+	quantity     int32
+	agentEnvVars []rkev1.EnvVar
+	psact        string
+}
+
+// driftCluster changes the quantity of the first machine pool, the agent env vars and the PSACT of the cluster
+// through the Rancher API, outside of Terraform. It returns the values Terraform declared for them.
+func driftCluster(t *testing.T, client *rancher.Client, clusterName, clusterID string) declaredState {
+	cluster, clusterObject, err := clusters.GetProvisioningClusterByName(client, clusterName, namespace)
+	require.NoError(t, err)
+	require.NotEmpty(t, cluster.Spec.RKEConfig.MachinePools)
+
+	declared := declaredState{
+		quantity:     *cluster.Spec.RKEConfig.MachinePools[0].Quantity,
+		agentEnvVars: cluster.Spec.AgentEnvVars,
+		psact:        cluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName,
+	}
+
+	quantity := declared.quantity + 1
+	cluster.Spec.RKEConfig.MachinePools[0].Quantity = &quantity
+	cluster.Spec.AgentEnvVars = append(cluster.Spec.AgentEnvVars, rkev1.EnvVar{Name: driftEnvVarName, Value: driftEnvVarValue})
+
+	cluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName = privilegedPSACT
+	if declared.psact == privilegedPSACT {
+		cluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName = restrictedPSACT
+	}
+
+	logrus.Infof("Changing machine pool %s quantity to %d, adding agent env var %s and setting PSACT to %s outside of Terraform...",
+		cluster.Spec.RKEConfig.MachinePools[0].Name, quantity, driftEnvVarName, cluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName)
+
+	_, err = clusters.UpdateK3SRKE2Cluster(client, clusterObject, cluster)
+	require.NoError(t, err)
+
+	err = waitState.IsActiveCluster(client, clusterID)
+	require.NoError(t, err)
+
+	err = waitState.AreNodesActive(client, clusterID)
+	require.NoError(t, err)
+
+	return declared
+}
+
+// driftKubernetesVersion upgrades the Kubernetes version of the cluster to the given version through the Rancher
+// API, outside of Terraform.
+func driftKubernetesVersion(t *testing.T, client *rancher.Client, clusterName, clusterID, kubernetesVersion string) {
+	cluster, clusterObject, err := clusters.GetProvisioningClusterByName(client, clusterName, namespace)
+	require.NoError(t, err)
+	require.NotEqual(t, kubernetesVersion, cluster.Spec.KubernetesVersion, "the cluster already runs the upgraded Kubernetes version")
+
+	logrus.Infof("Upgrading Kubernetes version to %s outside of Terraform...", kubernetesVersion)
+
+	cluster.Spec.KubernetesVersion = kubernetesVersion
+
+	_, err = clusters.UpdateK3SRKE2Cluster(client, clusterObject, cluster)
+	require.NoError(t, err)
+
+	err = clusters.WaitClusterToBeUpgraded(client, clusterID)
+	require.NoError(t, err)
+}
+
+// verifyDeclaredState verifies that the fields changed by driftCluster are back to the values Terraform declared.
+func verifyDeclaredState(t *testing.T, client *rancher.Client, clusterName string, declared declaredState) {
+	cluster, _, err := clusters.GetProvisioningClusterByName(client, clusterName, namespace)
+	require.NoError(t, err)
+
+	require.Equal(t, declared.quantity, *cluster.Spec.RKEConfig.MachinePools[0].Quantity)
+	require.ElementsMatch(t, declared.agentEnvVars, cluster.Spec.AgentEnvVars)
+	require.Equal(t, declared.psact, cluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName)
+}
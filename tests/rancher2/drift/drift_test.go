@@ -0,0 +1,134 @@
+package drift
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	set "github.com/rancher/tfp-automation/framework/set"
+	"github.com/rancher/tfp-automation/framework/set/defaults"
+	"github.com/rancher/tfp-automation/framework/set/provisioning/nodedriver/rke2k3s"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type DriftTestSuite struct {
+	suite.Suite
+	client          *rancher.Client
+	session         *session.Session
+	cattleConfig    map[string]any
+	rancherConfig   *rancher.Config
+	terraformConfig *config.TerraformConfig
+	terratestConfig *config.TerratestConfig
+}
+
+func (d *DriftTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	d.session = testSession
+
+	d.cattleConfig = framework.LoadCattleConfig(d.T())
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(d.T(), err)
+
+	d.client = client
+
+	configMap, err := provisioning.UniquifyTerraform([]map[string]any{d.cattleConfig})
+	require.NoError(d.T(), err)
+
+	d.cattleConfig = configMap[0]
+	d.rancherConfig, d.terraformConfig, d.terratestConfig = config.LoadTFPConfigs(d.cattleConfig)
+
+	err = config.Validate(d.terraformConfig.Module, d.rancherConfig, d.terraformConfig, d.terratestConfig)
+	require.NoError(d.T(), err)
+
+	if !slices.Contains(rke2k3s.NodeDriverModules(), d.terraformConfig.Module) {
+		d.T().Skipf("Drift tests only support node driver RKE2/K3s modules, got %s", d.terraformConfig.Module)
+	}
+
+	provisioning.GetK8sVersion(d.T(), d.client, d.terratestConfig, d.terraformConfig, configs.SecondHighestVersion, configMap)
+}
+
+func (d *DriftTestSuite) TestTfpDrift() {
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+
+	tests := []struct {
+		name      string
+		nodeRoles []config.Nodepool
+	}{
+		{"3 nodes - 1 role per node " + config.StandardClientName.String(), nodeRolesDedicated},
+	}
+
+	for _, tt := range tests {
+		terratestConfig := *d.terratestConfig
+		terratestConfig.Nodepools = tt.nodeRoles
+
+		tt.name = tt.name + " Module: " + d.terraformConfig.Module + " Kubernetes version: " + d.terratestConfig.KubernetesVersion
+
+		testUser, testPassword := configs.CreateTestCredentials()
+
+		d.Run((tt.name), func() {
+			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
+			terraformOptions := framework.Setup(d.T(), d.terraformConfig, d.terratestConfig, keyPath)
+			defer cleanup.Cleanup(d.T(), terraformOptions, terraformOptions.TerraformDir)
+
+			adminClient, err := provisioning.FetchAdminClient(d.T(), d.client)
+			require.NoError(d.T(), err)
+
+			configMap := []map[string]any{d.cattleConfig}
+			operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodeRoles, configMap[0])
+
+			clusterIDs := provisioning.Provision(d.T(), d.client, d.rancherConfig, d.terraformConfig, &terratestConfig, testUser, testPassword, terraformOptions, configMap, false)
+			provisioning.VerifyClustersState(d.T(), adminClient, clusterIDs)
+
+			clusterName := d.terraformConfig.ResourcePrefix
+			address := defaults.ClusterV2 + "." + clusterName
+
+			declared := driftCluster(d.T(), d.client, clusterName, clusterIDs[0])
+
+			plan := provisioning.TerraformPlanStruct(d.T(), terraformOptions)
+			provisioning.RequireDrift(d.T(), plan, address, machinePoolsAttribute, agentEnvVarsAttribute, psactAttribute)
+
+			terraform.Apply(d.T(), terraformOptions)
+			provisioning.RequireEmptyPlan(d.T(), provisioning.TerraformPlan(d.T(), terraformOptions))
+			provisioning.VerifyClustersState(d.T(), adminClient, clusterIDs)
+			verifyDeclaredState(d.T(), d.client, clusterName, declared)
+
+			provisioning.DefaultUpgradedK8sVersion(d.T(), d.client, &terratestConfig, d.terraformConfig, configMap)
+			_, _, upgraded := config.LoadTFPConfigs(configMap[0])
+
+			driftKubernetesVersion(d.T(), d.client, clusterName, clusterIDs[0], upgraded.KubernetesVersion)
+
+			plan = provisioning.TerraformPlanStruct(d.T(), terraformOptions)
+			provisioning.RequireDrift(d.T(), plan, address, kubernetesVersionAttribute)
+
+			// Rancher does not allow Kubernetes downgrades, so the declared version is moved to the drifted one instead.
+			_, err = set.ConfigTF(terraformOptions.TerraformDir, d.T().Name(), testUser, testPassword, "", configMap, false)
+			require.NoError(d.T(), err)
+
+			terraform.Apply(d.T(), terraformOptions)
+			provisioning.RequireEmptyPlan(d.T(), provisioning.TerraformPlan(d.T(), terraformOptions))
+			provisioning.VerifyClustersState(d.T(), adminClient, clusterIDs)
+			provisioning.VerifyKubernetesVersion(d.T(), d.client, clusterIDs[0], upgraded.KubernetesVersion, d.terraformConfig.Module)
+		})
+	}
+
+	if d.terratestConfig.LocalQaseReporting {
+		qase.ReportTest()
+	}
+}
+
+func TestTfpDriftTestSuite(t *testing.T) {
+	suite.Run(t, new(DriftTestSuite))
+}
@@ -0,0 +1,89 @@
+package importing
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/defaults/modules"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportTestSuite struct {
+	suite.Suite
+	client          *rancher.Client
+	session         *session.Session
+	cattleConfig    map[string]any
+	rancherConfig   *rancher.Config
+	terraformConfig *config.TerraformConfig
+	terratestConfig *config.TerratestConfig
+}
+
+func (i *ImportTestSuite) TearDownSuite() {
+	i.session.Cleanup()
+}
+
+func (i *ImportTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	i.session = testSession
+
+	i.cattleConfig = framework.LoadCattleConfig(i.T())
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(i.T(), err)
+
+	i.client = client
+
+	configMap, err := provisioning.UniquifyTerraform([]map[string]any{i.cattleConfig})
+	require.NoError(i.T(), err)
+
+	i.cattleConfig = configMap[0]
+	i.rancherConfig, i.terraformConfig, i.terratestConfig = config.LoadTFPConfigs(i.cattleConfig)
+
+	err = config.Validate(modules.Imported, i.rancherConfig, i.terraformConfig, i.terratestConfig)
+	require.NoError(i.T(), err)
+}
+
+func (i *ImportTestSuite) TestTfpImport() {
+	tests := []struct {
+		name string
+	}{
+		{"Import " + config.StandardClientName.String()},
+	}
+
+	for _, tt := range tests {
+		testUser, testPassword := configs.CreateTestCredentials()
+
+		i.Run((tt.name), func() {
+			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
+			terraformOptions := framework.Setup(i.T(), i.terraformConfig, i.terratestConfig, keyPath)
+			defer cleanup.Cleanup(i.T(), terraformOptions, terraformOptions.TerraformDir)
+
+			configMap := []map[string]any{i.cattleConfig}
+
+			clusterIDs := provisioning.ImportResources(i.T(), i.client, testUser, testPassword, terraformOptions, configMap)
+			require.Len(i.T(), clusterIDs, len(configMap))
+
+			terraform.Apply(i.T(), terraformOptions)
+			provisioning.RequireEmptyPlan(i.T(), provisioning.TerraformPlan(i.T(), terraformOptions))
+		})
+	}
+
+	if i.terratestConfig.LocalQaseReporting {
+		qase.ReportTest()
+	}
+}
+
+func TestTfpImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
@@ -1,13 +1,10 @@
 package nodescaling
 
 import (
-	"os"
 	"testing"
 	"time"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
-	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/shepherd/pkg/session"
 	"github.com/rancher/tfp-automation/config"
@@ -24,34 +21,34 @@ import (
 
 type ScaleHostedTestSuite struct {
 	suite.Suite
-	client           *rancher.Client
-	session          *session.Session
-	cattleConfig     map[string]any
-	rancherConfig    *rancher.Config
-	terraformConfig  *config.TerraformConfig
-	terratestConfig  *config.TerratestConfig
-	terraformOptions *terraform.Options
+	client          *rancher.Client
+	session         *session.Session
+	cattleConfig    map[string]any
+	rancherConfig   *rancher.Config
+	terraformConfig *config.TerraformConfig
+	terratestConfig *config.TerratestConfig
 }
 
 func (s *ScaleHostedTestSuite) SetupSuite() {
 	testSession := session.NewSession()
 	s.session = testSession
 
+	s.cattleConfig = framework.LoadCattleConfig(s.T())
+
 	client, err := rancher.NewClient("", testSession)
 	require.NoError(s.T(), err)
 
 	s.client = client
 
-	s.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
 	configMap, err := provisioning.UniquifyTerraform([]map[string]any{s.cattleConfig})
 	require.NoError(s.T(), err)
 
 	s.cattleConfig = configMap[0]
 	s.rancherConfig, s.terraformConfig, s.terratestConfig = config.LoadTFPConfigs(s.cattleConfig)
 
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-	terraformOptions := framework.Setup(s.T(), s.terraformConfig, s.terratestConfig, keyPath)
-	s.terraformOptions = terraformOptions
+	err = config.Validate(s.terraformConfig.Module, s.rancherConfig, s.terraformConfig, s.terratestConfig)
+	require.NoError(s.T(), err)
+
 }
 
 func (s *ScaleHostedTestSuite) TestTfpScaleHosted() {
@@ -68,34 +65,35 @@ func (s *ScaleHostedTestSuite) TestTfpScaleHosted() {
 
 		s.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(s.T(), s.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(s.T(), s.terraformConfig, s.terratestConfig, keyPath)
+			defer cleanup.Cleanup(s.T(), terraformOptions, terraformOptions.TerraformDir)
 
 			adminClient, err := provisioning.FetchAdminClient(s.T(), s.client)
 			require.NoError(s.T(), err)
 
 			configMap := []map[string]any{s.cattleConfig}
 
-			clusterIDs := provisioning.Provision(s.T(), s.client, s.rancherConfig, s.terraformConfig, s.terratestConfig, testUser, testPassword, s.terraformOptions, configMap, false)
+			clusterIDs := provisioning.Provision(s.T(), s.client, s.rancherConfig, s.terraformConfig, s.terratestConfig, testUser, testPassword, terraformOptions, configMap, false)
 			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
 			provisioning.VerifyWorkloads(s.T(), adminClient, clusterIDs)
 
 			operations.ReplaceValue([]string{"terratest", "nodepools"}, s.terratestConfig.ScalingInput.ScaledUpNodepools, configMap[0])
 
-			provisioning.Scale(s.T(), s.client, s.rancherConfig, s.terraformConfig, s.terratestConfig, testUser, testPassword, s.terraformOptions, configMap)
+			provisioning.Scale(s.T(), s.client, s.rancherConfig, s.terraformConfig, s.terratestConfig, testUser, testPassword, terraformOptions, configMap)
 
 			time.Sleep(4 * time.Minute)
 
 			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
-			provisioning.VerifyNodeCount(s.T(), s.client, s.terraformConfig.ResourcePrefix, s.terraformConfig, s.terratestConfig.ScalingInput.ScaledUpNodeCount)
+			provisioning.VerifyNodeCount(s.T(), s.client, clusterIDs[0], s.terraformConfig, s.terratestConfig.ScalingInput.ScaledUpNodeCount)
 
 			operations.ReplaceValue([]string{"terratest", "nodepools"}, s.terratestConfig.ScalingInput.ScaledDownNodepools, configMap[0])
 
-			provisioning.Scale(s.T(), s.client, s.rancherConfig, s.terraformConfig, s.terratestConfig, testUser, testPassword, s.terraformOptions, configMap)
+			provisioning.Scale(s.T(), s.client, s.rancherConfig, s.terraformConfig, s.terratestConfig, testUser, testPassword, terraformOptions, configMap)
 
 			time.Sleep(4 * time.Minute)
 
 			provisioning.VerifyClustersState(s.T(), adminClient, clusterIDs)
-			provisioning.VerifyNodeCount(s.T(), s.client, s.terraformConfig.ResourcePrefix, s.terraformConfig, s.terratestConfig.ScalingInput.ScaledDownNodeCount)
+			provisioning.VerifyNodeCount(s.T(), s.client, clusterIDs[0], s.terraformConfig, s.terratestConfig.ScalingInput.ScaledDownNodeCount)
 		})
 	}
 
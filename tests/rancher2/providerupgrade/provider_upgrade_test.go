@@ -0,0 +1,177 @@
+package providerupgrade
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/rancher/shepherd/clients/rancher"
+	"github.com/rancher/shepherd/pkg/config/operations"
+	"github.com/rancher/shepherd/pkg/session"
+	"github.com/rancher/tfp-automation/config"
+	"github.com/rancher/tfp-automation/defaults/clustertypes"
+	"github.com/rancher/tfp-automation/defaults/configs"
+	"github.com/rancher/tfp-automation/defaults/keypath"
+	"github.com/rancher/tfp-automation/framework"
+	"github.com/rancher/tfp-automation/framework/cleanup"
+	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	qase "github.com/rancher/tfp-automation/pipeline/qase/results"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProviderUpgradeTestSuite struct {
+	suite.Suite
+	client          *rancher.Client
+	session         *session.Session
+	cattleConfig    map[string]any
+	rancherConfig   *rancher.Config
+	terraformConfig *config.TerraformConfig
+	terratestConfig *config.TerratestConfig
+	hosted          bool
+}
+
+func (p *ProviderUpgradeTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	p.session = testSession
+
+	p.cattleConfig = framework.LoadCattleConfig(p.T())
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(p.T(), err)
+
+	p.client = client
+
+	configMap, err := provisioning.UniquifyTerraform([]map[string]any{p.cattleConfig})
+	require.NoError(p.T(), err)
+
+	p.cattleConfig = configMap[0]
+	p.rancherConfig, p.terraformConfig, p.terratestConfig = config.LoadTFPConfigs(p.cattleConfig)
+
+	err = config.Validate(p.terraformConfig.Module, p.rancherConfig, p.terraformConfig, p.terratestConfig)
+	require.NoError(p.T(), err)
+
+	require.NotNil(p.T(), p.terratestConfig.UpgradedProviders, "terratest.upgradedProviders is required")
+
+	provisioning.IgnoreUpgradedProviderVersions(p.terratestConfig, p.cattleConfig)
+	p.rancherConfig, p.terraformConfig, p.terratestConfig = config.LoadTFPConfigs(p.cattleConfig)
+
+	p.hosted = slices.Contains([]string{clustertypes.AKS, clustertypes.EKS, clustertypes.GKE}, p.terraformConfig.Module)
+	if !p.hosted {
+		provisioning.GetK8sVersion(p.T(), p.client, p.terratestConfig, p.terraformConfig, configs.SecondHighestVersion, configMap)
+	}
+}
+
+func (p *ProviderUpgradeTestSuite) TestTfpProviderUpgrade() {
+	nodeRolesDedicated := []config.Nodepool{config.EtcdNodePool, config.ControlPlaneNodePool, config.WorkerNodePool}
+	scaleUpRolesDedicated := []config.Nodepool{config.ScaleUpEtcdNodePool, config.ScaleUpControlPlaneNodePool, config.ScaleUpWorkerNodePool}
+
+	tests := []struct {
+		name             string
+		nodeRoles        []config.Nodepool
+		scaleUpNodeRoles []config.Nodepool
+	}{
+		{"3 nodes - 1 role per node -> 8 nodes " + config.StandardClientName.String(), nodeRolesDedicated, scaleUpRolesDedicated},
+	}
+
+	for _, tt := range tests {
+		terratestConfig := *p.terratestConfig
+		if !p.hosted {
+			terratestConfig.Nodepools = tt.nodeRoles
+		}
+
+		var scaledUpCount int64
+		for _, scaleUpNodepool := range tt.scaleUpNodeRoles {
+			scaledUpCount += scaleUpNodepool.Quantity
+		}
+
+		if p.hosted {
+			tt.name = config.StandardClientName.String()
+		}
+
+		tt.name = tt.name + " Module: " + p.terraformConfig.Module + " Kubernetes version: " + p.terratestConfig.KubernetesVersion
+
+		testUser, testPassword := configs.CreateTestCredentials()
+
+		p.Run((tt.name), func() {
+			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
+			terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
+			defer cleanup.Cleanup(p.T(), terraformOptions, terraformOptions.TerraformDir)
+
+			adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
+			require.NoError(p.T(), err)
+
+			// The providers and nodepools are rewritten during the test, so each test case starts from its own copy.
+			cattleConfig, err := operations.DeepCopyMap(p.cattleConfig)
+			require.NoError(p.T(), err)
+
+			configMap := []map[string]any{cattleConfig}
+			if !p.hosted {
+				operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.nodeRoles, configMap[0])
+			}
+
+			clusterIDs := provisioning.Provision(p.T(), p.client, p.rancherConfig, p.terraformConfig, &terratestConfig, testUser, testPassword, terraformOptions, configMap, false)
+			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+
+			provisioning.UpgradeProviders(p.T(), &terratestConfig, testUser, testPassword, "", terraformOptions, configMap, false)
+			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+
+			if p.hosted {
+				p.scaleHosted(&terratestConfig, testUser, testPassword, terraformOptions, configMap, clusterIDs)
+			} else {
+				operations.ReplaceValue([]string{"terratest", "nodepools"}, tt.scaleUpNodeRoles, configMap[0])
+
+				provisioning.Scale(p.T(), p.client, p.rancherConfig, p.terraformConfig, &terratestConfig, testUser, testPassword, terraformOptions, configMap)
+
+				time.Sleep(2 * time.Minute)
+
+				provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+				provisioning.VerifyNodeCount(p.T(), p.client, clusterIDs[0], p.terraformConfig, scaledUpCount)
+			}
+
+			if p.hosted && terratestConfig.UpgradedKubernetesVersion == "" {
+				logrus.Infof("Skipping the Kubernetes upgrade, as upgradedKubernetesVersion is not set for the hosted module %s.", p.terraformConfig.Module)
+				return
+			}
+
+			provisioning.KubernetesUpgrade(p.T(), p.client, p.rancherConfig, p.terraformConfig, &terratestConfig, testUser, testPassword, terraformOptions, configMap)
+			_, _, upgraded := config.LoadTFPConfigs(configMap[0])
+
+			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+			provisioning.VerifyKubernetesVersion(p.T(), p.client, clusterIDs[0], upgraded.KubernetesVersion, p.terraformConfig.Module)
+		})
+	}
+
+	if p.terratestConfig.LocalQaseReporting {
+		qase.ReportTest()
+	}
+}
+
+// scaleHosted scales the hosted cluster to the scaled up nodepools of scalingInput, like the hosted scale tests do. The
+// step is skipped when none are set.
+func (p *ProviderUpgradeTestSuite) scaleHosted(terratestConfig *config.TerratestConfig, testUser, testPassword string,
+	terraformOptions *terraform.Options, configMap []map[string]any, clusterIDs []string) {
+	if len(terratestConfig.ScalingInput.ScaledUpNodepools) == 0 {
+		logrus.Infof("Skipping the scale, as scalingInput.scaledUpNodepools is not set for the hosted module %s.", p.terraformConfig.Module)
+		return
+	}
+
+	adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
+	require.NoError(p.T(), err)
+
+	operations.ReplaceValue([]string{"terratest", "nodepools"}, terratestConfig.ScalingInput.ScaledUpNodepools, configMap[0])
+
+	provisioning.Scale(p.T(), p.client, p.rancherConfig, p.terraformConfig, terratestConfig, testUser, testPassword, terraformOptions, configMap)
+
+	time.Sleep(4 * time.Minute)
+
+	provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
+	provisioning.VerifyNodeCount(p.T(), p.client, clusterIDs[0], p.terraformConfig, terratestConfig.ScalingInput.ScaledUpNodeCount)
+}
+
+func TestTfpProviderUpgradeTestSuite(t *testing.T) {
+	suite.Run(t, new(ProviderUpgradeTestSuite))
+}
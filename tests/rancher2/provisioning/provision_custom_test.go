@@ -1,13 +1,10 @@
 package provisioning
 
 import (
-	"os"
 	"strings"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
-	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/shepherd/pkg/session"
 	"github.com/rancher/tfp-automation/config"
@@ -25,34 +22,33 @@ import (
 
 type ProvisionCustomTestSuite struct {
 	suite.Suite
-	client           *rancher.Client
-	session          *session.Session
-	cattleConfig     map[string]any
-	rancherConfig    *rancher.Config
-	terraformConfig  *config.TerraformConfig
-	terratestConfig  *config.TerratestConfig
-	terraformOptions *terraform.Options
+	client          *rancher.Client
+	session         *session.Session
+	cattleConfig    map[string]any
+	rancherConfig   *rancher.Config
+	terraformConfig *config.TerraformConfig
+	terratestConfig *config.TerratestConfig
 }
 
 func (p *ProvisionCustomTestSuite) SetupSuite() map[string]any {
 	testSession := session.NewSession()
 	p.session = testSession
 
+	p.cattleConfig = framework.LoadCattleConfig(p.T())
+
 	client, err := rancher.NewClient("", testSession)
 	require.NoError(p.T(), err)
 
 	p.client = client
 
-	p.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
 	configMap, err := provisioning.UniquifyTerraform([]map[string]any{p.cattleConfig})
 	require.NoError(p.T(), err)
 
 	p.cattleConfig = configMap[0]
 	p.rancherConfig, p.terraformConfig, p.terratestConfig = config.LoadTFPConfigs(p.cattleConfig)
 
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-	terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
-	p.terraformOptions = terraformOptions
+	err = config.Validate(p.terraformConfig.Module, p.rancherConfig, p.terraformConfig, p.terratestConfig)
+	require.NoError(p.T(), err)
 
 	return p.cattleConfig
 }
@@ -87,16 +83,17 @@ func (p *ProvisionCustomTestSuite) TestTfpProvisionCustom() {
 
 		p.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(p.T(), p.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(p.T(), p.terraformConfig, p.terratestConfig, keyPath)
+			defer cleanup.Cleanup(p.T(), terraformOptions, terraformOptions.TerraformDir)
 
 			adminClient, err := provisioning.FetchAdminClient(p.T(), p.client)
 			require.NoError(p.T(), err)
 
-			clusterIDs := provisioning.Provision(p.T(), p.client, p.rancherConfig, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, false)
+			clusterIDs := provisioning.Provision(p.T(), p.client, p.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap, false)
 			provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
 
 			if strings.Contains(terraform.Module, modules.CustomEC2RKE2Windows) {
-				clusterIDs = provisioning.Provision(p.T(), p.client, p.rancherConfig, terraform, terratest, testUser, testPassword, p.terraformOptions, configMap, true)
+				clusterIDs = provisioning.Provision(p.T(), p.client, p.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap, true)
 				provisioning.VerifyClustersState(p.T(), adminClient, clusterIDs)
 			}
 		})
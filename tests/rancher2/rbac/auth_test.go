@@ -3,9 +3,7 @@ package rbac
 import (
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
-	ranchFrame "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/session"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/authproviders"
@@ -22,41 +20,25 @@ import (
 
 type AuthConfigTestSuite struct {
 	suite.Suite
-	client           *rancher.Client
-	session          *session.Session
-	rancherConfig    *rancher.Config
-	terraformConfig  *config.TerraformConfig
-	terratestConfig  *config.TerratestConfig
-	terraformOptions *terraform.Options
+	client          *rancher.Client
+	session         *session.Session
+	rancherConfig   *rancher.Config
+	terraformConfig *config.TerraformConfig
+	terratestConfig *config.TerratestConfig
 }
 
 func (r *AuthConfigTestSuite) SetupSuite() {
 	testSession := session.NewSession()
 	r.session = testSession
 
+	cattleConfig := framework.LoadCattleConfig(r.T())
+
 	client, err := rancher.NewClient("", testSession)
 	require.NoError(r.T(), err)
 
 	r.client = client
 
-	rancherConfig := new(rancher.Config)
-	ranchFrame.LoadConfig(configs.Rancher, rancherConfig)
-
-	r.rancherConfig = rancherConfig
-
-	terraformConfig := new(config.TerraformConfig)
-	ranchFrame.LoadConfig(config.TerraformConfigurationFileKey, terraformConfig)
-
-	r.terraformConfig = terraformConfig
-
-	terratestConfig := new(config.TerratestConfig)
-	ranchFrame.LoadConfig(config.TerratestConfigurationFileKey, terratestConfig)
-
-	r.terratestConfig = terratestConfig
-
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-	terraformOptions := framework.Setup(r.T(), r.terraformConfig, r.terratestConfig, keyPath)
-	r.terraformOptions = terraformOptions
+	r.rancherConfig, r.terraformConfig, r.terratestConfig = config.LoadTFPConfigs(cattleConfig)
 }
 
 func (r *AuthConfigTestSuite) TestTfpAuthConfig() {
@@ -78,9 +60,10 @@ func (r *AuthConfigTestSuite) TestTfpAuthConfig() {
 
 		r.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(r.T(), r.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(r.T(), r.terraformConfig, r.terratestConfig, keyPath)
+			defer cleanup.Cleanup(r.T(), terraformOptions, terraformOptions.TerraformDir)
 
-			rb.AuthConfig(r.T(), &authConfig, r.terraformOptions, testUser, testPassword)
+			rb.AuthConfig(r.T(), &authConfig, terraformOptions, testUser, testPassword)
 		})
 	}
 
@@ -105,9 +88,10 @@ func (r *AuthConfigTestSuite) TestTfpAuthConfigDynamicInput() {
 
 		r.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(r.T(), r.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(r.T(), r.terraformConfig, r.terratestConfig, keyPath)
+			defer cleanup.Cleanup(r.T(), terraformOptions, terraformOptions.TerraformDir)
 
-			rb.AuthConfig(r.T(), r.terraformConfig, r.terraformOptions, testUser, testPassword)
+			rb.AuthConfig(r.T(), r.terraformConfig, terraformOptions, testUser, testPassword)
 		})
 	}
 
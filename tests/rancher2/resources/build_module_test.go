@@ -1,15 +1,11 @@
 package tests
 
 import (
-	"os"
 	"testing"
 
 	"github.com/rancher/shepherd/clients/rancher"
-	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/tfp-automation/config"
-	"github.com/rancher/tfp-automation/defaults/keypath"
-	cleanup "github.com/rancher/tfp-automation/framework/cleanup"
-	"github.com/rancher/tfp-automation/framework/set/resources/rancher2"
+	"github.com/rancher/tfp-automation/framework"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -24,15 +20,15 @@ type BuildModuleTestSuite struct {
 }
 
 func (r *BuildModuleTestSuite) TestBuildModule() {
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-	defer cleanup.TFFilesCleanup(keyPath)
-
-	r.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	r.cattleConfig = framework.LoadCattleConfig(r.T())
 	r.rancherConfig, r.terraformConfig, r.terratestConfig = config.LoadTFPConfigs(r.cattleConfig)
 
+	err := config.Validate(r.terraformConfig.Module, r.rancherConfig, r.terraformConfig, r.terratestConfig)
+	require.NoError(r.T(), err)
+
 	configMap := []map[string]any{r.cattleConfig}
 
-	err := provisioning.BuildModule(r.T(), r.rancherConfig, r.terraformConfig, r.terratestConfig, configMap)
+	err = provisioning.BuildModule(r.T(), r.rancherConfig, r.terraformConfig, r.terratestConfig, configMap)
 	require.NoError(r.T(), err)
 }
 
@@ -3,7 +3,9 @@ package tests
 import (
 	"testing"
 
+	"github.com/rancher/tfp-automation/framework"
 	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -12,7 +14,10 @@ type CleanupTestSuite struct {
 }
 
 func (r *CleanupTestSuite) TestCleanup() {
-	provisioning.ForceCleanup(r.T())
+	cattleConfig := framework.LoadCattleConfig(r.T())
+
+	err := provisioning.ForceCleanup(r.T(), cattleConfig)
+	require.NoError(r.T(), err)
 }
 
 func TestCleanupTestSuite(t *testing.T) {
@@ -23,6 +23,7 @@ import (
 	"github.com/rancher/tfp-automation/defaults/clustertypes"
 	"github.com/rancher/tfp-automation/defaults/stevetypes"
 	framework "github.com/rancher/tfp-automation/framework/set"
+	"github.com/rancher/tfp-automation/tests/extensions/provisioning"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,8 +61,7 @@ func snapshotRestore(t *testing.T, client *rancher.Client, rancherConfig *ranche
 	terratestConfig *config.TerratestConfig, testUser, testPassword string, terraformOptions *terraform.Options, configMap []map[string]any) {
 	initialWorkloadName := namegen.AppendRandomString(initialWorkload)
 
-	clusterID, err := clusters.GetClusterIDByName(client, terraformConfig.ResourcePrefix)
-	require.NoError(t, err)
+	clusterID := provisioning.ClusterID(t, terraformOptions, terraformConfig.ResourcePrefix)
 
 	steveclient, err := client.Steve.ProxyDownstream(clusterID)
 	require.NoError(t, err)
@@ -96,7 +96,7 @@ func snapshotV2Prov(t *testing.T, client *rancher.Client, rancherConfig *rancher
 	terraformOptions *terraform.Options, configMap []map[string]any) (*apisV1.Cluster, string, *steveV1.SteveAPIObject, *steveV1.SteveAPIObject, error) {
 	terratestConfig.SnapshotInput.CreateSnapshot = true
 
-	_, err := framework.ConfigTF(nil, testUser, testPassword, "", configMap, false)
+	_, err := framework.ConfigTF(terraformOptions.TerraformDir, t.Name(), testUser, testPassword, "", configMap, false)
 	require.NoError(t, err)
 
 	terraform.Apply(t, terraformOptions)
@@ -131,7 +131,7 @@ func restoreV2Prov(t *testing.T, client *rancher.Client, rancherConfig *rancher.
 	terratestConfig.SnapshotInput.RestoreSnapshot = true
 	terratestConfig.SnapshotInput.SnapshotName = snapshotName
 
-	_, err := framework.ConfigTF(nil, testUser, testPassword, "", configMap, false)
+	_, err := framework.ConfigTF(terraformOptions.TerraformDir, t.Name(), testUser, testPassword, "", configMap, false)
 	require.NoError(t, err)
 
 	terraform.Apply(t, terraformOptions)
@@ -192,7 +192,7 @@ func upgradeCluster(t *testing.T, client *rancher.Client, rancherConfig *rancher
 	terratestConfig.KubernetesVersion = clusterObject.Spec.KubernetesVersion
 	terratestConfig.SnapshotInput.CreateSnapshot = false
 
-	_, err = framework.ConfigTF(nil, testUser, testPassword, "", configMap, false)
+	_, err = framework.ConfigTF(terraformOptions.TerraformDir, t.Name(), testUser, testPassword, "", configMap, false)
 	require.NoError(t, err)
 
 	terraform.Apply(t, terraformOptions)
@@ -1,13 +1,10 @@
 package upgrading
 
 import (
-	"os"
 	"testing"
 	"time"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/rancher/shepherd/clients/rancher"
-	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/session"
 	"github.com/rancher/tfp-automation/config"
 	"github.com/rancher/tfp-automation/defaults/configs"
@@ -23,34 +20,34 @@ import (
 
 type KubernetesUpgradeHostedTestSuite struct {
 	suite.Suite
-	client           *rancher.Client
-	session          *session.Session
-	cattleConfig     map[string]any
-	rancherConfig    *rancher.Config
-	terraformConfig  *config.TerraformConfig
-	terratestConfig  *config.TerratestConfig
-	terraformOptions *terraform.Options
+	client          *rancher.Client
+	session         *session.Session
+	cattleConfig    map[string]any
+	rancherConfig   *rancher.Config
+	terraformConfig *config.TerraformConfig
+	terratestConfig *config.TerratestConfig
 }
 
 func (k *KubernetesUpgradeHostedTestSuite) SetupSuite() {
 	testSession := session.NewSession()
 	k.session = testSession
 
+	k.cattleConfig = framework.LoadCattleConfig(k.T())
+
 	client, err := rancher.NewClient("", testSession)
 	require.NoError(k.T(), err)
 
 	k.client = client
 
-	k.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
 	configMap, err := provisioning.UniquifyTerraform([]map[string]any{k.cattleConfig})
 	require.NoError(k.T(), err)
 
 	k.cattleConfig = configMap[0]
 	k.rancherConfig, k.terraformConfig, k.terratestConfig = config.LoadTFPConfigs(k.cattleConfig)
 
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-	terraformOptions := framework.Setup(k.T(), k.terraformConfig, k.terratestConfig, keyPath)
-	k.terraformOptions = terraformOptions
+	err = config.Validate(k.terraformConfig.Module, k.rancherConfig, k.terraformConfig, k.terratestConfig)
+	require.NoError(k.T(), err)
+
 }
 
 func (k *KubernetesUpgradeHostedTestSuite) TestTfpKubernetesUpgradeHosted() {
@@ -67,18 +64,19 @@ func (k *KubernetesUpgradeHostedTestSuite) TestTfpKubernetesUpgradeHosted() {
 
 		k.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(k.T(), k.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(k.T(), k.terraformConfig, k.terratestConfig, keyPath)
+			defer cleanup.Cleanup(k.T(), terraformOptions, terraformOptions.TerraformDir)
 
 			adminClient, err := provisioning.FetchAdminClient(k.T(), k.client)
 			require.NoError(k.T(), err)
 
 			configMap := []map[string]any{k.cattleConfig}
 
-			clusterIDs := provisioning.Provision(k.T(), k.client, k.rancherConfig, k.terraformConfig, k.terratestConfig, testUser, testPassword, k.terraformOptions, configMap, false)
+			clusterIDs := provisioning.Provision(k.T(), k.client, k.rancherConfig, k.terraformConfig, k.terratestConfig, testUser, testPassword, terraformOptions, configMap, false)
 			provisioning.VerifyClustersState(k.T(), adminClient, clusterIDs)
 			provisioning.VerifyWorkloads(k.T(), adminClient, clusterIDs)
 
-			provisioning.KubernetesUpgrade(k.T(), k.client, k.rancherConfig, k.terraformConfig, k.terratestConfig, testUser, testPassword, k.terraformOptions, configMap)
+			provisioning.KubernetesUpgrade(k.T(), k.client, k.rancherConfig, k.terraformConfig, k.terratestConfig, testUser, testPassword, terraformOptions, configMap)
 
 			time.Sleep(4 * time.Minute)
 
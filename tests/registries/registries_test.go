@@ -1,7 +1,6 @@
 package registries
 
 import (
-	"os"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
@@ -9,7 +8,6 @@ import (
 	"github.com/rancher/shepherd/clients/rancher"
 	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
 	"github.com/rancher/shepherd/extensions/token"
-	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/shepherd/pkg/session"
 	"github.com/rancher/tfp-automation/config"
@@ -34,7 +32,6 @@ type TfpRegistriesTestSuite struct {
 	terraformConfig            *config.TerraformConfig
 	terratestConfig            *config.TerratestConfig
 	standaloneTerraformOptions *terraform.Options
-	terraformOptions           *terraform.Options
 	authRegistry               string
 	nonAuthRegistry            string
 	globalRegistry             string
@@ -46,9 +43,12 @@ func (r *TfpRegistriesTestSuite) TearDownSuite() {
 }
 
 func (r *TfpRegistriesTestSuite) SetupSuite() {
-	r.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	r.cattleConfig = framework.LoadCattleConfig(r.T())
 	r.rancherConfig, r.terraformConfig, r.terratestConfig = config.LoadTFPConfigs(r.cattleConfig)
 
+	err := config.ValidateStandalone(r.terraformConfig)
+	require.NoError(r.T(), err)
+
 	keyPath := rancher2.SetKeyPath(keypath.RegistryKeyPath)
 	standaloneTerraformOptions := framework.Setup(r.T(), r.terraformConfig, r.terratestConfig, keyPath)
 	r.standaloneTerraformOptions = standaloneTerraformOptions
@@ -65,7 +65,7 @@ func (r *TfpRegistriesTestSuite) TfpSetupSuite() map[string]any {
 	testSession := session.NewSession()
 	r.session = testSession
 
-	r.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	r.cattleConfig = framework.LoadCattleConfig(r.T())
 	configMap, err := provisioning.UniquifyTerraform([]map[string]any{r.cattleConfig})
 	require.NoError(r.T(), err)
 
@@ -97,10 +97,6 @@ func (r *TfpRegistriesTestSuite) TfpSetupSuite() map[string]any {
 	err = pipeline.PostRancherInstall(r.client, r.client.RancherConfig.AdminPassword)
 	require.NoError(r.T(), err)
 
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-	terraformOptions := framework.Setup(r.T(), r.terraformConfig, r.terratestConfig, keyPath)
-	r.terraformOptions = terraformOptions
-
 	return r.cattleConfig
 }
 
@@ -143,9 +139,10 @@ func (r *TfpRegistriesTestSuite) TestTfpGlobalRegistry() {
 
 		r.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(r.T(), r.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(r.T(), r.terraformConfig, r.terratestConfig, keyPath)
+			defer cleanup.Cleanup(r.T(), terraformOptions, terraformOptions.TerraformDir)
 
-			clusterIDs := provisioning.Provision(r.T(), r.client, r.rancherConfig, terraform, terratest, testUser, testPassword, r.terraformOptions, configMap, false)
+			clusterIDs := provisioning.Provision(r.T(), r.client, r.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap, false)
 			provisioning.VerifyClustersState(r.T(), r.client, clusterIDs)
 			provisioning.VerifyRegistry(r.T(), r.client, clusterIDs[0], terraform)
 		})
@@ -193,9 +190,10 @@ func (r *TfpRegistriesTestSuite) TestTfpAuthenticatedRegistry() {
 
 		r.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(r.T(), r.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(r.T(), r.terraformConfig, r.terratestConfig, keyPath)
+			defer cleanup.Cleanup(r.T(), terraformOptions, terraformOptions.TerraformDir)
 
-			clusterIDs := provisioning.Provision(r.T(), r.client, r.rancherConfig, terraform, terratest, testUser, testPassword, r.terraformOptions, configMap, false)
+			clusterIDs := provisioning.Provision(r.T(), r.client, r.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap, false)
 			provisioning.VerifyClustersState(r.T(), r.client, clusterIDs)
 			provisioning.VerifyRegistry(r.T(), r.client, clusterIDs[0], terraform)
 		})
@@ -245,9 +243,10 @@ func (r *TfpRegistriesTestSuite) TestTfpNonAuthenticatedRegistry() {
 
 		r.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(r.T(), r.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(r.T(), r.terraformConfig, r.terratestConfig, keyPath)
+			defer cleanup.Cleanup(r.T(), terraformOptions, terraformOptions.TerraformDir)
 
-			clusterIDs := provisioning.Provision(r.T(), r.client, r.rancherConfig, terraform, terratest, testUser, testPassword, r.terraformOptions, configMap, false)
+			clusterIDs := provisioning.Provision(r.T(), r.client, r.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap, false)
 			provisioning.VerifyClustersState(r.T(), r.client, clusterIDs)
 			provisioning.VerifyRegistry(r.T(), r.client, clusterIDs[0], terraform)
 		})
@@ -1,7 +1,6 @@
 package sanity
 
 import (
-	"os"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
@@ -9,7 +8,6 @@ import (
 	"github.com/rancher/shepherd/clients/rancher"
 	management "github.com/rancher/shepherd/clients/rancher/generated/management/v3"
 	"github.com/rancher/shepherd/extensions/token"
-	shepherdConfig "github.com/rancher/shepherd/pkg/config"
 	"github.com/rancher/shepherd/pkg/config/operations"
 	"github.com/rancher/shepherd/pkg/session"
 	"github.com/rancher/tfp-automation/config"
@@ -34,7 +32,6 @@ type TfpSanityTestSuite struct {
 	terraformConfig            *config.TerraformConfig
 	terratestConfig            *config.TerratestConfig
 	standaloneTerraformOptions *terraform.Options
-	terraformOptions           *terraform.Options
 }
 
 func (t *TfpSanityTestSuite) TearDownSuite() {
@@ -43,14 +40,17 @@ func (t *TfpSanityTestSuite) TearDownSuite() {
 }
 
 func (t *TfpSanityTestSuite) SetupSuite() {
-	t.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	t.cattleConfig = framework.LoadCattleConfig(t.T())
 	t.rancherConfig, t.terraformConfig, t.terratestConfig = config.LoadTFPConfigs(t.cattleConfig)
 
+	err := config.ValidateStandalone(t.terraformConfig)
+	require.NoError(t.T(), err)
+
 	keyPath := rancher2.SetKeyPath(keypath.SanityKeyPath)
 	standaloneTerraformOptions := framework.Setup(t.T(), t.terraformConfig, t.terratestConfig, keyPath)
 	t.standaloneTerraformOptions = standaloneTerraformOptions
 
-	err := resources.CreateMainTF(t.T(), t.standaloneTerraformOptions, keyPath, t.terraformConfig, t.terratestConfig)
+	err = resources.CreateMainTF(t.T(), t.standaloneTerraformOptions, keyPath, t.terraformConfig, t.terratestConfig)
 	require.NoError(t.T(), err)
 }
 
@@ -58,7 +58,7 @@ func (t *TfpSanityTestSuite) TfpSetupSuite() map[string]any {
 	testSession := session.NewSession()
 	t.session = testSession
 
-	t.cattleConfig = shepherdConfig.LoadConfigFromFile(os.Getenv(shepherdConfig.ConfigEnvironmentKey))
+	t.cattleConfig = framework.LoadCattleConfig(t.T())
 	configMap, err := provisioning.UniquifyTerraform([]map[string]any{t.cattleConfig})
 	require.NoError(t.T(), err)
 
@@ -90,10 +90,6 @@ func (t *TfpSanityTestSuite) TfpSetupSuite() map[string]any {
 	err = pipeline.PostRancherInstall(t.client, t.client.RancherConfig.AdminPassword)
 	require.NoError(t.T(), err)
 
-	keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-	terraformOptions := framework.Setup(t.T(), t.terraformConfig, t.terratestConfig, keyPath)
-	t.terraformOptions = terraformOptions
-
 	return t.cattleConfig
 }
 
@@ -131,9 +127,10 @@ func (t *TfpSanityTestSuite) TestTfpProvisioningSanity() {
 
 		t.Run((tt.name), func() {
 			keyPath := rancher2.SetKeyPath(keypath.RancherKeyPath)
-			defer cleanup.Cleanup(t.T(), t.terraformOptions, keyPath)
+			terraformOptions := framework.Setup(t.T(), t.terraformConfig, t.terratestConfig, keyPath)
+			defer cleanup.Cleanup(t.T(), terraformOptions, terraformOptions.TerraformDir)
 
-			clusterIDs := provisioning.Provision(t.T(), t.client, t.rancherConfig, terraform, terratest, testUser, testPassword, t.terraformOptions, configMap, false)
+			clusterIDs := provisioning.Provision(t.T(), t.client, t.rancherConfig, terraform, terratest, testUser, testPassword, terraformOptions, configMap, false)
 			provisioning.VerifyClustersState(t.T(), t.client, clusterIDs)
 		})
 	}